@@ -7,4 +7,43 @@ type Conf struct {
 	JWT struct{
 		Secret string `env:"JWT_SECRET"`
 	}
+	Sandbox struct {
+		Runtime     string `env:"SANDBOX_RUNTIME"`
+		GoImage     string `env:"SANDBOX_GO_IMAGE"`
+		PythonImage string `env:"SANDBOX_PYTHON_IMAGE"`
+	}
+	Mail struct {
+		Host     string `env:"SMTP_HOST"`
+		Port     string `env:"SMTP_PORT"`
+		Username string `env:"SMTP_USERNAME"`
+		Password string `env:"SMTP_PASSWORD"`
+		From     string `env:"MAIL_FROM"`
+	}
+	Notification struct {
+		BaseURL         string `env:"APP_BASE_URL"`
+		ReminderDays    int    `env:"NOTIFY_REMINDER_DAYS"`
+		NudgeAfterHours int    `env:"NOTIFY_NUDGE_AFTER_HOURS"`
+	}
+	Adaptive struct {
+		TargetSE float64 `env:"ADAPTIVE_TARGET_SE"`
+		MaxItems int     `env:"ADAPTIVE_MAX_ITEMS"`
+	}
+	Jobs struct {
+		Workers                int `env:"JOB_WORKERS"`
+		ShutdownTimeoutSeconds int `env:"JOB_SHUTDOWN_TIMEOUT_SECONDS"`
+	}
+	Storage struct {
+		Driver      string `env:"STORAGE_DRIVER"`
+		LocalDir    string `env:"STORAGE_LOCAL_DIR"`
+		S3Endpoint  string `env:"S3_ENDPOINT"`
+		S3Region    string `env:"S3_REGION"`
+		S3Bucket    string `env:"S3_BUCKET"`
+		S3AccessKey string `env:"S3_ACCESS_KEY_ID"`
+		S3SecretKey string `env:"S3_SECRET_ACCESS_KEY"`
+	}
+	Documents struct {
+		MaxMegabytes    int    `env:"DOCUMENT_MAX_MB"`
+		MaxPerCandidate int    `env:"DOCUMENT_MAX_PER_CANDIDATE"`
+		ClamdAddr       string `env:"CLAMD_ADDR"`
+	}
 }
@@ -2,12 +2,17 @@ package middleware
 
 import (
 	"backend/app/config"
+	"errors"
 	"fmt"
+	"log"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
 type AuthConfig struct {
@@ -49,6 +54,53 @@ func AuthMiddleware() gin.HandlerFunc {
 
 				c.Set("userID", userIDStr)
 			}
+			if roleID, exists := claims["role_id"]; exists {
+				c.Set("roleID", fmt.Sprintf("%v", roleID))
+			}
+		}
+
+		c.Next()
+	}
+}
+
+
+// adminRoleID is the id of the "admin" row seeded by the roles migration.
+const adminRoleID = 1
+
+// roleDB is where AdminMiddleware looks up roles. It is set once at startup
+// by UseDatabase.
+var roleDB *pgxpool.Pool
+
+// UseDatabase gives AdminMiddleware the database to check roles against.
+func UseDatabase(db *pgxpool.Pool) {
+	roleDB = db
+}
+
+// AdminMiddleware only lets admins through. It must run after AuthMiddleware.
+// The role is read from the database rather than the token, so it cannot be
+// forged and a demoted admin loses access straight away.
+func AdminMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		userIDStr, exists := c.Get("userID")
+		userID, err := strconv.Atoi(fmt.Sprint(userIDStr))
+		if !exists || err != nil || roleDB == nil {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
+		}
+
+		var roleID *int32
+		err = roleDB.QueryRow(c, "SELECT role_id FROM users WHERE id = $1", int32(userID)).Scan(&roleID)
+		if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+			log.Printf("middleware: loading role of user %d: %v", userID, err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check permissions"})
+			c.Abort()
+			return
+		}
+		if err != nil || roleID == nil || *roleID != adminRoleID {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Admin access required"})
+			c.Abort()
+			return
 		}
 
 		c.Next()
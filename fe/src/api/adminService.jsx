@@ -1,7 +1,7 @@
 import api from "./api";
-export const getCandidateScores = async () => {
+export const getCandidateScores = async (filters = {}) => {
   try {
-    const response = await api.get('/self-assessment/candidate/scores');
+    const response = await api.get('/self-assessment/candidate/scores', { params: filters });
     return response;
   } catch (error) {
     console.error('Error fetching candidate scores:', error);
@@ -17,4 +17,587 @@ export const getCandidateDetails = async (params) => {
     console.error('Error fetching candidate details:', error);
     throw error;
   }
-};
\ No newline at end of file
+};
+
+export const compareCandidates = async (ids, jobProfileId) => {
+  try {
+    const params = { ids: ids.join(',') };
+    if (jobProfileId) {
+      params.job_profile_id = jobProfileId;
+    }
+    const response = await api.get('/candidates/compare', { params });
+    return response;
+  } catch (error) {
+    console.error('Error comparing candidates:', error);
+    throw error;
+  }
+};
+
+// Reports and exports are generated in the background: the request returns
+// a file to poll, which is downloaded once it is ready.
+const waitForCandidateFile = async (fileId, { intervalMs = 1000, timeoutMs = 120000 } = {}) => {
+  const deadline = Date.now() + timeoutMs;
+  while (Date.now() < deadline) {
+    const { data } = await api.get(`/candidates/files/${fileId}`);
+    if (data.status === 'ready') {
+      return api.get(`/candidates/files/${fileId}/download`, { responseType: 'blob' });
+    }
+    if (data.status === 'failed') {
+      throw new Error('File generation failed');
+    }
+    await new Promise((resolve) => setTimeout(resolve, intervalMs));
+  }
+  throw new Error('Timed out waiting for file');
+};
+
+export const downloadCandidateReport = async (userId) => {
+  try {
+    const response = await api.post(`/candidates/${userId}/report`);
+    return await waitForCandidateFile(response.data.file.id);
+  } catch (error) {
+    console.error('Error downloading candidate report:', error);
+    throw error;
+  }
+};
+
+export const exportCandidates = async (format = 'csv', filters = {}) => {
+  try {
+    const response = await api.post('/candidates/export', null, {
+      params: { ...filters, format }
+    });
+    return await waitForCandidateFile(response.data.file.id);
+  } catch (error) {
+    console.error('Error exporting candidates:', error);
+    throw error;
+  }
+};
+
+export const createBlueprint = async (blueprint) => {
+  try {
+    const response = await api.post('/self-assessment/blueprints', blueprint);
+    return response;
+  } catch (error) {
+    console.error('Error creating blueprint:', error);
+    throw error;
+  }
+};
+
+export const getBlueprints = async () => {
+  try {
+    const response = await api.get('/self-assessment/blueprints');
+    return response;
+  } catch (error) {
+    console.error('Error fetching blueprints:', error);
+    throw error;
+  }
+};
+
+export const getItemAnalysis = async (assessmentType = 'cognitive') => {
+  try {
+    const response = await api.get('/item-analysis', { params: { assessment_type: assessmentType } });
+    return response;
+  } catch (error) {
+    console.error('Error fetching item analysis:', error);
+    throw error;
+  }
+};
+
+export const createItemPair = async (pair) => {
+  try {
+    const response = await api.post('/self-assessment/item-pairs', pair);
+    return response;
+  } catch (error) {
+    console.error('Error creating item pair:', error);
+    throw error;
+  }
+};
+
+export const getItemPairs = async () => {
+  try {
+    const response = await api.get('/self-assessment/item-pairs');
+    return response;
+  } catch (error) {
+    console.error('Error fetching item pairs:', error);
+    throw error;
+  }
+};
+
+export const getSessionIntegrity = async (sessionId) => {
+  try {
+    const response = await api.get(`/proctoring/sessions/${sessionId}`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching session integrity:', error);
+    throw error;
+  }
+};
+
+export const setQuestionRubric = async (questionId, rubric) => {
+  try {
+    const response = await api.put(`/self-assessment/questions/${questionId}/rubric`, rubric);
+    return response;
+  } catch (error) {
+    console.error('Error saving rubric:', error);
+    throw error;
+  }
+};
+
+export const getGradingQueue = async (filters = {}) => {
+  try {
+    const response = await api.get('/self-assessment/grading/queue', { params: filters });
+    return response;
+  } catch (error) {
+    console.error('Error fetching grading queue:', error);
+    throw error;
+  }
+};
+
+export const getGradingItem = async (itemId) => {
+  try {
+    const response = await api.get(`/self-assessment/grading/items/${itemId}`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching grading item:', error);
+    throw error;
+  }
+};
+
+export const assignGrader = async (itemId, graderId) => {
+  try {
+    const response = await api.post(`/self-assessment/grading/items/${itemId}/assignments`, { grader_id: graderId });
+    return response;
+  } catch (error) {
+    console.error('Error assigning grader:', error);
+    throw error;
+  }
+};
+
+export const submitGrade = async (itemId, scores, comment) => {
+  try {
+    const response = await api.post(`/self-assessment/grading/items/${itemId}/grades`, { scores, comment });
+    return response;
+  } catch (error) {
+    console.error('Error submitting grade:', error);
+    throw error;
+  }
+};
+
+export const createCodingProblem = async (problem) => {
+  try {
+    const response = await api.post('/coding/problems', problem);
+    return response;
+  } catch (error) {
+    console.error('Error creating coding problem:', error);
+    throw error;
+  }
+};
+
+export const getCodingProblems = async () => {
+  try {
+    const response = await api.get('/coding/problems');
+    return response;
+  } catch (error) {
+    console.error('Error fetching coding problems:', error);
+    throw error;
+  }
+};
+
+export const setCodingProblemActive = async (problemId, active) => {
+  try {
+    const response = await api.put(`/coding/problems/${problemId}/active`, { active });
+    return response;
+  } catch (error) {
+    console.error('Error updating coding problem:', error);
+    throw error;
+  }
+};
+
+export const getCodingSessionSubmissions = async (sessionId) => {
+  try {
+    const response = await api.get(`/coding/sessions/${sessionId}/submissions`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching coding submissions:', error);
+    throw error;
+  }
+};
+
+export const setQuestionMappings = async (questionId, mappings) => {
+  try {
+    const response = await api.put(`/self-assessment/questions/${questionId}/mappings`, { mappings });
+    return response;
+  } catch (error) {
+    console.error('Error saving question mappings:', error);
+    throw error;
+  }
+};
+
+export const getTraitModels = async () => {
+  try {
+    const response = await api.get('/self-assessment/trait-models');
+    return response;
+  } catch (error) {
+    console.error('Error fetching trait models:', error);
+    throw error;
+  }
+};
+
+export const setTraitItem = async (questionId, facetId, reverseKeyed) => {
+  try {
+    const response = await api.put(`/self-assessment/questions/${questionId}/trait-item`, { facet_id: facetId, reverse_keyed: reverseKeyed });
+    return response;
+  } catch (error) {
+    console.error('Error saving trait item:', error);
+    throw error;
+  }
+};
+
+export const getValidityScales = async () => {
+  try {
+    const response = await api.get('/self-assessment/validity-scales');
+    return response;
+  } catch (error) {
+    console.error('Error fetching validity scales:', error);
+    throw error;
+  }
+};
+
+export const createValidityScale = async (scale) => {
+  try {
+    const response = await api.post('/self-assessment/validity-scales', scale);
+    return response;
+  } catch (error) {
+    console.error('Error creating validity scale:', error);
+    throw error;
+  }
+};
+
+export const setValidityItem = async (questionId, scaleId, keyedAnswers) => {
+  try {
+    const response = await api.put(`/self-assessment/questions/${questionId}/validity-item`, { scale_id: scaleId, keyed_answers: keyedAnswers });
+    return response;
+  } catch (error) {
+    console.error('Error saving validity item:', error);
+    throw error;
+  }
+};
+
+export const getQuestionTranslations = async (questionId) => {
+  try {
+    const response = await api.get(`/self-assessment/questions/${questionId}/translations`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching question translations:', error);
+    throw error;
+  }
+};
+
+export const setQuestionTranslation = async (questionId, locale, question, options) => {
+  try {
+    const response = await api.put(`/self-assessment/questions/${questionId}/translations/${locale}`, { question, options });
+    return response;
+  } catch (error) {
+    console.error('Error saving question translation:', error);
+    throw error;
+  }
+};
+
+export const setCategoryTranslation = async (categoryId, locale, description) => {
+  try {
+    const response = await api.put(`/self-assessment/categories/${categoryId}/translations/${locale}`, { description });
+    return response;
+  } catch (error) {
+    console.error('Error saving category translation:', error);
+    throw error;
+  }
+};
+
+export const grantAccommodation = async (accommodation) => {
+  try {
+    const response = await api.post('/accommodations', accommodation);
+    return response;
+  } catch (error) {
+    console.error('Error granting accommodation:', error);
+    throw error;
+  }
+};
+
+export const getCandidateAccommodations = async (userId) => {
+  try {
+    const response = await api.get(`/accommodations/candidates/${userId}`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching accommodations:', error);
+    throw error;
+  }
+};
+
+export const revokeAccommodation = async (accommodationId) => {
+  try {
+    const response = await api.post(`/accommodations/${accommodationId}/revoke`);
+    return response;
+  } catch (error) {
+    console.error('Error revoking accommodation:', error);
+    throw error;
+  }
+};
+
+export const createInvitation = async (invitation) => {
+  try {
+    const response = await api.post('/invitations', invitation);
+    return response;
+  } catch (error) {
+    console.error('Error creating invitation:', error);
+    throw error;
+  }
+};
+
+export const getInvitations = async (status) => {
+  try {
+    const response = await api.get('/invitations', { params: status ? { status } : {} });
+    return response;
+  } catch (error) {
+    console.error('Error fetching invitations:', error);
+    throw error;
+  }
+};
+
+export const getNotificationLog = async (filters = {}) => {
+  try {
+    const response = await api.get('/notifications/log', { params: filters });
+    return response;
+  } catch (error) {
+    console.error('Error fetching notification log:', error);
+    throw error;
+  }
+};
+
+export const getCandidateStage = async (userId) => {
+  try {
+    const response = await api.get(`/candidates/${userId}/stage`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching candidate stage:', error);
+    throw error;
+  }
+};
+
+export const setCandidateStage = async (userId, stage) => {
+  try {
+    const response = await api.put(`/candidates/${userId}/stage`, { stage });
+    return response;
+  } catch (error) {
+    console.error('Error updating candidate stage:', error);
+    throw error;
+  }
+};
+
+export const createWebhook = async (webhook) => {
+  try {
+    const response = await api.post('/webhooks', webhook);
+    return response;
+  } catch (error) {
+    console.error('Error creating webhook:', error);
+    throw error;
+  }
+};
+
+export const getWebhooks = async () => {
+  try {
+    const response = await api.get('/webhooks');
+    return response;
+  } catch (error) {
+    console.error('Error fetching webhooks:', error);
+    throw error;
+  }
+};
+
+export const updateWebhook = async (id, webhook) => {
+  try {
+    const response = await api.put(`/webhooks/${id}`, webhook);
+    return response;
+  } catch (error) {
+    console.error('Error updating webhook:', error);
+    throw error;
+  }
+};
+
+export const deleteWebhook = async (id) => {
+  try {
+    const response = await api.delete(`/webhooks/${id}`);
+    return response;
+  } catch (error) {
+    console.error('Error deleting webhook:', error);
+    throw error;
+  }
+};
+
+export const replayFailedWebhookDeliveries = async (id) => {
+  try {
+    const response = await api.post(`/webhooks/${id}/replay`);
+    return response;
+  } catch (error) {
+    console.error('Error replaying webhook deliveries:', error);
+    throw error;
+  }
+};
+
+export const getWebhookDeliveries = async (filters = {}) => {
+  try {
+    const response = await api.get('/webhooks/deliveries', { params: filters });
+    return response;
+  } catch (error) {
+    console.error('Error fetching webhook deliveries:', error);
+    throw error;
+  }
+};
+
+export const getWebhookDelivery = async (id) => {
+  try {
+    const response = await api.get(`/webhooks/deliveries/${id}`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching webhook delivery:', error);
+    throw error;
+  }
+};
+
+export const replayWebhookDelivery = async (id) => {
+  try {
+    const response = await api.post(`/webhooks/deliveries/${id}/replay`);
+    return response;
+  } catch (error) {
+    console.error('Error replaying webhook delivery:', error);
+    throw error;
+  }
+};
+
+export const getJobs = async (filters = {}) => {
+  try {
+    const response = await api.get('/jobs', { params: filters });
+    return response;
+  } catch (error) {
+    console.error('Error fetching jobs:', error);
+    throw error;
+  }
+};
+
+export const getJobStats = async () => {
+  try {
+    const response = await api.get('/jobs/stats');
+    return response;
+  } catch (error) {
+    console.error('Error fetching job stats:', error);
+    throw error;
+  }
+};
+
+export const getJob = async (id) => {
+  try {
+    const response = await api.get(`/jobs/${id}`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching job:', error);
+    throw error;
+  }
+};
+
+export const retryJob = async (id) => {
+  try {
+    const response = await api.post(`/jobs/${id}/retry`);
+    return response;
+  } catch (error) {
+    console.error('Error retrying job:', error);
+    throw error;
+  }
+};
+
+export const retryFailedJobs = async (kind) => {
+  try {
+    const response = await api.post('/jobs/retry', null, { params: kind ? { kind } : {} });
+    return response;
+  } catch (error) {
+    console.error('Error retrying failed jobs:', error);
+    throw error;
+  }
+};
+
+// Streams live dashboard events. EventSource cannot send the auth header, so
+// the stream is read with fetch and reconnects from the last event seen.
+// Returns a function that stops the stream.
+export const streamDashboardEvents = (onEvent) => {
+  const controller = new AbortController();
+  let lastEventId = '';
+
+  const connect = async () => {
+    const headers = { Authorization: `Bearer ${localStorage.getItem('token')}` };
+    if (lastEventId) {
+      headers['Last-Event-ID'] = lastEventId;
+    }
+    const response = await fetch(`${api.defaults.baseURL}/live/events`, {
+      headers,
+      signal: controller.signal,
+    });
+    if (!response.ok) {
+      throw new Error(`Stream responded ${response.status}`);
+    }
+    const reader = response.body.pipeThrough(new TextDecoderStream()).getReader();
+    let buffer = '';
+    for (;;) {
+      const { value, done } = await reader.read();
+      if (done) return;
+      buffer += value;
+      const messages = buffer.split('\n\n');
+      buffer = messages.pop();
+      messages.forEach((message) => {
+        const event = { id: '', type: '', data: '' };
+        message.split('\n').forEach((line) => {
+          if (line.startsWith('id: ')) event.id = line.slice(4);
+          else if (line.startsWith('event: ')) event.type = line.slice(7);
+          else if (line.startsWith('data: ')) event.data = line.slice(6);
+        });
+        if (event.id) {
+          lastEventId = event.id;
+          onEvent({ id: event.id, type: event.type, data: JSON.parse(event.data) });
+        }
+      });
+    }
+  };
+
+  const run = async () => {
+    while (!controller.signal.aborted) {
+      try {
+        await connect();
+      } catch (error) {
+        if (controller.signal.aborted) return;
+        console.error('Error streaming dashboard events:', error);
+      }
+      await new Promise((resolve) => setTimeout(resolve, 3000));
+    }
+  };
+  run();
+
+  return () => controller.abort();
+};
+
+export const getCandidateDocuments = async (candidateId) => {
+  try {
+    const response = await api.get(`/documents/candidate/${candidateId}`);
+    return response;
+  } catch (error) {
+    console.error('Error fetching candidate documents:', error);
+    throw error;
+  }
+};
+
+// The signed link works without the auth header, so it can be opened in a
+// new tab. It is relative to the API's base URL.
+export const getDocumentDownloadUrl = async (documentId) => {
+  try {
+    const response = await api.get(`/documents/${documentId}/url`);
+    return `${api.defaults.baseURL}${response.data.url}`;
+  } catch (error) {
+    console.error('Error fetching document download link:', error);
+    throw error;
+  }
+};
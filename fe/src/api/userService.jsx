@@ -40,16 +40,31 @@ export const register = (userData) => {
   return api.post("/sign-up", userData);
 };
 
-export const getSelfAssessmentBehavioral = () => {
-  return api.get("/self-assessment/question/behavioral");
+// Starts (or resumes) a randomized session; answers must be submitted with its session_id
+export const startAssessment = (type) => {
+  return api.post(`/self-assessment/start/${type}`);
 };
 
-export const getSelfAssessmentPersonality = () => {
-  return api.get("/self-assessment/question/personality");
+// Lets the server stamp when each question was answered; call it whenever an answer changes
+export const recordAnswerTime = (sessionId, questionId) => {
+  return api.put(`/self-assessment/sessions/${sessionId}/questions/${questionId}/answered`);
 };
 
-export const getSelfAssessmentCognitive = () => {
-  return api.get("/self-assessment/question/cognitive");
+// Adaptive cognitive test: start (or resume) returns the first item, each answer returns the next one
+export const startAdaptiveAssessment = () => {
+  return api.post("/self-assessment/adaptive/start");
+};
+
+export const answerAdaptiveItem = (sessionId, questionId, answerValue) => {
+  return api.post(`/self-assessment/adaptive/${sessionId}/answer`, {
+    question_id: questionId,
+    answer_value: String(answerValue),
+  });
+};
+
+// Proctoring: events are { type, occurred_at, details } and are best sent in small batches
+export const recordProctoringEvents = (sessionId, events) => {
+  return api.post(`/proctoring/sessions/${sessionId}/events`, { events });
 };
 
 // Function to get user ID from token
@@ -101,7 +116,7 @@ export const setupAuthHeadersFromStorage = () => {
 };
 
 // Updated function for submitting behavioral assessment
-export const submitBehavioralAssessment = async (answers) => {
+export const submitBehavioralAssessment = async (sessionId, answers) => {
   // Ensure the token is set in the API headers
   const token = localStorage.getItem("auth_token");
   if (token) {
@@ -137,7 +152,7 @@ export const submitBehavioralAssessment = async (answers) => {
 
     // Make the API request with the user ID
     const response = await api.post("/self-assessment/submit/behavioral", {
-      user_id: parsedUserId,
+      session_id: sessionId,
       answers: answers,
     });
 
@@ -149,7 +164,7 @@ export const submitBehavioralAssessment = async (answers) => {
 };
 
 // Function for submitting personality assessment
-export const submitPersonalityAssessment = async (answers) => {
+export const submitPersonalityAssessment = async (sessionId, answers) => {
   // Ensure the token is set in the API headers
   const token = localStorage.getItem("auth_token");
   if (token) {
@@ -182,7 +197,7 @@ export const submitPersonalityAssessment = async (answers) => {
 
     // Make the API request with the user ID
     const response = await api.post("/self-assessment/submit/personality", {
-      user_id: parsedUserId,
+      session_id: sessionId,
       answers: answers,
     });
 
@@ -194,7 +209,7 @@ export const submitPersonalityAssessment = async (answers) => {
 };
 
 // Function for submitting cognitive assessment
-export const submitCognitiveAssessment = async (answers) => {
+export const submitCognitiveAssessment = async (sessionId, answers) => {
   // Ensure the token is set in the API headers
   const token = localStorage.getItem("auth_token");
   if (token) {
@@ -227,7 +242,7 @@ export const submitCognitiveAssessment = async (answers) => {
     
     // Make the API request with the user ID
     const response = await api.post("/self-assessment/submit/cognitive", {
-      user_id: parsedUserId,
+      session_id: sessionId,
       answers: answers,
     });
     
@@ -247,4 +262,72 @@ export const logout = () => {
 // Get assessment completion status
 export const getAssessmentStatus = () => {
   return api.get("/self-assessment/status");
-};
\ No newline at end of file
+};
+// Coding assessment
+export const startCodingAssessment = () => {
+  return api.post("/coding/start");
+};
+
+export const submitCodingSolution = (sessionId, problemId, language, source) => {
+  return api.post(`/coding/sessions/${sessionId}/submissions`, {
+    problem_id: problemId,
+    language,
+    source,
+  });
+};
+
+export const getCodingSubmission = (submissionId) => {
+  return api.get(`/coding/submissions/${submissionId}`);
+};
+
+export const finishCodingAssessment = (sessionId) => {
+  return api.post(`/coding/sessions/${sessionId}/finish`);
+};
+
+// Locale
+export const getLocales = () => {
+  return api.get("/self-assessment/locales");
+};
+
+export const setPreferredLocale = (locale) => {
+  return api.put("/self-assessment/locale", { locale });
+};
+
+// Invitations
+export const openInvitation = (token) => {
+  return api.get(`/invitations/token/${token}`);
+};
+
+// New candidates get a session token; existing accounts get login_required and sign in as usual
+export const acceptInvitation = (token, password) => {
+  return api.post(`/invitations/token/${token}/accept`, { password });
+};
+
+export const getAssignedAssessments = () => {
+  return api.get("/invitations/assigned");
+};
+
+// Notifications
+export const getNotificationPreferences = () => {
+  return api.get("/notifications/preferences");
+};
+
+export const setEmailOptOut = (emailOptOut) => {
+  return api.put("/notifications/preferences", { email_opt_out: emailOptOut });
+};
+
+// Documents
+export const uploadDocument = (file, kind = "resume") => {
+  const form = new FormData();
+  form.append("file", file);
+  form.append("kind", kind);
+  return api.post("/documents", form);
+};
+
+export const getMyDocuments = () => {
+  return api.get("/documents");
+};
+
+export const deleteDocument = (documentId) => {
+  return api.delete(`/documents/${documentId}`);
+};
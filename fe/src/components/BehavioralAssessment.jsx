@@ -15,15 +15,16 @@ import {
 } from "@chakra-ui/react";
 import { MainLayout } from "./Dashboard"; 
 import {
-  getSelfAssessmentBehavioral,
+  startAssessment,
+  recordAnswerTime,
   submitBehavioralAssessment,
   setupAuthHeadersFromStorage,
 } from "../api/userService";
 import { useNavigate } from "react-router-dom";
-import base64 from "base-64"; 
 
 const BehavioralAssessment = ({ onLogout, user, onComplete }) => {
   const [questions, setQuestions] = useState([]);
+  const [sessionId, setSessionId] = useState(null);
   const [answers, setAnswers] = useState({});
   const [currentQuestion, setCurrentQuestion] = useState(0);
   const [isLoading, setIsLoading] = useState(true);
@@ -35,12 +36,15 @@ const BehavioralAssessment = ({ onLogout, user, onComplete }) => {
     setupAuthHeadersFromStorage();
     const fetchBehavioralQuestions = async () => {
       try {
-        const response = await getSelfAssessmentBehavioral();
-        const formattedQuestions = response.data.map((item) => ({
-          id: item.ID,
-          question: item.Question,
-          type: item.Type,
-          options: JSON.parse(base64.decode(item.Options)), // Decode Base64 options
+        // Answers are only accepted for a started session, which also
+        // fixes the question set and option order.
+        const response = await startAssessment("behavioral");
+        setSessionId(response.data.session_id);
+        const formattedQuestions = response.data.questions.map((item) => ({
+          id: item.id,
+          question: item.question,
+          type: item.type,
+          options: item.options,
         }));
 
         setQuestions(formattedQuestions);
@@ -69,9 +73,13 @@ const BehavioralAssessment = ({ onLogout, user, onComplete }) => {
   }, [toast]);
 
   const handleAnswer = (value) => {
+    const questionId = questions[currentQuestion].id;
     setAnswers({
       ...answers,
-      [questions[currentQuestion].id]: value,
+      [questionId]: value,
+    });
+    recordAnswerTime(sessionId, questionId).catch((error) => {
+      console.error("Error recording answer time:", error);
     });
   };
 
@@ -111,7 +119,7 @@ const BehavioralAssessment = ({ onLogout, user, onComplete }) => {
 
       setupAuthHeadersFromStorage();
 
-      const response = await submitBehavioralAssessment(formattedAnswers);
+      const response = await submitBehavioralAssessment(sessionId, formattedAnswers);
 
       toast({
         title: "Success",
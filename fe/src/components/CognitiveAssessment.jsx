@@ -1,12 +1,12 @@
 import { useState, useEffect } from "react";
 import { MainLayout } from "./Dashboard";
 import {
-  getSelfAssessmentCognitive,
+  startAssessment,
+  recordAnswerTime,
   submitCognitiveAssessment,
   setupAuthHeadersFromStorage,
 } from "../api/userService";
 import { useNavigate } from "react-router-dom";
-import base64 from "base-64";
 import {
   VStack,
   Box,
@@ -30,6 +30,7 @@ import {
 
 const CognitiveAssessment = ({ onLogout, user, onComplete }) => {
   const [questions, setQuestions] = useState([]);
+  const [sessionId, setSessionId] = useState(null);
   const [answers, setAnswers] = useState({});
   const [currentQuestion, setCurrentQuestion] = useState(0);
   const [isLoading, setIsLoading] = useState(true);
@@ -49,12 +50,15 @@ const CognitiveAssessment = ({ onLogout, user, onComplete }) => {
     const fetchCognitiveQuestions = async () => {
       try {
         setIsLoading(true);
-        const response = await getSelfAssessmentCognitive();
-        const formattedQuestions = response.data.map((item) => ({
-          id: item.ID,
-          question: item.Question,
-          type: item.Type,
-          options: JSON.parse(base64.decode(item.Options)), // Decode Base64 options
+        // Answers are only accepted for a started session, which also
+        // fixes the question set and option order.
+        const response = await startAssessment("cognitive");
+        setSessionId(response.data.session_id);
+        const formattedQuestions = response.data.questions.map((item) => ({
+          id: item.id,
+          question: item.question,
+          type: item.type,
+          options: item.options,
         }));
 
         setQuestions(formattedQuestions);
@@ -112,9 +116,13 @@ const CognitiveAssessment = ({ onLogout, user, onComplete }) => {
   };
 
   const handleAnswer = (value) => {
+    const questionId = questions[currentQuestion].id;
     setAnswers({
       ...answers,
-      [questions[currentQuestion].id]: value,
+      [questionId]: value,
+    });
+    recordAnswerTime(sessionId, questionId).catch((error) => {
+      console.error("Error recording answer time:", error);
     });
   };
 
@@ -143,7 +151,7 @@ const CognitiveAssessment = ({ onLogout, user, onComplete }) => {
 
       setupAuthHeadersFromStorage();
 
-      const response = await submitCognitiveAssessment(formattedAnswers);
+      const response = await submitCognitiveAssessment(sessionId, formattedAnswers);
 
       toast({
         title: "Success",
@@ -1,12 +1,40 @@
 package main
 
 import (
+	"context"
+	"errors"
+	"log"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
 	"backend/app/config"
 	"backend/app/databases"
+	"backend/app/middleware"
 
+	"backend/pkg/mailer"
+	"backend/pkg/sandbox"
+	"backend/pkg/storage"
+	"backend/pkg/virusscan"
+	"backend/utilities/accommodation"
+	candidates "backend/utilities/candidate"
+	"backend/utilities/coding"
+	"backend/utilities/document"
+	"backend/utilities/invitation"
+	"backend/utilities/item_analysis"
+	job_profiles "backend/utilities/job_profile"
+	"backend/utilities/jobs"
+	"backend/utilities/live"
+	"backend/utilities/notification"
+	"backend/utilities/outbox"
+	"backend/utilities/proctoring"
+	"backend/utilities/question_bank"
 	roles "backend/utilities/role"
 	"backend/utilities/self_assessment"
 	users "backend/utilities/user"
+	"backend/utilities/webhook"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
@@ -24,17 +52,131 @@ func main() {
 	}
 
 	defer db.Close()
+	middleware.UseDatabase(db)
+
+	// Background work stops, and the server drains, once the process is
+	// asked to shut down.
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
 	// Initialize queries
 	roleQueries := roles.New(db)
 	userQueries := users.New(db)
 	selfAssesmentQueries := self_assessment.New(db)
+	candidateQueries := candidates.New(db)
+	jobProfileQueries := job_profiles.New(db)
+	questionBankQueries := question_bank.New(db)
+	itemAnalysisQueries := item_analysis.New(db)
+	proctoringQueries := proctoring.New(db)
+	codingQueries := coding.New(db)
+	accommodationQueries := accommodation.New(db)
+	invitationQueries := invitation.New(db)
+	notificationQueries := notification.New(db)
+	webhookQueries := webhook.New(db)
+	outboxQueries := outbox.New(db)
+	jobQueries := jobs.New(db)
+	liveQueries := live.New(db)
+	documentQueries := document.New(db)
+
+	// Coding submissions cannot be judged without a sandbox, but the rest
+	// of the app works fine without one.
+	runner, err := sandbox.New(sandbox.Config{
+		Runtime:     conf.Sandbox.Runtime,
+		GoImage:     conf.Sandbox.GoImage,
+		PythonImage: conf.Sandbox.PythonImage,
+	})
+	if err != nil {
+		log.Printf("coding assessments disabled: %v", err)
+	}
+
+	// Without SMTP settings emails are logged rather than sent.
+	mail := mailer.New(mailer.Config{
+		Host:     conf.Mail.Host,
+		Port:     conf.Mail.Port,
+		Username: conf.Mail.Username,
+		Password: conf.Mail.Password,
+		From:     conf.Mail.From,
+	})
+	notifier := notification.NewNotifier(notificationQueries, mail, notification.Config{
+		BaseURL:         conf.Notification.BaseURL,
+		ReminderDays:    conf.Notification.ReminderDays,
+		NudgeAfterHours: conf.Notification.NudgeAfterHours,
+	})
+	go notifier.Run(ctx)
+
+	// Events are recorded in the outbox alongside the changes they describe,
+	// then handed to the webhook dispatcher and the live dashboard feed once
+	// committed.
+	dispatcher := webhook.NewDispatcher(webhookQueries)
+	go dispatcher.Run(ctx)
+	hub := live.NewHub(db, liveQueries)
+	go hub.Run(ctx)
+	go outbox.NewDispatcher(outboxQueries, dispatcher, hub).Run(ctx)
+
+	// Uploaded documents live on local disk unless an S3-compatible bucket
+	// is configured. Without clamd, uploads are stored unscanned.
+	store, err := storage.New(storage.Config{
+		Driver:   conf.Storage.Driver,
+		LocalDir: conf.Storage.LocalDir,
+		S3: storage.S3Config{
+			Endpoint:  conf.Storage.S3Endpoint,
+			Region:    conf.Storage.S3Region,
+			Bucket:    conf.Storage.S3Bucket,
+			AccessKey: conf.Storage.S3AccessKey,
+			SecretKey: conf.Storage.S3SecretKey,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+	var scanner document.Scanner
+	if conf.Documents.ClamdAddr != "" {
+		scanner = virusscan.NewClamd(conf.Documents.ClamdAddr)
+	}
 
 	secretKey := conf.JWT.Secret
 	// Initialize handlers
 	roleHandler := roles.NewRoleHandler(roleQueries)
-	userHandler := users.NewAuthHandler(userQueries, secretKey)
-	selfAssessmentHandler := self_assessment.NewSelfAssessmentHandler(selfAssesmentQueries)
+	userHandler := users.NewAuthHandler(db, userQueries, secretKey)
+	selfAssessmentHandler := self_assessment.NewSelfAssessmentHandler(db, selfAssesmentQueries, self_assessment.Config{
+		AdaptiveTargetSE: conf.Adaptive.TargetSE,
+		AdaptiveMaxItems: int32(conf.Adaptive.MaxItems),
+	})
+	candidateHandler := candidates.NewCandidateHandler(db, candidateQueries, store)
+	jobProfileHandler := job_profiles.NewJobProfileHandler(db, jobProfileQueries)
+	questionBankHandler := question_bank.NewQuestionBankHandler(db, questionBankQueries)
+	itemAnalysisHandler := item_analysis.NewItemAnalysisHandler(itemAnalysisQueries)
+	proctoringHandler := proctoring.NewProctoringHandler(db, proctoringQueries)
+	codingHandler := coding.NewCodingHandler(db, codingQueries, runner)
+	accommodationHandler := accommodation.NewAccommodationHandler(db, accommodationQueries)
+	invitationHandler := invitation.NewInvitationHandler(db, invitationQueries, secretKey, notifier)
+	notificationHandler := notification.NewNotificationHandler(notificationQueries)
+	webhookHandler := webhook.NewWebhookHandler(webhookQueries)
+	jobHandler := jobs.NewJobHandler(jobQueries)
+	liveHandler := live.NewLiveHandler(hub, liveQueries)
+	documentHandler := document.NewDocumentHandler(documentQueries, store, scanner, secretKey, document.Config{
+		MaxBytes:     int64(conf.Documents.MaxMegabytes) << 20,
+		MaxDocuments: int64(conf.Documents.MaxPerCandidate),
+	})
+
+	// Work outside the request path goes through the job queue: invitation
+	// emails, score recalculation, the reports and exports staff request,
+	// and judging coding submissions. Handlers queue jobs in their own transactions, so every kind
+	// is registered here before the queue starts.
+	queue := jobs.NewQueue(jobQueries, jobs.Config{
+		Workers:         conf.Jobs.Workers,
+		ShutdownTimeout: time.Duration(conf.Jobs.ShutdownTimeoutSeconds) * time.Second,
+	})
+	jobs.Register(queue, invitation.SendJobKind, invitationHandler.SendInvitationEmail)
+	jobs.Register(queue, self_assessment.RescoreJobKind, selfAssessmentHandler.RecalculateScores)
+	jobs.Register(queue, candidates.ReportJobKind, candidateHandler.GenerateReport)
+	jobs.Register(queue, candidates.ExportJobKind, candidateHandler.GenerateExport)
+	jobs.Register(queue, coding.JudgeJobKind, codingHandler.JudgeSubmission)
+	queueDone := make(chan struct{})
+	go func() {
+		queue.Run(ctx)
+		close(queueDone)
+	}()
 
 	// Setup router
 	r := gin.Default()
@@ -51,6 +193,40 @@ func main() {
 	roles.SetupRoutesRole(r, roleHandler)
 	users.SetupRoutesAuth(r, userHandler)
 	self_assessment.SetupRoutesSelfAssessment(r, selfAssessmentHandler)
-	
-	r.Run()
+	candidates.SetupRoutesCandidate(r, candidateHandler)
+	job_profiles.SetupRoutesJobProfile(r, jobProfileHandler)
+	question_bank.SetupRoutesQuestionBank(r, questionBankHandler)
+	item_analysis.SetupRoutesItemAnalysis(r, itemAnalysisHandler)
+	proctoring.SetupRoutesProctoring(r, proctoringHandler)
+	coding.SetupRoutesCoding(r, codingHandler)
+	accommodation.SetupRoutesAccommodation(r, accommodationHandler)
+	invitation.SetupRoutesInvitation(r, invitationHandler)
+	notification.SetupRoutesNotification(r, notificationHandler)
+	webhook.SetupRoutesWebhook(r, webhookHandler)
+	jobs.SetupRoutesJob(r, jobHandler)
+	live.SetupRoutesLive(r, liveHandler)
+	document.SetupRoutesDocument(r, documentHandler)
+
+	addr := ":8080"
+	if port := os.Getenv("PORT"); port != "" {
+		addr = ":" + port
+	}
+	srv := &http.Server{Addr: addr, Handler: r}
+	go func() {
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Fatalf("server: %v", err)
+		}
+	}()
+
+	<-ctx.Done()
+	stop()
+	log.Printf("shutting down")
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(shutdownCtx); err != nil {
+		log.Printf("server shutdown: %v", err)
+	}
+	// Running jobs are given the configured shutdown timeout to finish
+	// before the database pool closes under them.
+	<-queueDone
 }
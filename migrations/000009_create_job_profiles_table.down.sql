@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS job_profile_targets;
+DROP TABLE IF EXISTS job_profiles;
\ No newline at end of file
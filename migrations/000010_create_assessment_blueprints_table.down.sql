@@ -0,0 +1,4 @@
+DROP TABLE IF EXISTS user_session_questions;
+ALTER TABLE user_assessment_sessions DROP COLUMN IF EXISTS blueprint_id;
+DROP TABLE IF EXISTS assessment_blueprint_sections;
+DROP TABLE IF EXISTS assessment_blueprints;
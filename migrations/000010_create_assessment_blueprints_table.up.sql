@@ -0,0 +1,36 @@
+CREATE TABLE IF NOT EXISTS assessment_blueprints(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) not null,
+    assessment_type varchar(50) not null,
+    shuffle_options boolean not null DEFAULT true,
+    max_item_exposures int null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS assessment_blueprint_sections(
+    id SERIAL PRIMARY KEY,
+    blueprint_id int not null,
+    category_id int not null,
+    question_count int not null,
+    constraint fk_blueprint_section foreign key (blueprint_id) REFERENCES assessment_blueprints(id) on delete CASCADE,
+    constraint fk_category_section foreign key (category_id) REFERENCES self_assessment_categories(id) on delete CASCADE,
+    constraint uq_blueprint_section unique (blueprint_id, category_id)
+);
+
+ALTER TABLE user_assessment_sessions
+    ADD COLUMN IF NOT EXISTS blueprint_id int null,
+    ADD constraint fk_session_blueprint foreign key (blueprint_id) REFERENCES assessment_blueprints(id) on delete SET NULL;
+
+-- The questions drawn for a session, in the order they are shown. option_order
+-- lists the original option keys in display order so answers can be mapped back.
+CREATE TABLE IF NOT EXISTS user_session_questions(
+    session_id int not null,
+    question_id int not null,
+    position int not null,
+    option_order JSONB NOT NULL DEFAULT '[]'::jsonb,
+    PRIMARY KEY (session_id, question_id),
+    constraint fk_session_question_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_session_question_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_session_questions_question ON user_session_questions(question_id);
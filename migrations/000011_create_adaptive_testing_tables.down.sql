@@ -0,0 +1,5 @@
+ALTER TABLE user_assessment_scores
+    DROP COLUMN IF EXISTS theta,
+    DROP COLUMN IF EXISTS theta_se;
+DROP TABLE IF EXISTS adaptive_sessions;
+DROP TABLE IF EXISTS self_assessment_item_parameters;
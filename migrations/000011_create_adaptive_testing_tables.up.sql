@@ -0,0 +1,56 @@
+-- Two-parameter logistic IRT parameters for cognitive items. Items without a
+-- row are treated as discrimination 1, difficulty 0.
+CREATE TABLE IF NOT EXISTS self_assessment_item_parameters(
+    question_id int PRIMARY KEY,
+    discrimination double precision not null DEFAULT 1,
+    difficulty double precision not null DEFAULT 0,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_item_parameter_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE
+);
+
+-- Running state of an adaptive session; a session is adaptive when it has a row here.
+CREATE TABLE IF NOT EXISTS adaptive_sessions(
+    session_id int PRIMARY KEY,
+    target_se double precision not null,
+    max_items int not null,
+    theta double precision not null DEFAULT 0,
+    standard_error double precision not null DEFAULT 1,
+    constraint fk_adaptive_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE
+);
+
+ALTER TABLE user_assessment_scores
+    ADD COLUMN IF NOT EXISTS theta double precision null,
+    ADD COLUMN IF NOT EXISTS theta_se double precision null;
+
+INSERT INTO self_assessment_item_parameters (question_id, discrimination, difficulty)
+SELECT
+  q.id,
+  CASE
+    WHEN q.question LIKE 'If 2x + 3 = 9%' THEN 1.2
+    WHEN q.question LIKE 'How many 3-letter%' THEN 1.4
+    WHEN q.question LIKE 'Which number comes next%' THEN 1.3
+    WHEN q.question LIKE 'In a team of 10 people%' THEN 1.5
+    WHEN q.question LIKE 'If the radius of a circle%' THEN 1.1
+    WHEN q.question LIKE 'A train travels%' THEN 1.0
+    WHEN q.question LIKE 'If 30%% of a number%' THEN 1.2
+    WHEN q.question LIKE 'Which of the following is a valid%' THEN 1.6
+    WHEN q.question LIKE 'A piece of paper%' THEN 1.4
+    WHEN q.question LIKE 'If a dice is rolled%' THEN 1.7
+    ELSE 1.0
+  END,
+  CASE
+    WHEN q.question LIKE 'If 2x + 3 = 9%' THEN -1.5
+    WHEN q.question LIKE 'How many 3-letter%' THEN 0.8
+    WHEN q.question LIKE 'Which number comes next%' THEN 0.2
+    WHEN q.question LIKE 'In a team of 10 people%' THEN 1.0
+    WHEN q.question LIKE 'If the radius of a circle%' THEN -0.8
+    WHEN q.question LIKE 'A train travels%' THEN -1.8
+    WHEN q.question LIKE 'If 30%% of a number%' THEN -0.5
+    WHEN q.question LIKE 'Which of the following is a valid%' THEN 0.5
+    WHEN q.question LIKE 'A piece of paper%' THEN 0.3
+    WHEN q.question LIKE 'If a dice is rolled%' THEN 1.2
+    ELSE 0.0
+  END
+FROM self_assessment_questions q
+WHERE q.type = 'cognitive'
+ON CONFLICT (question_id) DO NOTHING;
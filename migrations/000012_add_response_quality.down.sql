@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS self_assessment_item_pairs;
+ALTER TABLE user_assessment_sessions DROP COLUMN IF EXISTS quality_flags;
+ALTER TABLE user_answers DROP COLUMN IF EXISTS answered_at;
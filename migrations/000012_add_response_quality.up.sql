@@ -0,0 +1,19 @@
+ALTER TABLE user_answers
+    ADD COLUMN IF NOT EXISTS answered_at timestamp null;
+
+-- Response-quality flags raised when the session was scored.
+ALTER TABLE user_assessment_sessions
+    ADD COLUMN IF NOT EXISTS quality_flags JSONB NOT NULL DEFAULT '[]'::jsonb;
+
+-- Items that ask the same thing. A reversed pair is worded in opposite
+-- directions, so agreeing with one should mean disagreeing with the other.
+CREATE TABLE IF NOT EXISTS self_assessment_item_pairs(
+    id SERIAL PRIMARY KEY,
+    question_id int not null,
+    paired_question_id int not null,
+    reversed boolean not null DEFAULT false,
+    constraint fk_item_pair_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE,
+    constraint fk_item_pair_paired foreign key (paired_question_id) REFERENCES self_assessment_questions(id) on delete CASCADE,
+    constraint uq_item_pair unique (question_id, paired_question_id),
+    constraint ck_item_pair_distinct check (question_id <> paired_question_id)
+);
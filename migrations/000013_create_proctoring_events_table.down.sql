@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS proctoring_events;
+DROP TYPE IF EXISTS proctoring_event_type;
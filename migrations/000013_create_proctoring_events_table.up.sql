@@ -0,0 +1,15 @@
+CREATE TYPE proctoring_event_type as ENUM ('tab_hidden','window_blur','copy','paste','fullscreen_exit','page_reload');
+
+-- Integrity events reported by the client while a session is in progress.
+-- occurred_at is the client's time, clamped to the session; received_at is ours.
+CREATE TABLE IF NOT EXISTS proctoring_events(
+    id SERIAL PRIMARY KEY,
+    session_id int not null,
+    event_type proctoring_event_type not null,
+    occurred_at timestamp not null,
+    received_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb,
+    constraint fk_proctoring_event_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_proctoring_events_session ON proctoring_events(session_id, received_at);
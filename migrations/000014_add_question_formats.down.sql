@@ -0,0 +1,3 @@
+ALTER TABLE self_assessment_questions
+    DROP COLUMN IF EXISTS format;
+DROP TYPE IF EXISTS question_format;
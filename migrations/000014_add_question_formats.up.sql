@@ -0,0 +1,7 @@
+CREATE TYPE question_format as ENUM ('single_choice','multi_select','ranking','numeric','free_text');
+
+-- How a question is answered and scored. Options and mappings keep their
+-- meaning per format: multi_select and ranking map every option to a category,
+-- numeric options are accepted ranges and free_text options accepted answers.
+ALTER TABLE self_assessment_questions
+    ADD COLUMN IF NOT EXISTS format question_format NOT NULL DEFAULT 'single_choice';
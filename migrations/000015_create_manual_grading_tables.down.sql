@@ -0,0 +1,7 @@
+DROP TABLE IF EXISTS grading_assignments;
+DROP TABLE IF EXISTS grading_items;
+DROP TYPE IF EXISTS grading_status;
+DROP TABLE IF EXISTS self_assessment_rubrics;
+ALTER TABLE user_assessment_sessions
+    DROP COLUMN IF EXISTS submitted_at;
+-- Postgres cannot drop an enum value, so 'open_ended' stays in question_format.
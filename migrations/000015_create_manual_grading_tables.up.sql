@@ -0,0 +1,56 @@
+ALTER TYPE question_format ADD VALUE IF NOT EXISTS 'open_ended';
+
+-- When the candidate handed the session in. Sessions with open-ended answers
+-- stay uncompleted, and unscored, until every answer has been graded.
+ALTER TABLE user_assessment_sessions
+    ADD COLUMN IF NOT EXISTS submitted_at timestamp null;
+
+UPDATE user_assessment_sessions SET submitted_at = completed_at WHERE completed_at IS NOT NULL;
+
+-- Rubric for an open-ended question. criteria is a list of
+-- {"key", "description", "max_points", "category_id"}; a double-marked
+-- question needs a resolver when the two totals differ by more than
+-- max_disagreement.
+CREATE TABLE IF NOT EXISTS self_assessment_rubrics(
+    question_id int PRIMARY KEY,
+    criteria JSONB NOT NULL DEFAULT '[]'::jsonb,
+    double_marked boolean not null DEFAULT false,
+    max_disagreement int not null DEFAULT 0,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_rubric_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE
+);
+
+CREATE TYPE grading_status as ENUM ('pending','disputed','graded');
+
+-- One open-ended answer waiting for, or done with, manual grading.
+CREATE TABLE IF NOT EXISTS grading_items(
+    id SERIAL PRIMARY KEY,
+    answer_id int not null UNIQUE,
+    session_id int not null,
+    question_id int not null,
+    required_grades int not null DEFAULT 1,
+    status grading_status not null DEFAULT 'pending',
+    final_scores JSONB null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    graded_at timestamp null,
+    constraint fk_grading_item_answer foreign key (answer_id) REFERENCES user_answers(id) on delete CASCADE,
+    constraint fk_grading_item_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_grading_item_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_grading_items_status ON grading_items(status);
+
+-- A grader's mark for an item. A resolver settles a disputed double mark.
+CREATE TABLE IF NOT EXISTS grading_assignments(
+    id SERIAL PRIMARY KEY,
+    item_id int not null,
+    grader_id int not null,
+    resolver boolean not null DEFAULT false,
+    criterion_scores JSONB null,
+    comment text null,
+    assigned_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    submitted_at timestamp null,
+    constraint fk_grading_assignment_item foreign key (item_id) REFERENCES grading_items(id) on delete CASCADE,
+    constraint fk_grading_assignment_grader foreign key (grader_id) REFERENCES users(id) on delete CASCADE,
+    constraint uq_grading_assignment unique (item_id, grader_id)
+);
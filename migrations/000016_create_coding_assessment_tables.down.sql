@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS coding_test_results;
+DROP TABLE IF EXISTS coding_submissions;
+DROP TABLE IF EXISTS coding_test_cases;
+DROP TABLE IF EXISTS coding_problems;
+DROP TYPE IF EXISTS coding_verdict;
+DROP TYPE IF EXISTS coding_submission_status;
+DROP TYPE IF EXISTS coding_language;
+DELETE FROM self_assessment_categories WHERE name = 'Coding';
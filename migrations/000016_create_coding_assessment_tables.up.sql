@@ -0,0 +1,69 @@
+INSERT INTO self_assessment_categories
+  (name, description)
+VALUES
+  ('Coding', 'Ability to write working code that solves a stated problem')
+ON CONFLICT (name) DO NOTHING;
+
+CREATE TYPE coding_language as ENUM ('go','python');
+
+CREATE TYPE coding_submission_status as ENUM ('queued','running','completed','failed');
+
+CREATE TYPE coding_verdict as ENUM ('passed','wrong_answer','runtime_error','time_limit','memory_limit');
+
+-- A problem of the coding assessment. Limits apply to each test case run.
+CREATE TABLE IF NOT EXISTS coding_problems(
+    id SERIAL PRIMARY KEY,
+    title varchar(255) not null,
+    statement text not null,
+    time_limit_ms int not null DEFAULT 2000,
+    memory_limit_mb int not null DEFAULT 256,
+    max_points int not null DEFAULT 10,
+    active boolean not null DEFAULT true,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Hidden test cases are never shown to candidates; the others serve as examples.
+CREATE TABLE IF NOT EXISTS coding_test_cases(
+    id SERIAL PRIMARY KEY,
+    problem_id int not null,
+    input text not null DEFAULT '',
+    expected_output text not null,
+    hidden boolean not null DEFAULT true,
+    weight int not null DEFAULT 1,
+    constraint fk_coding_test_case_problem foreign key (problem_id) REFERENCES coding_problems(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS coding_submissions(
+    id SERIAL PRIMARY KEY,
+    session_id int not null,
+    user_id int not null,
+    problem_id int not null,
+    language coding_language not null,
+    source text not null,
+    status coding_submission_status not null DEFAULT 'queued',
+    compile_output text null,
+    passed_weight int not null DEFAULT 0,
+    total_weight int not null DEFAULT 0,
+    score int not null DEFAULT 0,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    finished_at timestamp null,
+    constraint fk_coding_submission_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_coding_submission_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_coding_submission_problem foreign key (problem_id) REFERENCES coding_problems(id) on delete CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_coding_submissions_session ON coding_submissions(session_id, problem_id);
+
+-- Outcome of one test case for a submission, kept for reviewers.
+CREATE TABLE IF NOT EXISTS coding_test_results(
+    id SERIAL PRIMARY KEY,
+    submission_id int not null,
+    test_case_id int not null,
+    verdict coding_verdict not null,
+    stdout text not null DEFAULT '',
+    stderr text not null DEFAULT '',
+    exit_code int not null DEFAULT 0,
+    duration_ms int not null DEFAULT 0,
+    constraint fk_coding_test_result_submission foreign key (submission_id) REFERENCES coding_submissions(id) on delete CASCADE,
+    constraint fk_coding_test_result_case foreign key (test_case_id) REFERENCES coding_test_cases(id) on delete CASCADE
+);
@@ -0,0 +1,4 @@
+ALTER TABLE self_assessment_mappings
+    DROP CONSTRAINT IF EXISTS uq_mapping_option_category;
+UPDATE self_assessment_questions SET format = 'single_choice' WHERE format = 'situational';
+-- Postgres cannot drop an enum value, so 'situational' stays in question_format.
@@ -0,0 +1,7 @@
+ALTER TYPE question_format ADD VALUE IF NOT EXISTS 'situational';
+
+-- A situational judgement question describes a workplace scenario; each
+-- response option may add or take away points in several categories, so an
+-- option maps to a category at most once.
+ALTER TABLE self_assessment_mappings
+    ADD CONSTRAINT uq_mapping_option_category UNIQUE (question_id, answer_value, category_id);
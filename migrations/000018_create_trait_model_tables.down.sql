@@ -0,0 +1,58 @@
+DELETE FROM assessment_blueprints WHERE name = 'Big Five (reduced IPIP-NEO-120)';
+
+-- Mappings and scores reference questions and categories with ON DELETE SET
+-- NULL on columns that may not be null, so they go first.
+DELETE FROM self_assessment_mappings
+WHERE question_id IN (SELECT question_id FROM trait_items)
+   OR category_id IN (SELECT trait_category_id FROM trait_facets)
+   OR category_id IN (SELECT facet_category_id FROM trait_facets);
+
+DELETE FROM user_assessment_scores
+WHERE category_id IN (SELECT trait_category_id FROM trait_facets)
+   OR category_id IN (SELECT facet_category_id FROM trait_facets);
+
+DELETE FROM user_answers
+WHERE question_id IN (SELECT question_id FROM trait_items);
+
+DELETE FROM self_assessment_questions
+WHERE id IN (SELECT question_id FROM trait_items);
+
+DROP TABLE IF EXISTS trait_items;
+DROP TABLE IF EXISTS trait_facets;
+DROP TABLE IF EXISTS trait_models;
+
+DELETE FROM self_assessment_categories
+WHERE name IN (
+  'Neuroticism',
+  'Extraversion',
+  'Openness',
+  'Agreeableness',
+  'Conscientiousness',
+  'Anxiety',
+  'Anger',
+  'Self-Consciousness',
+  'Vulnerability',
+  'Friendliness',
+  'Gregariousness',
+  'Assertiveness',
+  'Activity Level',
+  'Excitement-Seeking',
+  'Cheerfulness',
+  'Imagination',
+  'Artistic Interests',
+  'Emotionality',
+  'Adventurousness',
+  'Intellect',
+  'Trust',
+  'Morality',
+  'Altruism',
+  'Cooperation',
+  'Modesty',
+  'Sympathy',
+  'Self-Efficacy',
+  'Orderliness',
+  'Dutifulness',
+  'Achievement-Striving',
+  'Self-Discipline',
+  'Cautiousness'
+);
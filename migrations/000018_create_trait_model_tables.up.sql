@@ -0,0 +1,311 @@
+-- A personality trait model such as the Big Five. Traits and their facets are
+-- ordinary categories, so facet subscores and trait scores are stored in
+-- user_assessment_scores like any other category score.
+CREATE TABLE IF NOT EXISTS trait_models(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) not null UNIQUE,
+    source text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS trait_facets(
+    id SERIAL PRIMARY KEY,
+    model_id int not null,
+    code varchar(10) not null,
+    trait_category_id int not null,
+    facet_category_id int not null,
+    position int not null DEFAULT 0,
+    constraint fk_trait_facet_model foreign key (model_id) REFERENCES trait_models(id) on delete CASCADE,
+    constraint fk_trait_facet_trait foreign key (trait_category_id) REFERENCES self_assessment_categories(id) on delete CASCADE,
+    constraint fk_trait_facet_facet foreign key (facet_category_id) REFERENCES self_assessment_categories(id) on delete CASCADE,
+    constraint uq_trait_facet_code unique (model_id, code),
+    constraint uq_trait_facet_category unique (model_id, facet_category_id)
+);
+
+-- A Likert item scored on a facet. A reverse-keyed item is worded against its
+-- trait, so its mappings give the most points to the lowest option.
+CREATE TABLE IF NOT EXISTS trait_items(
+    question_id int PRIMARY KEY,
+    facet_id int not null,
+    reverse_keyed boolean not null DEFAULT false,
+    constraint fk_trait_item_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE,
+    constraint fk_trait_item_facet foreign key (facet_id) REFERENCES trait_facets(id) on delete CASCADE
+);
+
+-- Big Five traits and facets. The Depression, Immoderation and Liberalism
+-- facets of the IPIP-NEO-120 are not seeded; see the model's source.
+INSERT INTO self_assessment_categories
+  (name, description)
+VALUES
+  ('Neuroticism', 'Tendency to experience negative emotions such as anxiety, anger and low mood'),
+  ('Extraversion', 'Orientation towards other people, activity and positive emotion'),
+  ('Openness', 'Openness to new experiences, ideas and feelings'),
+  ('Agreeableness', 'Concern for cooperation and social harmony'),
+  ('Conscientiousness', 'Organisation, persistence and self-control in pursuing goals'),
+  ('Anxiety', 'Proneness to worry and fear (Neuroticism)'),
+  ('Anger', 'Readiness to feel anger and frustration (Neuroticism)'),
+  ('Self-Consciousness', 'Sensitivity to what others think and unease in social situations (Neuroticism)'),
+  ('Vulnerability', 'Susceptibility to stress and feeling overwhelmed (Neuroticism)'),
+  ('Friendliness', 'Warmth and ease in forming relationships (Extraversion)'),
+  ('Gregariousness', 'Preference for the company of others (Extraversion)'),
+  ('Assertiveness', 'Tendency to take charge and speak up (Extraversion)'),
+  ('Activity Level', 'Pace and energy of everyday life (Extraversion)'),
+  ('Excitement-Seeking', 'Need for stimulation and thrills (Extraversion)'),
+  ('Cheerfulness', 'Tendency to experience positive emotions (Extraversion)'),
+  ('Imagination', 'Richness of fantasy and inner life (Openness)'),
+  ('Artistic Interests', 'Appreciation of art and beauty (Openness)'),
+  ('Emotionality', 'Awareness of one''s own and others'' feelings (Openness)'),
+  ('Adventurousness', 'Willingness to try new activities (Openness)'),
+  ('Intellect', 'Interest in ideas and abstract thinking (Openness)'),
+  ('Trust', 'Belief in the honesty and good intentions of others (Agreeableness)'),
+  ('Morality', 'Sincerity and fairness in dealing with others (Agreeableness)'),
+  ('Altruism', 'Active concern for the welfare of others (Agreeableness)'),
+  ('Cooperation', 'Preference for compromise over confrontation (Agreeableness)'),
+  ('Modesty', 'Humility about one''s own qualities (Agreeableness)'),
+  ('Sympathy', 'Compassion for those in need (Agreeableness)'),
+  ('Self-Efficacy', 'Confidence in one''s ability to get things done (Conscientiousness)'),
+  ('Orderliness', 'Preference for tidiness and organisation (Conscientiousness)'),
+  ('Dutifulness', 'Sense of obligation and keeping one''s word (Conscientiousness)'),
+  ('Achievement-Striving', 'Drive to work hard and excel (Conscientiousness)'),
+  ('Self-Discipline', 'Ability to start and finish tasks despite distractions (Conscientiousness)'),
+  ('Cautiousness', 'Tendency to think before acting (Conscientiousness)')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO trait_models (name, source)
+VALUES (
+  'Big Five (reduced IPIP-NEO-120)',
+  'International Personality Item Pool (ipip.ori.org), public domain. A reduced form of the IPIP-NEO-120 (Johnson, 2014) with 27 of its 30 facets and 108 items. The Depression (N3), Immoderation (N5) and Liberalism (O6) facets ask about mental health, self-control over cravings and political attitudes, and are left out for use in hiring, so Neuroticism and Openness are scored on fewer facets than in the full inventory.'
+)
+ON CONFLICT (name) DO NOTHING;
+
+WITH facets AS (
+  SELECT * FROM (
+    VALUES
+      ('N1', 'Neuroticism', 'Anxiety', 1),
+      ('N2', 'Neuroticism', 'Anger', 2),
+      ('N4', 'Neuroticism', 'Self-Consciousness', 3),
+      ('N6', 'Neuroticism', 'Vulnerability', 4),
+      ('E1', 'Extraversion', 'Friendliness', 5),
+      ('E2', 'Extraversion', 'Gregariousness', 6),
+      ('E3', 'Extraversion', 'Assertiveness', 7),
+      ('E4', 'Extraversion', 'Activity Level', 8),
+      ('E5', 'Extraversion', 'Excitement-Seeking', 9),
+      ('E6', 'Extraversion', 'Cheerfulness', 10),
+      ('O1', 'Openness', 'Imagination', 11),
+      ('O2', 'Openness', 'Artistic Interests', 12),
+      ('O3', 'Openness', 'Emotionality', 13),
+      ('O4', 'Openness', 'Adventurousness', 14),
+      ('O5', 'Openness', 'Intellect', 15),
+      ('A1', 'Agreeableness', 'Trust', 16),
+      ('A2', 'Agreeableness', 'Morality', 17),
+      ('A3', 'Agreeableness', 'Altruism', 18),
+      ('A4', 'Agreeableness', 'Cooperation', 19),
+      ('A5', 'Agreeableness', 'Modesty', 20),
+      ('A6', 'Agreeableness', 'Sympathy', 21),
+      ('C1', 'Conscientiousness', 'Self-Efficacy', 22),
+      ('C2', 'Conscientiousness', 'Orderliness', 23),
+      ('C3', 'Conscientiousness', 'Dutifulness', 24),
+      ('C4', 'Conscientiousness', 'Achievement-Striving', 25),
+      ('C5', 'Conscientiousness', 'Self-Discipline', 26),
+      ('C6', 'Conscientiousness', 'Cautiousness', 27)
+  ) AS t(code, trait_name, facet_name, position)
+)
+INSERT INTO trait_facets (model_id, code, trait_category_id, facet_category_id, position)
+SELECT m.id, facets.code, tc.id, fc.id, facets.position
+FROM facets
+JOIN trait_models m ON m.name = 'Big Five (reduced IPIP-NEO-120)'
+JOIN self_assessment_categories tc ON tc.name = facets.trait_name
+JOIN self_assessment_categories fc ON fc.name = facets.facet_name
+ON CONFLICT (model_id, code) DO NOTHING;
+
+-- IPIP items are answered on a five-point accuracy scale. They are loaded
+-- once into a temporary table, then added as questions and as trait items.
+CREATE TEMP TABLE ipip_items(
+    question_text text not null,
+    facet_code varchar(10) not null,
+    reverse_keyed boolean not null
+);
+
+INSERT INTO ipip_items (question_text, facet_code, reverse_keyed)
+VALUES
+  ('I worry about things', 'N1', false),
+  ('I fear for the worst', 'N1', false),
+  ('I am afraid of many things', 'N1', false),
+  ('I get stressed out easily', 'N1', false),
+  ('I get angry easily', 'N2', false),
+  ('I get irritated easily', 'N2', false),
+  ('I lose my temper', 'N2', false),
+  ('I am not easily annoyed', 'N2', true),
+  ('I find it difficult to approach others', 'N4', false),
+  ('I am afraid to draw attention to myself', 'N4', false),
+  ('I only feel comfortable with friends', 'N4', false),
+  ('I am not bothered by difficult social situations', 'N4', true),
+  ('I panic easily', 'N6', false),
+  ('I become overwhelmed by events', 'N6', false),
+  ('I feel that I''m unable to deal with things', 'N6', false),
+  ('I remain calm under pressure', 'N6', true),
+  ('I make friends easily', 'E1', false),
+  ('I feel comfortable around people', 'E1', false),
+  ('I avoid contacts with others', 'E1', true),
+  ('I keep others at a distance', 'E1', true),
+  ('I love large parties', 'E2', false),
+  ('I talk to a lot of different people at parties', 'E2', false),
+  ('I prefer to be alone', 'E2', true),
+  ('I avoid crowds', 'E2', true),
+  ('I take charge', 'E3', false),
+  ('I try to lead others', 'E3', false),
+  ('I take control of things', 'E3', false),
+  ('I wait for others to lead the way', 'E3', true),
+  ('I am always busy', 'E4', false),
+  ('I am always on the go', 'E4', false),
+  ('I do a lot in my spare time', 'E4', false),
+  ('I like to take it easy', 'E4', true),
+  ('I love excitement', 'E5', false),
+  ('I seek adventure', 'E5', false),
+  ('I enjoy being reckless', 'E5', false),
+  ('I act wild and crazy', 'E5', false),
+  ('I radiate joy', 'E6', false),
+  ('I have a lot of fun', 'E6', false),
+  ('I love life', 'E6', false),
+  ('I look at the bright side of life', 'E6', false),
+  ('I have a vivid imagination', 'O1', false),
+  ('I enjoy wild flights of fantasy', 'O1', false),
+  ('I love to daydream', 'O1', false),
+  ('I like to get lost in thought', 'O1', false),
+  ('I believe in the importance of art', 'O2', false),
+  ('I see beauty in things that others might not notice', 'O2', false),
+  ('I do not like poetry', 'O2', true),
+  ('I do not enjoy going to art museums', 'O2', true),
+  ('I experience my emotions intensely', 'O3', false),
+  ('I feel others'' emotions', 'O3', false),
+  ('I rarely notice my emotional reactions', 'O3', true),
+  ('I don''t understand people who get emotional', 'O3', true),
+  ('I prefer variety to routine', 'O4', false),
+  ('I prefer to stick with things that I know', 'O4', true),
+  ('I dislike changes', 'O4', true),
+  ('I am attached to conventional ways', 'O4', true),
+  ('I love to read challenging material', 'O5', false),
+  ('I avoid philosophical discussions', 'O5', true),
+  ('I have difficulty understanding abstract ideas', 'O5', true),
+  ('I am not interested in theoretical discussions', 'O5', true),
+  ('I trust others', 'A1', false),
+  ('I believe that others have good intentions', 'A1', false),
+  ('I trust what people say', 'A1', false),
+  ('I distrust people', 'A1', true),
+  ('I use others for my own ends', 'A2', true),
+  ('I cheat to get ahead', 'A2', true),
+  ('I take advantage of others', 'A2', true),
+  ('I obstruct others'' plans', 'A2', true),
+  ('I love to help others', 'A3', false),
+  ('I am concerned about others', 'A3', false),
+  ('I am indifferent to the feelings of others', 'A3', true),
+  ('I take no time for others', 'A3', true),
+  ('I love a good fight', 'A4', true),
+  ('I yell at people', 'A4', true),
+  ('I insult people', 'A4', true),
+  ('I get back at others', 'A4', true),
+  ('I believe that I am better than others', 'A5', true),
+  ('I think highly of myself', 'A5', true),
+  ('I have a high opinion of myself', 'A5', true),
+  ('I boast about my virtues', 'A5', true),
+  ('I sympathize with the homeless', 'A6', false),
+  ('I feel sympathy for those who are worse off than myself', 'A6', false),
+  ('I am not interested in other people''s problems', 'A6', true),
+  ('I try not to think about the needy', 'A6', true),
+  ('I complete tasks successfully', 'C1', false),
+  ('I excel in what I do', 'C1', false),
+  ('I handle tasks smoothly', 'C1', false),
+  ('I know how to get things done', 'C1', false),
+  ('I like to tidy up', 'C2', false),
+  ('I often forget to put things back in their proper place', 'C2', true),
+  ('I leave a mess in my room', 'C2', true),
+  ('I leave my belongings around', 'C2', true),
+  ('I keep my promises', 'C3', false),
+  ('I tell the truth', 'C3', false),
+  ('I break rules', 'C3', true),
+  ('I break my promises', 'C3', true),
+  ('I do more than what''s expected of me', 'C4', false),
+  ('I work hard', 'C4', false),
+  ('I put little time and effort into my work', 'C4', true),
+  ('I do just enough work to get by', 'C4', true),
+  ('I am always prepared', 'C5', false),
+  ('I carry out my plans', 'C5', false),
+  ('I waste my time', 'C5', true),
+  ('I have difficulty starting tasks', 'C5', true),
+  ('I jump into things without thinking', 'C6', true),
+  ('I make rash decisions', 'C6', true),
+  ('I rush into things', 'C6', true),
+  ('I act without thinking', 'C6', true);
+
+INSERT INTO self_assessment_questions (question, type, options, format)
+SELECT
+  items.question_text,
+  'personality',
+  '{"1": "Very inaccurate", "2": "Moderately inaccurate", "3": "Neither accurate nor inaccurate", "4": "Moderately accurate", "5": "Very accurate"}',
+  'single_choice'
+FROM ipip_items items
+WHERE NOT EXISTS (
+  SELECT 1 FROM self_assessment_questions q
+  WHERE q.question = items.question_text AND q.type = 'personality'
+);
+
+INSERT INTO trait_items (question_id, facet_id, reverse_keyed)
+SELECT q.id, f.id, items.reverse_keyed
+FROM ipip_items items
+JOIN self_assessment_questions q ON q.question = items.question_text AND q.type = 'personality'
+JOIN trait_models m ON m.name = 'Big Five (reduced IPIP-NEO-120)'
+JOIN trait_facets f ON f.model_id = m.id AND f.code = items.facet_code
+ON CONFLICT (question_id) DO NOTHING;
+
+DROP TABLE ipip_items;
+
+-- Consistency pairs for response-quality checks. Each pair asks the same
+-- thing, either in other words or, when reversed, as its opposite.
+WITH pairs AS (
+  SELECT * FROM (
+    VALUES
+      ('I get angry easily', 'I get irritated easily', false),
+      ('I jump into things without thinking', 'I act without thinking', false),
+      ('I think highly of myself', 'I have a high opinion of myself', false),
+      ('I keep my promises', 'I break my promises', true),
+      ('I trust others', 'I distrust people', true),
+      ('I work hard', 'I put little time and effort into my work', true)
+  ) AS t(question_text, paired_text, reversed)
+)
+INSERT INTO self_assessment_item_pairs (question_id, paired_question_id, reversed)
+SELECT q.id, p.id, pairs.reversed
+FROM pairs
+JOIN self_assessment_questions q ON q.question = pairs.question_text AND q.type = 'personality'
+JOIN self_assessment_questions p ON p.question = pairs.paired_text AND p.type = 'personality'
+ON CONFLICT (question_id, paired_question_id) DO NOTHING;
+
+-- Every item scores on its facet and on the facet's trait
+INSERT INTO self_assessment_mappings (question_id, answer_value, category_id, points)
+SELECT
+  ti.question_id,
+  options.answer_value,
+  categories.category_id,
+  CASE WHEN ti.reverse_keyed THEN 6 - options.answer_value ELSE options.answer_value END
+FROM trait_items ti
+JOIN trait_facets f ON f.id = ti.facet_id
+CROSS JOIN LATERAL (VALUES (f.facet_category_id), (f.trait_category_id)) AS categories(category_id)
+CROSS JOIN generate_series(1, 5) AS options(answer_value)
+ON CONFLICT (question_id, answer_value, category_id) DO NOTHING;
+
+-- The default personality blueprint asks every item of every facet, in a
+-- fixed option order as the scale expects
+INSERT INTO assessment_blueprints (name, assessment_type, shuffle_options)
+SELECT 'Big Five (reduced IPIP-NEO-120)', 'personality', false
+WHERE NOT EXISTS (
+  SELECT 1 FROM assessment_blueprints WHERE name = 'Big Five (reduced IPIP-NEO-120)'
+);
+
+INSERT INTO assessment_blueprint_sections (blueprint_id, category_id, question_count)
+SELECT b.id, f.facet_category_id, COUNT(ti.question_id)
+FROM assessment_blueprints b
+JOIN trait_models m ON m.name = b.name
+JOIN trait_facets f ON f.model_id = m.id
+JOIN trait_items ti ON ti.facet_id = f.id
+WHERE b.name = 'Big Five (reduced IPIP-NEO-120)'
+GROUP BY b.id, f.facet_category_id
+ON CONFLICT (blueprint_id, category_id) DO NOTHING;
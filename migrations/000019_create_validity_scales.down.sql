@@ -0,0 +1,7 @@
+DELETE FROM self_assessment_questions
+WHERE id IN (SELECT question_id FROM validity_items);
+
+DROP TABLE IF EXISTS session_validity_scores;
+DROP TABLE IF EXISTS validity_items;
+DROP TABLE IF EXISTS validity_scales;
+DROP TYPE IF EXISTS validity_scale_kind;
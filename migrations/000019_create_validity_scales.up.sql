@@ -0,0 +1,83 @@
+CREATE TYPE validity_scale_kind as ENUM ('lie','infrequency');
+
+-- Validity scales check how a self-report was answered rather than what it
+-- says about the candidate. Lie items describe virtues almost nobody has, so
+-- endorsing many of them suggests impression management; infrequency items
+-- are almost never endorsed by attentive respondents. A scale's score is the
+-- number of its items answered with a keyed answer, and a session reaching
+-- the threshold should be interpreted with caution.
+CREATE TABLE IF NOT EXISTS validity_scales(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) not null UNIQUE,
+    kind validity_scale_kind not null,
+    assessment_type varchar(50) not null,
+    threshold int not null,
+    description text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint ck_validity_scale_threshold check (threshold > 0)
+);
+
+-- Validity items have no category mappings, so they never count towards
+-- regular category scores.
+CREATE TABLE IF NOT EXISTS validity_items(
+    question_id int PRIMARY KEY,
+    scale_id int not null,
+    keyed_answers text[] not null,
+    constraint fk_validity_item_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE,
+    constraint fk_validity_item_scale foreign key (scale_id) REFERENCES validity_scales(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS session_validity_scores(
+    session_id int not null,
+    scale_id int not null,
+    score int not null,
+    item_count int not null,
+    flagged boolean not null,
+    PRIMARY KEY (session_id, scale_id),
+    constraint fk_session_validity_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_session_validity_scale foreign key (scale_id) REFERENCES validity_scales(id) on delete CASCADE
+);
+
+INSERT INTO validity_scales (name, kind, assessment_type, threshold, description)
+VALUES
+  ('Impression Management', 'lie', 'behavioral', 4, 'Agreement with statements describing implausibly perfect conduct'),
+  ('Infrequency', 'infrequency', 'behavioral', 2, 'Answers almost no attentive respondent gives')
+ON CONFLICT (name) DO NOTHING;
+
+-- Validity items are loaded once into a temporary table, then added as
+-- questions and as items of their scales.
+CREATE TEMP TABLE validity_seed_items(
+    question_text text not null,
+    scale_name varchar(255) not null,
+    keyed_answers text[] not null
+);
+
+INSERT INTO validity_seed_items (question_text, scale_name, keyed_answers)
+VALUES
+  ('I have never been late for an appointment or a deadline.', 'Impression Management', ARRAY['4','5']),
+  ('I have never said anything I later regretted.', 'Impression Management', ARRAY['4','5']),
+  ('I follow every rule at work, however small, without exception.', 'Impression Management', ARRAY['4','5']),
+  ('I have never felt annoyed when asked to do something at short notice.', 'Impression Management', ARRAY['4','5']),
+  ('I have never put off a task I did not want to do.', 'Impression Management', ARRAY['4','5']),
+  ('I have liked every person I have ever worked with.', 'Impression Management', ARRAY['4','5']),
+  ('I have visited every country in the world.', 'Infrequency', ARRAY['4','5']),
+  ('I sleep less than one hour every night.', 'Infrequency', ARRAY['4','5']),
+  ('I have never used a phone or a computer.', 'Infrequency', ARRAY['4','5']),
+  ('I am able to read and understand this sentence.', 'Infrequency', ARRAY['1','2']);
+
+INSERT INTO self_assessment_questions (question, type, options, format)
+SELECT items.question_text, 'behavioral', '{"1": {"text": "Strongly Disagree", "points": 1}, "2": {"text": "Disagree", "points": 2}, "3": {"text": "Neutral", "points": 3}, "4": {"text": "Agree", "points": 4}, "5": {"text": "Strongly Agree", "points": 5}}', 'single_choice'
+FROM validity_seed_items items
+WHERE NOT EXISTS (
+  SELECT 1 FROM self_assessment_questions q
+  WHERE q.question = items.question_text AND q.type = 'behavioral'
+);
+
+INSERT INTO validity_items (question_id, scale_id, keyed_answers)
+SELECT q.id, s.id, items.keyed_answers
+FROM validity_seed_items items
+JOIN self_assessment_questions q ON q.question = items.question_text AND q.type = 'behavioral'
+JOIN validity_scales s ON s.name = items.scale_name
+ON CONFLICT (question_id) DO NOTHING;
+
+DROP TABLE validity_seed_items;
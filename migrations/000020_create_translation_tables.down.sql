@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS user_preferences;
+DROP TABLE IF EXISTS category_translations;
+DROP TABLE IF EXISTS question_translations;
@@ -0,0 +1,28 @@
+-- Translations overlay the English question bank. Question IDs and option
+-- keys are shared by every locale, so answers given in any language are
+-- scored by the same mappings and scores stay comparable.
+CREATE TABLE IF NOT EXISTS question_translations(
+    question_id int not null,
+    locale varchar(35) not null,
+    question text not null,
+    -- Option key -> translated label; keys must exist on the question.
+    options JSONB NOT NULL DEFAULT '{}'::jsonb,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (question_id, locale),
+    constraint fk_question_translation_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS category_translations(
+    category_id int not null,
+    locale varchar(35) not null,
+    description text not null,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (category_id, locale),
+    constraint fk_category_translation_category foreign key (category_id) REFERENCES self_assessment_categories(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_preferences(
+    user_id int PRIMARY KEY,
+    locale varchar(35) null,
+    constraint fk_user_preferences_user foreign key (user_id) REFERENCES users(id) on delete CASCADE
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS session_accommodations;
+DROP TABLE IF EXISTS candidate_accommodations;
+
+ALTER TABLE assessment_blueprints
+    DROP constraint IF EXISTS ck_blueprint_time_limit,
+    DROP COLUMN IF EXISTS time_limit_minutes;
@@ -0,0 +1,39 @@
+-- A blueprint may limit how long a session of it can take. Untimed
+-- blueprints, and sessions started without one, have no deadline.
+ALTER TABLE assessment_blueprints
+    ADD COLUMN IF NOT EXISTS time_limit_minutes int null,
+    ADD constraint ck_blueprint_time_limit check (time_limit_minutes > 0);
+
+-- Accommodations granted to a candidate, either for all of their sessions
+-- or for a single one. The time multiplier stretches a session's time
+-- limit and break minutes are added on top of it.
+CREATE TABLE IF NOT EXISTS candidate_accommodations(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    session_id int null,
+    time_multiplier double precision not null DEFAULT 1,
+    break_minutes int not null DEFAULT 0,
+    alternative_formats text[] not null DEFAULT '{}',
+    reason text not null,
+    approved_by int not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    revoked_at timestamp null,
+    revoked_by int null,
+    constraint fk_accommodation_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_accommodation_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_accommodation_approver foreign key (approved_by) REFERENCES users(id),
+    constraint fk_accommodation_revoker foreign key (revoked_by) REFERENCES users(id),
+    constraint ck_accommodation_time_multiplier check (time_multiplier >= 1 AND time_multiplier <= 4),
+    constraint ck_accommodation_break_minutes check (break_minutes >= 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_accommodations_user ON candidate_accommodations(user_id);
+
+-- The accommodation a session runs under. Candidate-wide accommodations are
+-- linked when a session starts; a session-specific one replaces the link.
+CREATE TABLE IF NOT EXISTS session_accommodations(
+    session_id int PRIMARY KEY,
+    accommodation_id int not null,
+    constraint fk_session_accommodation_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_session_accommodation_accommodation foreign key (accommodation_id) REFERENCES candidate_accommodations(id) on delete CASCADE
+);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS assigned_assessments;
+DROP TABLE IF EXISTS invitations;
@@ -0,0 +1,31 @@
+-- Invitations let recruiters bring a candidate in by email. Only a hash of
+-- the signed token is stored; the token itself is handed out once.
+CREATE TABLE IF NOT EXISTS invitations(
+    id SERIAL PRIMARY KEY,
+    email varchar(100) not null,
+    name varchar(100) not null,
+    user_id int null,
+    assessment_types text[] not null,
+    token_hash varchar(64) not null UNIQUE,
+    invited_by int not null,
+    expires_at timestamp not null,
+    opened_at timestamp null,
+    accepted_at timestamp null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_invitation_user foreign key (user_id) REFERENCES users(id) on delete SET NULL,
+    constraint fk_invitation_inviter foreign key (invited_by) REFERENCES users(id),
+    constraint ck_invitation_assessment_types check (cardinality(assessment_types) > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_invitations_email ON invitations(email);
+
+-- Assessments a candidate is required to take.
+CREATE TABLE IF NOT EXISTS assigned_assessments(
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    invitation_id int null,
+    assigned_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, assessment_type),
+    constraint fk_assigned_assessment_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_assigned_assessment_invitation foreign key (invitation_id) REFERENCES invitations(id) on delete SET NULL
+);
@@ -0,0 +1,6 @@
+DROP TABLE IF EXISTS notification_log;
+DROP TYPE IF EXISTS notification_status;
+DROP TYPE IF EXISTS notification_kind;
+
+ALTER TABLE user_preferences
+    DROP COLUMN IF EXISTS email_opt_out;
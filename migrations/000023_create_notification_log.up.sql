@@ -0,0 +1,27 @@
+ALTER TABLE user_preferences
+    ADD COLUMN IF NOT EXISTS email_opt_out boolean not null DEFAULT false;
+
+CREATE TYPE notification_kind as ENUM ('invitation','expiry_reminder','incomplete_nudge','completion_confirmation','staff_digest');
+
+CREATE TYPE notification_status as ENUM ('pending','sent','failed','skipped');
+
+-- Every email the notification engine sends, or decides not to send. The
+-- dedup key names what the message is about (an invitation, a session, a
+-- staff member's day), so each is sent once however many servers run the
+-- scheduler.
+CREATE TABLE IF NOT EXISTS notification_log(
+    id SERIAL PRIMARY KEY,
+    kind notification_kind not null,
+    dedup_key varchar(255) not null UNIQUE,
+    user_id int null,
+    email varchar(100) not null,
+    subject text not null,
+    status notification_status not null DEFAULT 'pending',
+    attempts int not null DEFAULT 1,
+    error text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    sent_at timestamp null,
+    constraint fk_notification_user foreign key (user_id) REFERENCES users(id) on delete SET NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_notification_log_user ON notification_log(user_id, kind);
@@ -0,0 +1,5 @@
+DROP TABLE IF EXISTS webhook_delivery_attempts;
+DROP TABLE IF EXISTS webhook_deliveries;
+DROP TYPE IF EXISTS webhook_delivery_status;
+DROP TABLE IF EXISTS webhook_subscriptions;
+DROP TABLE IF EXISTS candidate_stages;
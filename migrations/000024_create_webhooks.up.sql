@@ -0,0 +1,59 @@
+-- Where each candidate is in the hiring pipeline. Candidates without a row
+-- have not been moved along yet.
+CREATE TABLE IF NOT EXISTS candidate_stages(
+    user_id int PRIMARY KEY,
+    stage varchar(30) not null,
+    changed_by int null,
+    changed_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_candidate_stage_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_candidate_stage_changed_by foreign key (changed_by) REFERENCES users(id) on delete SET NULL
+);
+
+-- Endpoints outside systems register to hear about events. The secret signs
+-- every delivery so receivers can check it came from us.
+CREATE TABLE IF NOT EXISTS webhook_subscriptions(
+    id SERIAL PRIMARY KEY,
+    url text not null,
+    secret varchar(100) not null,
+    event_types text[] not null,
+    active boolean not null DEFAULT true,
+    created_by int null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_webhook_subscription_creator foreign key (created_by) REFERENCES users(id) on delete SET NULL,
+    constraint ck_webhook_subscription_event_types check (cardinality(event_types) > 0)
+);
+
+CREATE TYPE webhook_delivery_status as ENUM ('pending','succeeded','failed');
+
+-- One event on its way to one subscription. Pending deliveries are picked up
+-- once next_attempt_at has passed; failed ones have run out of retries.
+CREATE TABLE IF NOT EXISTS webhook_deliveries(
+    id SERIAL PRIMARY KEY,
+    subscription_id int not null,
+    event_id varchar(32) not null,
+    event_type varchar(50) not null,
+    payload JSONB not null,
+    status webhook_delivery_status not null DEFAULT 'pending',
+    attempts int not null DEFAULT 0,
+    next_attempt_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    delivered_at timestamp null,
+    UNIQUE (subscription_id, event_id),
+    constraint fk_webhook_delivery_subscription foreign key (subscription_id) REFERENCES webhook_subscriptions(id) on delete CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_deliveries_due ON webhook_deliveries(next_attempt_at) WHERE status = 'pending';
+
+-- Every request made for a delivery, successful or not.
+CREATE TABLE IF NOT EXISTS webhook_delivery_attempts(
+    id SERIAL PRIMARY KEY,
+    delivery_id int not null,
+    attempted_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    status_code int null,
+    error text null,
+    duration_ms int not null,
+    constraint fk_webhook_attempt_delivery foreign key (delivery_id) REFERENCES webhook_deliveries(id) on delete CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_webhook_delivery_attempts_delivery ON webhook_delivery_attempts(delivery_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS background_jobs;
+DROP TYPE IF EXISTS background_job_status;
@@ -0,0 +1,23 @@
+CREATE TYPE background_job_status as ENUM ('queued','running','succeeded','failed');
+
+-- Work done outside the request path. Queued jobs are picked up once run_at
+-- has passed; a running job whose lease has lapsed is assumed lost with its
+-- worker and picked up again. Failed jobs have run out of attempts.
+CREATE TABLE IF NOT EXISTS background_jobs(
+    id BIGSERIAL PRIMARY KEY,
+    kind varchar(100) not null,
+    payload JSONB not null,
+    status background_job_status not null DEFAULT 'queued',
+    attempts int not null DEFAULT 0,
+    max_attempts int not null DEFAULT 5,
+    run_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamp null,
+    last_error text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    started_at timestamp null,
+    finished_at timestamp null,
+    constraint ck_background_job_max_attempts check (max_attempts > 0)
+);
+
+CREATE INDEX IF NOT EXISTS idx_background_jobs_due ON background_jobs(run_at) WHERE status = 'queued';
+CREATE INDEX IF NOT EXISTS idx_background_jobs_leased ON background_jobs(locked_until) WHERE status = 'running';
@@ -0,0 +1,12 @@
+-- Events streamed to staff dashboards. Each server listens for new rows and
+-- pushes them to its connected clients; a client that reconnects asks for
+-- everything after the last ID it saw. Rows are only kept briefly.
+CREATE TABLE IF NOT EXISTS live_events(
+    id BIGSERIAL PRIMARY KEY,
+    outbox_event_id bigint not null UNIQUE,
+    event_type varchar(50) not null,
+    payload JSONB not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_live_events_created_at ON live_events(created_at);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS candidate_documents;
+DROP TYPE IF EXISTS document_scan_status;
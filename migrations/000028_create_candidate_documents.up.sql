@@ -0,0 +1,21 @@
+CREATE TYPE document_scan_status as ENUM ('clean','unscanned');
+
+-- Files candidates attach to their profile, such as a resume. The file
+-- itself lives in object storage under storage_key. Infected uploads are
+-- rejected, so a document is either clean or was stored while no scanner
+-- was configured.
+CREATE TABLE IF NOT EXISTS candidate_documents(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    kind varchar(30) not null,
+    file_name varchar(255) not null,
+    content_type varchar(100) not null,
+    size_bytes bigint not null,
+    sha256 char(64) not null,
+    storage_key varchar(255) not null UNIQUE,
+    scan_status document_scan_status not null,
+    uploaded_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_candidate_document_user foreign key (user_id) REFERENCES users(id) on delete CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_documents_user ON candidate_documents(user_id);
@@ -0,0 +1 @@
+ALTER TABLE user_session_questions DROP COLUMN IF EXISTS answered_at;
@@ -0,0 +1,4 @@
+-- When the candidate last answered each question, stamped by the server as
+-- they go so response times cannot be supplied by the client.
+ALTER TABLE user_session_questions
+    ADD COLUMN IF NOT EXISTS answered_at timestamp null;
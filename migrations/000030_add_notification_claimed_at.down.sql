@@ -0,0 +1 @@
+ALTER TABLE notification_log DROP COLUMN IF EXISTS claimed_at;
@@ -0,0 +1,4 @@
+-- When a message was last claimed for sending. A message still pending long
+-- after its claim was lost with its sender and may be claimed again.
+ALTER TABLE notification_log
+    ADD COLUMN IF NOT EXISTS claimed_at timestamp not null DEFAULT CURRENT_TIMESTAMP;
@@ -0,0 +1,17 @@
+-- Reports and exports are generated by background jobs. Each request is
+-- tracked here, and the finished file is kept in document storage.
+CREATE TABLE IF NOT EXISTS candidate_files(
+    id BIGSERIAL PRIMARY KEY,
+    kind varchar(20) not null,
+    params JSONB not null,
+    job_id bigint null,
+    filename varchar(255) null,
+    content_type varchar(100) null,
+    storage_key varchar(255) null,
+    size_bytes bigint null,
+    requested_by int not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    finished_at timestamp null,
+    constraint fk_candidate_file_job foreign key (job_id) REFERENCES background_jobs(id) on delete SET NULL,
+    constraint fk_candidate_file_requester foreign key (requested_by) REFERENCES users(id) on delete CASCADE
+);
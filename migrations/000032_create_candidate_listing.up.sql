@@ -0,0 +1,29 @@
+-- The candidates staff see: everyone with behavioral scores, alongside their
+-- top trait. The listing and the export both read from here so that they
+-- always agree on who counts as a candidate.
+CREATE OR REPLACE VIEW candidate_listing AS
+WITH candidate_behavioral_scores AS (
+SELECT
+ uas.user_id,
+ uas.session_id,
+ sac.name AS category_name,
+ uas.score,
+ RANK() OVER (PARTITION BY uas.user_id ORDER BY uas.score DESC) as score_rank
+FROM
+ user_assessment_scores uas
+JOIN
+ self_assessment_categories sac ON uas.category_id = sac.id
+JOIN
+ user_assessment_sessions sessions ON uas.session_id = sessions.id
+WHERE
+ sessions.assessment_type = 'behavioral'
+)
+SELECT
+ user_id,
+ session_id,
+ category_name AS top_behavioral_trait,
+ score AS top_behavioral_score
+FROM
+ candidate_behavioral_scores
+WHERE
+ score_rank = 1;
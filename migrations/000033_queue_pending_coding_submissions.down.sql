@@ -0,0 +1,2 @@
+DELETE FROM background_jobs
+WHERE kind = 'coding.judge' AND status = 'queued';
@@ -0,0 +1,12 @@
+-- Submissions used to be judged in memory and picked up again on restart.
+-- They are now judged by background jobs, so queue one for every submission
+-- still waiting to be judged.
+INSERT INTO background_jobs (kind, payload)
+SELECT 'coding.judge', jsonb_build_object('submission_id', s.id)
+FROM coding_submissions s
+WHERE s.status IN ('queued', 'running')
+  AND NOT EXISTS (
+    SELECT 1 FROM background_jobs j
+    WHERE j.kind = 'coding.judge'
+      AND j.payload = jsonb_build_object('submission_id', s.id)
+  );
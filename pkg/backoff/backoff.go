@@ -0,0 +1,15 @@
+// Package backoff works out how long to wait before retrying failed work.
+package backoff
+
+import "time"
+
+// Exponential is how long to wait after the given number of failed
+// attempts: base after the first, doubling with each one after that, and
+// never more than max.
+func Exponential(attempts int, base, max time.Duration) time.Duration {
+	delay := base
+	for i := 1; i < attempts && delay < max; i++ {
+		delay *= 2
+	}
+	return min(delay, max)
+}
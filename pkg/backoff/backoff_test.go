@@ -0,0 +1,31 @@
+package backoff
+
+import (
+	"testing"
+	"time"
+)
+
+func TestExponential(t *testing.T) {
+	tests := []struct {
+		name     string
+		attempts int
+		base     time.Duration
+		max      time.Duration
+		want     time.Duration
+	}{
+		{name: "no attempts yet", attempts: 0, base: 5 * time.Second, max: time.Hour, want: 5 * time.Second},
+		{name: "first failure", attempts: 1, base: 5 * time.Second, max: time.Hour, want: 5 * time.Second},
+		{name: "second failure doubles", attempts: 2, base: 5 * time.Second, max: time.Hour, want: 10 * time.Second},
+		{name: "fifth failure", attempts: 5, base: 30 * time.Second, max: 6 * time.Hour, want: 8 * time.Minute},
+		{name: "capped", attempts: 12, base: 10 * time.Second, max: time.Hour, want: time.Hour},
+		{name: "many attempts do not overflow", attempts: 1000, base: 5 * time.Second, max: time.Hour, want: time.Hour},
+		{name: "base above max", attempts: 1, base: 2 * time.Hour, max: time.Hour, want: time.Hour},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Exponential(tt.attempts, tt.base, tt.max); got != tt.want {
+				t.Errorf("Exponential(%d, %v, %v) = %v, want %v", tt.attempts, tt.base, tt.max, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,99 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Event types outside systems can subscribe to.
+const (
+	AssessmentCompleted = "assessment.completed"
+	CandidateCreated    = "candidate.created"
+	ScoresCalculated    = "scores.calculated"
+	SessionStarted      = "session.started"
+	StageChanged        = "stage.changed"
+)
+
+// Types lists every event type, in the order they are documented.
+var Types = []string{AssessmentCompleted, CandidateCreated, ScoresCalculated, SessionStarted, StageChanged}
+
+// Event is a domain event read back from the outbox once the change it
+// describes has been committed.
+type Event struct {
+	ID        int64
+	Type      string
+	DedupKey  string
+	Payload   json.RawMessage
+	CreatedAt time.Time
+}
+
+// Consumer acts on committed events. Events are delivered at least once, so
+// a consumer may see the same one again after a failure or crash and should
+// use its dedup key to recognise it.
+type Consumer interface {
+	Consume(ctx context.Context, event Event) error
+}
+
+// DedupKey names the change an event describes, such as
+// "assessment.completed:42". Recording an event under a key that is already
+// in the outbox has no effect.
+func DedupKey(eventType string, parts ...any) string {
+	var b strings.Builder
+	b.WriteString(eventType)
+	for _, part := range parts {
+		fmt.Fprintf(&b, ":%v", part)
+	}
+	return b.String()
+}
+
+// AssessmentCompletedData is the payload of an assessment.completed event.
+type AssessmentCompletedData struct {
+	SessionID      int32     `json:"session_id"`
+	UserID         int32     `json:"user_id"`
+	AssessmentType string    `json:"assessment_type"`
+	CompletedAt    time.Time `json:"completed_at"`
+}
+
+// CandidateCreatedData is the payload of a candidate.created event.
+type CandidateCreatedData struct {
+	UserID       int32  `json:"user_id"`
+	Name         string `json:"name"`
+	Email        string `json:"email"`
+	InvitationID *int32 `json:"invitation_id"`
+}
+
+// ScoresCalculatedData is the payload of a scores.calculated event.
+type ScoresCalculatedData struct {
+	SessionID      int32           `json:"session_id"`
+	UserID         int32           `json:"user_id"`
+	AssessmentType string          `json:"assessment_type"`
+	Scores         []CategoryScore `json:"scores"`
+}
+
+// CategoryScore is a session's score in one category.
+type CategoryScore struct {
+	CategoryID   int32  `json:"category_id"`
+	CategoryName string `json:"category_name"`
+	Score        int32  `json:"score"`
+}
+
+// SessionStartedData is the payload of a session.started event.
+type SessionStartedData struct {
+	SessionID      int32     `json:"session_id"`
+	UserID         int32     `json:"user_id"`
+	AssessmentType string    `json:"assessment_type"`
+	StartedAt      time.Time `json:"started_at"`
+}
+
+// StageChangedData is the payload of a stage.changed event. From is empty
+// for a candidate's first stage.
+type StageChangedData struct {
+	UserID    int32     `json:"user_id"`
+	From      string    `json:"from"`
+	To        string    `json:"to"`
+	ChangedBy int32     `json:"changed_by"`
+	ChangedAt time.Time `json:"changed_at"`
+}
@@ -0,0 +1,55 @@
+package filters
+
+import (
+	"errors"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const dateLayout = "2006-01-02"
+
+// CandidateFilter holds the optional filters shared by the candidate listing and exports.
+type CandidateFilter struct {
+	Search         pgtype.Text
+	AssessmentType pgtype.Text
+	CompletedFrom  pgtype.Timestamp
+	CompletedTo    pgtype.Timestamp
+}
+
+// ParseCandidateFilter reads the candidate filters from the query string:
+// search, assessment_type, completed_from and completed_to (YYYY-MM-DD, inclusive).
+func ParseCandidateFilter(c *gin.Context) (CandidateFilter, error) {
+	var filter CandidateFilter
+
+	if search := c.Query("search"); search != "" {
+		filter.Search = pgtype.Text{String: search, Valid: true}
+	}
+
+	if assessmentType := c.Query("assessment_type"); assessmentType != "" {
+		if assessmentType != "behavioral" && assessmentType != "personality" && assessmentType != "cognitive" && assessmentType != "coding" {
+			return filter, errors.New("Invalid assessment type")
+		}
+		filter.AssessmentType = pgtype.Text{String: assessmentType, Valid: true}
+	}
+
+	if from := c.Query("completed_from"); from != "" {
+		t, err := time.Parse(dateLayout, from)
+		if err != nil {
+			return filter, errors.New("Invalid completed_from date, expected YYYY-MM-DD")
+		}
+		filter.CompletedFrom = pgtype.Timestamp{Time: t, Valid: true}
+	}
+
+	if to := c.Query("completed_to"); to != "" {
+		t, err := time.Parse(dateLayout, to)
+		if err != nil {
+			return filter, errors.New("Invalid completed_to date, expected YYYY-MM-DD")
+		}
+		// Make the upper bound inclusive of the whole day.
+		filter.CompletedTo = pgtype.Timestamp{Time: t.AddDate(0, 0, 1), Valid: true}
+	}
+
+	return filter, nil
+}
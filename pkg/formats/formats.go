@@ -0,0 +1,96 @@
+package formats
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Question formats, as stored in the question_format enum.
+const (
+	SingleChoice = "single_choice"
+	MultiSelect  = "multi_select"
+	Ranking      = "ranking"
+	Numeric      = "numeric"
+	FreeText     = "free_text"
+	OpenEnded    = "open_ended"
+	Situational  = "situational"
+)
+
+// MaxTextLength bounds a free-text answer, in characters.
+const MaxTextLength = 2000
+
+// MaxEssayLength bounds an open-ended answer, in characters.
+const MaxEssayLength = 10000
+
+// NumericRange is an accepted numeric answer: anything within Tolerance of Value.
+type NumericRange struct {
+	Value     float64 `json:"value"`
+	Tolerance float64 `json:"tolerance"`
+}
+
+// Valid reports whether format is a known question format.
+func Valid(format string) bool {
+	switch format {
+	case SingleChoice, MultiSelect, Ranking, Numeric, FreeText, OpenEnded, Situational:
+		return true
+	}
+	return false
+}
+
+// ValidateOptions checks that a question's options fit its format. Option
+// keys of typed formats must be numbers because mappings refer to them by
+// answer value. Single-choice options are not checked, as before formats,
+// and open-ended questions are graded against a rubric instead of options.
+func ValidateOptions(format string, options map[string]json.RawMessage) error {
+	if !Valid(format) {
+		return fmt.Errorf("unknown question format %q", format)
+	}
+	if format == SingleChoice || format == OpenEnded {
+		return nil
+	}
+
+	minOptions := 1
+	if format == MultiSelect || format == Ranking || format == Situational {
+		minOptions = 2
+	}
+	if len(options) < minOptions {
+		return fmt.Errorf("%s questions need at least %d options", format, minOptions)
+	}
+
+	for key, raw := range options {
+		if _, err := strconv.Atoi(key); err != nil {
+			return fmt.Errorf("option key %q must be a number", key)
+		}
+		switch format {
+		case Numeric:
+			var r struct {
+				Value     *float64 `json:"value"`
+				Tolerance float64  `json:"tolerance"`
+			}
+			if err := json.Unmarshal(raw, &r); err != nil || r.Value == nil {
+				return fmt.Errorf("option %s must be an object with a numeric value", key)
+			}
+			if r.Tolerance < 0 {
+				return fmt.Errorf("option %s has a negative tolerance", key)
+			}
+		case FreeText:
+			var accepted string
+			if err := json.Unmarshal(raw, &accepted); err != nil || strings.TrimSpace(accepted) == "" {
+				return fmt.Errorf("option %s must be a non-empty accepted answer", key)
+			}
+		case Situational:
+			var response string
+			if err := json.Unmarshal(raw, &response); err != nil || strings.TrimSpace(response) == "" {
+				return fmt.Errorf("option %s must be a non-empty response", key)
+			}
+		}
+	}
+	return nil
+}
+
+// NormalizeText folds a free-text answer for comparison with accepted answers.
+func NormalizeText(s string) string {
+	return strings.ToLower(strings.Join(strings.Fields(s), " "))
+}
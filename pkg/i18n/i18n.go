@@ -0,0 +1,133 @@
+package i18n
+
+import (
+	"errors"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Default is the locale the stored question bank is written in. Every
+// other locale is an overlay of translations on top of it.
+const Default = "en"
+
+var errInvalidLocale = errors.New("locale must be a language tag such as \"de\" or \"pt-BR\"")
+
+// Canonical checks a BCP 47 language tag and writes it in its usual case:
+// a lowercase language, a title-case script and an uppercase region, as in
+// "zh-Hant-TW". Only the language, script and region subtags are accepted.
+func Canonical(tag string) (string, error) {
+	parts := strings.Split(strings.ReplaceAll(strings.TrimSpace(tag), "_", "-"), "-")
+	if !isAlpha(parts[0]) || len(parts[0]) < 2 || len(parts[0]) > 3 {
+		return "", errInvalidLocale
+	}
+	parts[0] = strings.ToLower(parts[0])
+
+	rest := parts[1:]
+	if len(rest) > 0 && len(rest[0]) == 4 && isAlpha(rest[0]) {
+		rest[0] = strings.ToUpper(rest[0][:1]) + strings.ToLower(rest[0][1:])
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		switch {
+		case len(rest[0]) == 2 && isAlpha(rest[0]):
+			rest[0] = strings.ToUpper(rest[0])
+		case len(rest[0]) == 3 && isDigits(rest[0]):
+		default:
+			return "", errInvalidLocale
+		}
+		rest = rest[1:]
+	}
+	if len(rest) > 0 {
+		return "", errInvalidLocale
+	}
+	return strings.Join(parts, "-"), nil
+}
+
+// ParseAcceptLanguage returns the tags of an Accept-Language header, most
+// preferred first. Wildcards, malformed tags and tags with q=0 are dropped.
+func ParseAcceptLanguage(header string) []string {
+	type weighted struct {
+		tag string
+		q   float64
+	}
+	var found []weighted
+	for _, entry := range strings.Split(header, ",") {
+		fields := strings.Split(entry, ";")
+		tag, err := Canonical(fields[0])
+		if err != nil {
+			continue
+		}
+		q := 1.0
+		for _, param := range fields[1:] {
+			name, value, ok := strings.Cut(strings.TrimSpace(param), "=")
+			if !ok || strings.TrimSpace(name) != "q" {
+				continue
+			}
+			parsed, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+			if err != nil || parsed < 0 || parsed > 1 {
+				parsed = 0
+			}
+			q = parsed
+		}
+		if q > 0 {
+			found = append(found, weighted{tag, q})
+		}
+	}
+	sort.SliceStable(found, func(i, j int) bool { return found[i].q > found[j].q })
+
+	tags := make([]string, len(found))
+	for i, w := range found {
+		tags[i] = w.tag
+	}
+	return tags
+}
+
+// Match picks the supported locale that best serves a list of preferred
+// tags, most preferred first. A tag matches a supported locale exactly, then
+// by dropping its trailing subtags ("pt-BR" is served by "pt"), then by
+// language alone ("pt" is served by "pt-BR"). Default is used when nothing
+// matches.
+func Match(preferred []string, supported []string) string {
+	for _, tag := range preferred {
+		for candidate := tag; candidate != ""; candidate = parent(candidate) {
+			for _, locale := range supported {
+				if locale == candidate {
+					return locale
+				}
+			}
+		}
+		language, _, _ := strings.Cut(tag, "-")
+		for _, locale := range supported {
+			if strings.HasPrefix(locale, language+"-") {
+				return locale
+			}
+		}
+	}
+	return Default
+}
+
+func parent(tag string) string {
+	if i := strings.LastIndex(tag, "-"); i > 0 {
+		return tag[:i]
+	}
+	return ""
+}
+
+func isAlpha(s string) bool {
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') {
+			return false
+		}
+	}
+	return s != ""
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return s != ""
+}
@@ -0,0 +1,57 @@
+package i18n
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseAcceptLanguage(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+		want   []string
+	}{
+		{name: "empty", header: "", want: []string{}},
+		{name: "single tag", header: "de", want: []string{"de"}},
+		{name: "sorted by quality", header: "en;q=0.5, pt-br, fr;q=0.8", want: []string{"pt-BR", "fr", "en"}},
+		{name: "ties keep header order", header: "nl;q=0.7, de;q=0.7", want: []string{"nl", "de"}},
+		{name: "q=0 is dropped", header: "de, en;q=0", want: []string{"de"}},
+		{name: "wildcard is dropped", header: "*, es;q=0.9", want: []string{"es"}},
+		{name: "malformed tags are dropped", header: "e, english, zh-Hant-TW", want: []string{"zh-Hant-TW"}},
+		{name: "bad quality counts as zero", header: "de;q=abc, en;q=2", want: []string{}},
+		{name: "underscores are accepted", header: "pt_br", want: []string{"pt-BR"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ParseAcceptLanguage(tt.header); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseAcceptLanguage(%q) = %q, want %q", tt.header, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	supported := []string{"en", "de", "pt-BR", "zh-Hant"}
+	tests := []struct {
+		name      string
+		preferred []string
+		want      string
+	}{
+		{name: "exact", preferred: []string{"de"}, want: "de"},
+		{name: "first preference wins", preferred: []string{"pt-BR", "de"}, want: "pt-BR"},
+		{name: "parent of a regional tag", preferred: []string{"de-AT"}, want: "de"},
+		{name: "parent of a script and region", preferred: []string{"zh-Hant-TW"}, want: "zh-Hant"},
+		{name: "language served by a regional locale", preferred: []string{"pt"}, want: "pt-BR"},
+		{name: "sibling region by language", preferred: []string{"pt-PT"}, want: "pt-BR"},
+		{name: "unsupported falls through to later preferences", preferred: []string{"fr", "de"}, want: "de"},
+		{name: "nothing matches", preferred: []string{"fr", "ja"}, want: Default},
+		{name: "no preferences", preferred: nil, want: Default},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Match(tt.preferred, supported); got != tt.want {
+				t.Errorf("Match(%q) = %q, want %q", tt.preferred, got, tt.want)
+			}
+		})
+	}
+}
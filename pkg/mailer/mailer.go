@@ -0,0 +1,84 @@
+package mailer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+// Message is a plain-text email to one recipient.
+type Message struct {
+	To      string `json:"to"`
+	Subject string `json:"subject"`
+	Body    string `json:"body"`
+}
+
+// Mailer sends email.
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config configures the SMTP server mail is sent through.
+type Config struct {
+	Host     string
+	Port     string
+	Username string
+	Password string
+	From     string
+}
+
+// New returns a mailer for the configured SMTP server. Without a host,
+// messages are written to the log instead, so development setups need no
+// mail server.
+func New(cfg Config) Mailer {
+	if cfg.Host == "" {
+		return logMailer{}
+	}
+	if cfg.Port == "" {
+		cfg.Port = "587"
+	}
+	return &smtpMailer{cfg: cfg}
+}
+
+type smtpMailer struct {
+	cfg Config
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if strings.ContainsAny(msg.To+msg.Subject, "\r\n") {
+		return errors.New("mailer: header values must not contain line breaks")
+	}
+
+	var data strings.Builder
+	fmt.Fprintf(&data, "From: %s\r\n", m.cfg.From)
+	fmt.Fprintf(&data, "To: %s\r\n", msg.To)
+	fmt.Fprintf(&data, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", msg.Subject))
+	fmt.Fprintf(&data, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	data.WriteString("MIME-Version: 1.0\r\n")
+	data.WriteString("Content-Type: text/plain; charset=UTF-8\r\n")
+	data.WriteString("Content-Transfer-Encoding: 8bit\r\n\r\n")
+	data.WriteString(strings.ReplaceAll(msg.Body, "\n", "\r\n"))
+
+	var auth smtp.Auth
+	if m.cfg.Username != "" {
+		auth = smtp.PlainAuth("", m.cfg.Username, m.cfg.Password, m.cfg.Host)
+	}
+	addr := net.JoinHostPort(m.cfg.Host, m.cfg.Port)
+	return smtp.SendMail(addr, auth, m.cfg.From, []string{msg.To}, []byte(data.String()))
+}
+
+type logMailer struct{}
+
+func (logMailer) Send(ctx context.Context, msg Message) error {
+	log.Printf("mailer: SMTP not configured, not sending %q to %s", msg.Subject, msg.To)
+	return nil
+}
@@ -0,0 +1,64 @@
+package sandbox
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os/exec"
+	"time"
+)
+
+// dockerIsolator runs every step in a fresh container with no network, no
+// capabilities, a read-only root and Docker's default seccomp profile. The
+// container runs as nobody and can only write to /tmp, and to the work
+// directory while compiling.
+type dockerIsolator struct {
+	binary string
+	images map[Language]string
+}
+
+func (d *dockerIsolator) command(ctx context.Context, lang Language, dir string, s step, limits Limits, argv []string) *exec.Cmd {
+	name := containerName()
+	mount := dir + ":/work:ro"
+	if s == stepCompile {
+		mount = dir + ":/work"
+	}
+	cpuSeconds := int((limits.CPUTime + time.Second - 1) / time.Second)
+
+	args := []string{
+		"run", "--rm", "-i",
+		"--name", name,
+		"--network", "none",
+		"--cpus", "1",
+		"--memory", fmt.Sprint(limits.MemoryBytes),
+		"--memory-swap", fmt.Sprint(limits.MemoryBytes),
+		"--pids-limit", "64",
+		"--ulimit", fmt.Sprintf("cpu=%d:%d", cpuSeconds, cpuSeconds+1),
+		"--read-only",
+		"--tmpfs", "/tmp:rw,exec,size=64m",
+		"--cap-drop", "ALL",
+		"--security-opt", "no-new-privileges",
+		"--user", "65534:65534",
+		"-e", "HOME=/tmp",
+		"-e", "GOCACHE=/tmp/gocache",
+		"-e", "CGO_ENABLED=0",
+		"-v", mount,
+		"-w", "/work",
+		d.images[lang],
+	}
+	cmd := exec.CommandContext(ctx, d.binary, append(args, argv...)...)
+	// Killing the docker client leaves the container running, so remove
+	// the container itself when the time is up.
+	cmd.Cancel = func() error {
+		exec.Command(d.binary, "rm", "-f", name).Run()
+		return cmd.Process.Kill()
+	}
+	return cmd
+}
+
+func containerName() string {
+	b := make([]byte, 8)
+	rand.Read(b)
+	return "sandbox-" + hex.EncodeToString(b)
+}
@@ -0,0 +1,297 @@
+// Package sandbox compiles and runs untrusted candidate code with CPU, memory,
+// time and output limits.
+package sandbox
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// Language is a programming language the sandbox can run.
+type Language string
+
+const (
+	Go     Language = "go"
+	Python Language = "python"
+)
+
+// Valid reports whether lang is a supported language.
+func (lang Language) Valid() bool {
+	return lang == Go || lang == Python
+}
+
+// Status is how a single run ended.
+type Status string
+
+const (
+	StatusOK           Status = "ok"
+	StatusRuntimeError Status = "runtime_error"
+	StatusTimeLimit    Status = "time_limit"
+	StatusMemoryLimit  Status = "memory_limit"
+)
+
+// Limits bound one run of a program.
+type Limits struct {
+	WallTime    time.Duration
+	CPUTime     time.Duration
+	MemoryBytes int64
+	// OutputBytes caps how much of stdout and stderr is kept. Anything
+	// beyond it is discarded and the result marked truncated.
+	OutputBytes int
+}
+
+// compileLimits apply to the compile step, which needs far more room than
+// the solutions themselves.
+var compileLimits = Limits{
+	WallTime:    60 * time.Second,
+	CPUTime:     60 * time.Second,
+	MemoryBytes: 1 << 30,
+	OutputBytes: 16 << 10,
+}
+
+// Result is the outcome of running a program on one input.
+type Result struct {
+	Status    Status        `json:"status"`
+	Stdout    string        `json:"stdout"`
+	Stderr    string        `json:"stderr"`
+	ExitCode  int           `json:"exit_code"`
+	Duration  time.Duration `json:"duration"`
+	Truncated bool          `json:"truncated"`
+}
+
+// Execution is the outcome of compiling a program and running it on every
+// input. Results is empty when compilation failed.
+type Execution struct {
+	CompileFailed bool
+	CompileOutput string
+	Results       []Result
+}
+
+// Runner compiles source and runs it once per input.
+type Runner interface {
+	Run(ctx context.Context, lang Language, source string, inputs []string, limits Limits) (Execution, error)
+}
+
+// Config selects and configures a runner.
+type Config struct {
+	// Runtime must be "docker", the default. Untrusted code never runs
+	// directly on the host.
+	Runtime     string
+	GoImage     string
+	PythonImage string
+}
+
+// New returns the runner cfg asks for.
+func New(cfg Config) (Runner, error) {
+	switch cfg.Runtime {
+	case "", "docker":
+		binary, err := exec.LookPath("docker")
+		if err != nil {
+			return nil, errors.New("sandbox: docker runtime selected but docker is not installed")
+		}
+		// Fail at startup, rather than on the first submission, when the
+		// daemon cannot be reached.
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if out, err := exec.CommandContext(ctx, binary, "version", "--format", "{{.Server.Version}}").CombinedOutput(); err != nil {
+			return nil, fmt.Errorf("sandbox: docker daemon is not reachable: %w: %s", err, bytes.TrimSpace(out))
+		}
+		iso := &dockerIsolator{
+			binary: binary,
+			images: map[Language]string{Go: "golang:1.22-alpine", Python: "python:3.12-alpine"},
+		}
+		if cfg.GoImage != "" {
+			iso.images[Go] = cfg.GoImage
+		}
+		if cfg.PythonImage != "" {
+			iso.images[Python] = cfg.PythonImage
+		}
+		return &runner{iso: iso}, nil
+	}
+	return nil, fmt.Errorf("sandbox: unknown runtime %q", cfg.Runtime)
+}
+
+// step is one stage of an execution.
+type step int
+
+const (
+	stepCompile step = iota
+	stepRun
+)
+
+// isolator wraps a command so it runs isolated from the host. The work
+// directory is writable only while compiling.
+type isolator interface {
+	command(ctx context.Context, lang Language, dir string, s step, limits Limits, argv []string) *exec.Cmd
+}
+
+// language describes how to build and run one language inside the work
+// directory.
+type language struct {
+	file    string
+	compile []string
+	run     []string
+}
+
+var languages = map[Language]language{
+	Go: {
+		file:    "main.go",
+		compile: []string{"go", "build", "-o", "main", "main.go"},
+		run:     []string{"./main"},
+	},
+	Python: {
+		file:    "main.py",
+		compile: []string{"python3", "-m", "py_compile", "main.py"},
+		run:     []string{"python3", "main.py"},
+	},
+}
+
+type runner struct {
+	iso isolator
+}
+
+func (r *runner) Run(ctx context.Context, lang Language, source string, inputs []string, limits Limits) (Execution, error) {
+	var execution Execution
+	spec, ok := languages[lang]
+	if !ok {
+		return execution, fmt.Errorf("sandbox: unsupported language %q", lang)
+	}
+
+	dir, err := os.MkdirTemp("", "sandbox-")
+	if err != nil {
+		return execution, err
+	}
+	defer os.RemoveAll(dir)
+	// The sandboxed user is not the server's user, so the directory has to
+	// be open to it while compiling.
+	if err := os.Chmod(dir, 0o777); err != nil {
+		return execution, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, spec.file), []byte(source), 0o644); err != nil {
+		return execution, err
+	}
+
+	compiled, err := r.exec(ctx, lang, dir, stepCompile, compileLimits, spec.compile, "")
+	if err != nil {
+		return execution, err
+	}
+	if compiled.Status != StatusOK {
+		execution.CompileFailed = true
+		execution.CompileOutput = strings.TrimSpace(compiled.Stderr + "\n" + compiled.Stdout)
+		if compiled.Status == StatusTimeLimit {
+			execution.CompileOutput = "compilation timed out"
+		}
+		return execution, nil
+	}
+
+	for _, input := range inputs {
+		result, err := r.exec(ctx, lang, dir, stepRun, limits, spec.run, input)
+		if err != nil {
+			return execution, err
+		}
+		execution.Results = append(execution.Results, result)
+	}
+	return execution, nil
+}
+
+// exec runs argv once and classifies how it ended. Errors are reserved for
+// failures of the sandbox itself, not of the program.
+func (r *runner) exec(ctx context.Context, lang Language, dir string, s step, limits Limits, argv []string, input string) (Result, error) {
+	ctx, cancel := context.WithTimeout(ctx, limits.WallTime)
+	defer cancel()
+
+	cmd := r.iso.command(ctx, lang, dir, s, limits, argv)
+	stdout := &cappedBuffer{limit: limits.OutputBytes}
+	stderr := &cappedBuffer{limit: limits.OutputBytes}
+	cmd.Stdin = strings.NewReader(input)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.WaitDelay = time.Second
+
+	started := time.Now()
+	err := cmd.Run()
+	result := Result{
+		Stdout:    stdout.String(),
+		Stderr:    stderr.String(),
+		Duration:  time.Since(started),
+		Truncated: stdout.truncated || stderr.truncated,
+	}
+
+	var exitErr *exec.ExitError
+	if err != nil && !errors.As(err, &exitErr) && ctx.Err() == nil {
+		return result, fmt.Errorf("sandbox: %w", err)
+	}
+	if cmd.ProcessState != nil {
+		result.ExitCode = exitCode(cmd.ProcessState)
+	}
+	result.Status = classify(result, errors.Is(ctx.Err(), context.DeadlineExceeded))
+	return result, nil
+}
+
+// exitCode reports a process's exit code, using the shell convention of
+// 128+signal for processes killed by a signal.
+func exitCode(state *os.ProcessState) int {
+	if code := state.ExitCode(); code != -1 {
+		return code
+	}
+	if status, ok := state.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	return -1
+}
+
+const (
+	exitSIGKILL = 128 + 9
+	exitSIGXCPU = 128 + 24
+)
+
+// classify works out how a run ended from its exit code and output. The
+// process state is that of the container client, not of the program, so its
+// CPU time says nothing about the program: the CPU limit is enforced inside
+// the container, which is killed with SIGXCPU when it runs out, and the wall
+// clock catches programs that wait instead of computing.
+func classify(result Result, timedOut bool) Status {
+	switch {
+	case timedOut, result.ExitCode == exitSIGXCPU:
+		return StatusTimeLimit
+	case result.ExitCode == exitSIGKILL:
+		// The kernel's OOM killer is what sends SIGKILL to a container
+		// that is within its time limits.
+		return StatusMemoryLimit
+	case strings.Contains(result.Stderr, "MemoryError"), strings.Contains(result.Stderr, "out of memory"):
+		return StatusMemoryLimit
+	case result.ExitCode != 0:
+		return StatusRuntimeError
+	}
+	return StatusOK
+}
+
+// cappedBuffer keeps the first limit bytes written to it.
+type cappedBuffer struct {
+	buf       bytes.Buffer
+	limit     int
+	truncated bool
+}
+
+func (b *cappedBuffer) Write(p []byte) (int, error) {
+	if room := b.limit - b.buf.Len(); room < len(p) {
+		b.truncated = true
+		if room > 0 {
+			b.buf.Write(p[:room])
+		}
+		return len(p), nil
+	}
+	return b.buf.Write(p)
+}
+
+func (b *cappedBuffer) String() string {
+	return b.buf.String()
+}
@@ -0,0 +1,153 @@
+package storage
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+)
+
+type completedPart struct {
+	PartNumber int
+	ETag       string
+}
+
+// putMultipart uploads a body too large for one request. first holds the
+// first part, already read from rest, and is reused as the buffer for the
+// parts after it. An upload that fails part way is aborted so its parts do
+// not linger in the bucket.
+func (s *S3) putMultipart(ctx context.Context, key string, first []byte, rest io.Reader, contentType string) error {
+	uploadID, err := s.createMultipartUpload(ctx, key, contentType)
+	if err != nil {
+		return err
+	}
+	if err := s.uploadParts(ctx, key, uploadID, first, rest); err != nil {
+		if abortErr := s.abortMultipartUpload(context.WithoutCancel(ctx), key, uploadID); abortErr != nil {
+			return errors.Join(err, abortErr)
+		}
+		return err
+	}
+	return nil
+}
+
+func (s *S3) uploadParts(ctx context.Context, key, uploadID string, buf []byte, rest io.Reader) error {
+	var parts []completedPart
+	part := buf
+	for number := 1; ; number++ {
+		etag, err := s.uploadPart(ctx, key, uploadID, number, part)
+		if err != nil {
+			return err
+		}
+		parts = append(parts, completedPart{PartNumber: number, ETag: etag})
+		if len(part) < len(buf) {
+			break
+		}
+		n, err := io.ReadFull(rest, buf)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil && !errors.Is(err, io.ErrUnexpectedEOF) {
+			return err
+		}
+		part = buf[:n]
+	}
+	return s.completeMultipartUpload(ctx, key, uploadID, parts)
+}
+
+func (s *S3) createMultipartUpload(ctx context.Context, key, contentType string) (string, error) {
+	req, err := s.newRequest(ctx, http.MethodPost, key, url.Values{"uploads": {""}}, nil)
+	if err != nil {
+		return "", err
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	resp, err := s.do(req, nil)
+	if err != nil {
+		return "", err
+	}
+	defer resp.Body.Close()
+	var result struct {
+		UploadID string `xml:"UploadId"`
+	}
+	if err := xml.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return "", fmt.Errorf("storage: reading multipart upload: %w", err)
+	}
+	if result.UploadID == "" {
+		return "", errors.New("storage: S3 returned no upload ID")
+	}
+	return result.UploadID, nil
+}
+
+// uploadPart sends one part. The response body is closed before returning,
+// after which the part's buffer may be reused.
+func (s *S3) uploadPart(ctx context.Context, key, uploadID string, number int, part []byte) (string, error) {
+	query := url.Values{"partNumber": {strconv.Itoa(number)}, "uploadId": {uploadID}}
+	req, err := s.newRequest(ctx, http.MethodPut, key, query, part)
+	if err != nil {
+		return "", err
+	}
+	resp, err := s.do(req, part)
+	if err != nil {
+		return "", err
+	}
+	resp.Body.Close()
+	etag := resp.Header.Get("ETag")
+	if etag == "" {
+		return "", fmt.Errorf("storage: S3 returned no ETag for part %d", number)
+	}
+	return etag, nil
+}
+
+func (s *S3) completeMultipartUpload(ctx context.Context, key, uploadID string, parts []completedPart) error {
+	body, err := xml.Marshal(struct {
+		XMLName xml.Name        `xml:"CompleteMultipartUpload"`
+		Parts   []completedPart `xml:"Part"`
+	}{Parts: parts})
+	if err != nil {
+		return err
+	}
+	req, err := s.newRequest(ctx, http.MethodPost, key, url.Values{"uploadId": {uploadID}}, body)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	// Completion can fail after S3 has already answered 200, in which case
+	// the body is an Error document rather than the result.
+	var result struct {
+		XMLName xml.Name
+		Code    string `xml:"Code"`
+		Message string `xml:"Message"`
+	}
+	if err := xml.NewDecoder(io.LimitReader(resp.Body, 64<<10)).Decode(&result); err != nil {
+		return fmt.Errorf("storage: reading multipart completion: %w", err)
+	}
+	if result.XMLName.Local == "Error" {
+		return fmt.Errorf("storage: S3 completing upload of %s: %s: %s", key, result.Code, result.Message)
+	}
+	return nil
+}
+
+func (s *S3) abortMultipartUpload(ctx context.Context, key, uploadID string) error {
+	req, err := s.newRequest(ctx, http.MethodDelete, key, url.Values{"uploadId": {uploadID}}, nil)
+	if err != nil {
+		return err
+	}
+	resp, err := s.do(req, nil)
+	if errors.Is(err, ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
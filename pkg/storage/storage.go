@@ -0,0 +1,49 @@
+// Package storage keeps uploaded files on the local disk or in an
+// S3-compatible object store.
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrNotFound is returned when no object is stored under a key.
+var ErrNotFound = errors.New("storage: object not found")
+
+// Storage stores objects under slash-separated keys.
+type Storage interface {
+	// Put stores everything read from body under key, replacing any object
+	// already there. Bodies are streamed, so their size need not be known
+	// up front and they are never held in memory whole.
+	Put(ctx context.Context, key string, body io.Reader, contentType string) error
+	// Get opens an object for reading. The caller must close it.
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	// Delete removes an object. Deleting a missing object is not an error.
+	Delete(ctx context.Context, key string) error
+}
+
+// Config selects and configures a storage backend.
+type Config struct {
+	// Driver is "local" (the default) or "s3".
+	Driver string
+	// LocalDir is where the local driver keeps files.
+	LocalDir string
+	S3       S3Config
+}
+
+// New returns the backend cfg asks for.
+func New(cfg Config) (Storage, error) {
+	switch cfg.Driver {
+	case "", "local":
+		dir := cfg.LocalDir
+		if dir == "" {
+			dir = "uploads"
+		}
+		return NewLocal(dir)
+	case "s3":
+		return NewS3(cfg.S3)
+	}
+	return nil, fmt.Errorf("storage: unknown driver %q", cfg.Driver)
+}
@@ -0,0 +1,94 @@
+// Package virusscan checks uploaded files for malware with ClamAV.
+package virusscan
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	// defaultTimeout bounds a scan when the context has no deadline.
+	defaultTimeout = 30 * time.Second
+	// chunkSize is how much of the file goes in each INSTREAM chunk. It must
+	// stay under clamd's StreamMaxLength.
+	chunkSize = 64 << 10
+)
+
+// Clamd scans content by streaming it to a clamd daemon.
+type Clamd struct {
+	network string
+	addr    string
+}
+
+// NewClamd talks to clamd at addr, which is a host:port or, when it starts
+// with a slash, a Unix socket path.
+func NewClamd(addr string) *Clamd {
+	network := "tcp"
+	if strings.HasPrefix(addr, "/") {
+		network = "unix"
+	}
+	return &Clamd{network: network, addr: addr}
+}
+
+// Scan reports the name of the threat found in content, or "" if it is
+// clean. An error means the content could not be scanned.
+func (c *Clamd) Scan(ctx context.Context, content []byte) (string, error) {
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, c.network, c.addr)
+	if err != nil {
+		return "", fmt.Errorf("virusscan: connecting to clamd: %w", err)
+	}
+	defer conn.Close()
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(defaultTimeout)
+	}
+	conn.SetDeadline(deadline)
+
+	// INSTREAM takes the file as length-prefixed chunks ended by an empty
+	// one; the z prefix asks for null-terminated replies.
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return "", fmt.Errorf("virusscan: sending to clamd: %w", err)
+	}
+	var size [4]byte
+	for len(content) > 0 {
+		n := min(len(content), chunkSize)
+		binary.BigEndian.PutUint32(size[:], uint32(n))
+		if _, err := conn.Write(size[:]); err != nil {
+			return "", fmt.Errorf("virusscan: sending to clamd: %w", err)
+		}
+		if _, err := conn.Write(content[:n]); err != nil {
+			return "", fmt.Errorf("virusscan: sending to clamd: %w", err)
+		}
+		content = content[n:]
+	}
+	binary.BigEndian.PutUint32(size[:], 0)
+	if _, err := conn.Write(size[:]); err != nil {
+		return "", fmt.Errorf("virusscan: sending to clamd: %w", err)
+	}
+
+	reply, err := bufio.NewReader(conn).ReadString(0)
+	if err != nil && reply == "" {
+		return "", fmt.Errorf("virusscan: reading clamd reply: %w", err)
+	}
+	return parseReply(reply)
+}
+
+// parseReply reads clamd's answer, such as "stream: OK" or
+// "stream: Eicar-Signature FOUND".
+func parseReply(reply string) (string, error) {
+	reply = strings.TrimSpace(strings.TrimRight(reply, "\x00"))
+	result := strings.TrimPrefix(reply, "stream: ")
+	switch {
+	case result == "OK":
+		return "", nil
+	case strings.HasSuffix(result, " FOUND"):
+		return strings.TrimSuffix(result, " FOUND"), nil
+	}
+	return "", fmt.Errorf("virusscan: clamd replied %q", reply)
+}
@@ -0,0 +1,222 @@
+package accommodation
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// maxTimeMultiplier bounds how far a session's time limit can be stretched.
+	maxTimeMultiplier = 4
+	// maxBreakMinutes bounds the break allowance added to a session.
+	maxBreakMinutes = 240
+)
+
+// alternativeFormats are the presentation changes the front end can apply.
+var alternativeFormats = []string{"large_print", "high_contrast", "screen_reader", "text_to_speech", "reduced_motion"}
+
+type AccommodationHandler struct {
+	db      *pgxpool.Pool
+	queries *Queries
+}
+
+func NewAccommodationHandler(db *pgxpool.Pool, queries *Queries) *AccommodationHandler {
+	return &AccommodationHandler{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// validateSettings checks that an accommodation changes something and stays
+// within bounds.
+func validateSettings(timeMultiplier float64, breakMinutes int32, formats []string) error {
+	if timeMultiplier < 1 || timeMultiplier > maxTimeMultiplier {
+		return fmt.Errorf("time multiplier must be between 1 and %d", maxTimeMultiplier)
+	}
+	if breakMinutes < 0 || breakMinutes > maxBreakMinutes {
+		return fmt.Errorf("break minutes must be between 0 and %d", maxBreakMinutes)
+	}
+	for i, format := range formats {
+		if !slices.Contains(alternativeFormats, format) {
+			return fmt.Errorf("unknown alternative format %q; expected one of %s", format, strings.Join(alternativeFormats, ", "))
+		}
+		if slices.Contains(formats[:i], format) {
+			return fmt.Errorf("alternative format %q appears more than once", format)
+		}
+	}
+	if timeMultiplier == 1 && breakMinutes == 0 && len(formats) == 0 {
+		return errors.New("an accommodation needs extra time, breaks or an alternative format")
+	}
+	return nil
+}
+
+func (h *AccommodationHandler) GrantAccommodation(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	approverID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req struct {
+		UserID             int32    `json:"user_id" binding:"required"`
+		SessionID          *int32   `json:"session_id"`
+		TimeMultiplier     *float64 `json:"time_multiplier"`
+		BreakMinutes       int32    `json:"break_minutes"`
+		AlternativeFormats []string `json:"alternative_formats"`
+		Reason             string   `json:"reason" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	timeMultiplier := 1.0
+	if req.TimeMultiplier != nil {
+		timeMultiplier = *req.TimeMultiplier
+	}
+	if req.AlternativeFormats == nil {
+		req.AlternativeFormats = []string{}
+	}
+	if err := validateSettings(timeMultiplier, req.BreakMinutes, req.AlternativeFormats); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if strings.TrimSpace(req.Reason) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A reason is required"})
+		return
+	}
+
+	if _, err := h.queries.GetCandidate(c, req.UserID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load candidate"})
+		return
+	}
+
+	var sessionID pgtype.Int4
+	if req.SessionID != nil {
+		session, err := h.queries.GetAssessmentSession(c, *req.SessionID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+				return
+			}
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session"})
+			return
+		}
+		if session.UserID != req.UserID {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Session belongs to a different candidate"})
+			return
+		}
+		if session.CompletedAt.Valid || session.SubmittedAt.Valid {
+			c.JSON(http.StatusConflict, gin.H{"error": "Session has already been submitted"})
+			return
+		}
+		sessionID = pgtype.Int4{Int32: session.ID, Valid: true}
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	accommodation, err := qtx.CreateAccommodation(c, CreateAccommodationParams{
+		UserID:             req.UserID,
+		SessionID:          sessionID,
+		TimeMultiplier:     timeMultiplier,
+		BreakMinutes:       req.BreakMinutes,
+		AlternativeFormats: req.AlternativeFormats,
+		Reason:             strings.TrimSpace(req.Reason),
+		ApprovedBy:         int32(approverID),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save accommodation"})
+		return
+	}
+	// Candidate-wide accommodations apply from the next session on; a
+	// session-specific one applies straight away.
+	if sessionID.Valid {
+		err = qtx.LinkSessionAccommodation(c, LinkSessionAccommodationParams{
+			SessionID:       sessionID.Int32,
+			AccommodationID: accommodation.ID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply accommodation"})
+			return
+		}
+	}
+
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit accommodation"})
+		return
+	}
+	c.JSON(http.StatusCreated, accommodation)
+}
+
+func (h *AccommodationHandler) ListCandidateAccommodations(c *gin.Context) {
+	userID, err := strconv.ParseInt(c.Param("user_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	accommodations, err := h.queries.ListCandidateAccommodations(c, int32(userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve accommodations"})
+		return
+	}
+	if accommodations == nil {
+		accommodations = []ListCandidateAccommodationsRow{}
+	}
+	c.JSON(http.StatusOK, accommodations)
+}
+
+func (h *AccommodationHandler) RevokeAccommodation(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	revokerID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid accommodation ID"})
+		return
+	}
+
+	accommodation, err := h.queries.RevokeAccommodation(c, RevokeAccommodationParams{
+		ID:        int32(id),
+		RevokedBy: pgtype.Int4{Int32: int32(revokerID), Valid: true},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Accommodation not found or already revoked"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to revoke accommodation"})
+		return
+	}
+	c.JSON(http.StatusOK, accommodation)
+}
@@ -0,0 +1,59 @@
+-- name: GetCandidate :one
+SELECT u.id, u.name, u.email FROM users u
+WHERE u.id = $1
+  AND u.role_id IS DISTINCT FROM (SELECT id FROM roles WHERE name = 'admin');
+
+-- name: GetAssessmentSession :one
+SELECT * FROM user_assessment_sessions
+WHERE id = $1;
+
+-- name: CreateAccommodation :one
+INSERT INTO candidate_accommodations (
+    user_id,
+    session_id,
+    time_multiplier,
+    break_minutes,
+    alternative_formats,
+    reason,
+    approved_by
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+) RETURNING *;
+
+-- name: LinkSessionAccommodation :exec
+-- A session-specific accommodation replaces whatever the session ran under
+INSERT INTO session_accommodations (session_id, accommodation_id)
+VALUES ($1, $2)
+ON CONFLICT (session_id) DO UPDATE
+SET accommodation_id = EXCLUDED.accommodation_id;
+
+-- name: ListCandidateAccommodations :many
+SELECT
+  a.id,
+  a.user_id,
+  a.session_id,
+  a.time_multiplier,
+  a.break_minutes,
+  a.alternative_formats,
+  a.reason,
+  a.approved_by,
+  approver.name AS approver_name,
+  a.created_at,
+  a.revoked_at,
+  a.revoked_by
+FROM candidate_accommodations a
+JOIN users approver ON approver.id = a.approved_by
+WHERE a.user_id = $1
+ORDER BY a.created_at DESC, a.id DESC;
+
+-- name: RevokeAccommodation :one
+UPDATE candidate_accommodations
+SET revoked_at = CURRENT_TIMESTAMP, revoked_by = $2
+WHERE id = $1 AND revoked_at IS NULL
+RETURNING *;
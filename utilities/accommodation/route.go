@@ -0,0 +1,15 @@
+package accommodation
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesAccommodation(r *gin.Engine, accommodationHandler *AccommodationHandler) {
+	admin := r.Group("accommodations")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.POST("", accommodationHandler.GrantAccommodation)
+	admin.GET("/candidates/:user_id", accommodationHandler.ListCandidateAccommodations)
+	admin.POST("/:id/revoke", accommodationHandler.RevokeAccommodation)
+}
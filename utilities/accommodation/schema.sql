@@ -0,0 +1,53 @@
+CREATE TABLE IF NOT EXISTS roles(
+    id SERIAL PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    created_at timestamp default now()
+);
+
+CREATE TABLE IF NOT EXISTS users(
+    id SERIAL PRIMARY KEY,
+    role_id integer null,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password varchar(100) NOT NULL,
+    created_at timestamp default now(),
+    constraint fk_role foreign key (role_id) REFERENCES roles(id) on delete SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_sessions(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    started_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    completed_at timestamp null,
+    blueprint_id int null,
+    quality_flags JSONB NOT NULL DEFAULT '[]'::jsonb,
+    submitted_at timestamp null
+);
+
+CREATE TABLE IF NOT EXISTS candidate_accommodations(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    session_id int null,
+    time_multiplier double precision not null DEFAULT 1,
+    break_minutes int not null DEFAULT 0,
+    alternative_formats text[] not null DEFAULT '{}',
+    reason text not null,
+    approved_by int not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    revoked_at timestamp null,
+    revoked_by int null,
+    constraint fk_accommodation_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_accommodation_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_accommodation_approver foreign key (approved_by) REFERENCES users(id),
+    constraint fk_accommodation_revoker foreign key (revoked_by) REFERENCES users(id),
+    constraint ck_accommodation_time_multiplier check (time_multiplier >= 1 AND time_multiplier <= 4),
+    constraint ck_accommodation_break_minutes check (break_minutes >= 0)
+);
+
+CREATE TABLE IF NOT EXISTS session_accommodations(
+    session_id int PRIMARY KEY,
+    accommodation_id int not null,
+    constraint fk_session_accommodation_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_session_accommodation_accommodation foreign key (accommodation_id) REFERENCES candidate_accommodations(id) on delete CASCADE
+);
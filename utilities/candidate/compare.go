@@ -0,0 +1,120 @@
+package candidates
+
+import "math"
+
+// maxComparedCandidates caps how many candidates one comparison can return.
+const maxComparedCandidates = 10
+
+type categoryKey struct {
+	assessmentType string
+	categoryID     int32
+}
+
+// ComparisonAxis is one spoke of the radar chart: a category within an assessment type.
+type ComparisonAxis struct {
+	AssessmentType string   `json:"assessment_type"`
+	CategoryID     int32    `json:"category_id"`
+	CategoryName   string   `json:"category_name"`
+	MaxScore       int32    `json:"max_score"`
+	CohortAverage  *float64 `json:"cohort_average"`
+	IdealScore     *int32   `json:"ideal_score"`
+}
+
+// ComparedScore is a candidate's result on the axis at the same index.
+// Fields are nil when the candidate has no score for that category.
+type ComparedScore struct {
+	Score       *int32   `json:"score"`
+	Percent     *float64 `json:"percent"`
+	DeltaIdeal  *float64 `json:"delta_ideal"`
+	DeltaCohort *float64 `json:"delta_cohort"`
+}
+
+type CandidateComparison struct {
+	UserID int32           `json:"user_id"`
+	Name   string          `json:"name"`
+	Email  string          `json:"email"`
+	Scores []ComparedScore `json:"scores"`
+}
+
+// buildComparison aligns every candidate's scores on the same ordered list of axes.
+func buildComparison(
+	candidates []ListCandidatesByIDsRow,
+	scores []ListLatestCandidateScoresRow,
+	maxScores []ListCategoryMaxScoresRow,
+	averages []ListCohortCategoryAveragesRow,
+	targets []JobProfileTarget,
+) ([]ComparisonAxis, []CandidateComparison) {
+	averageByKey := make(map[categoryKey]float64)
+	for _, a := range averages {
+		averageByKey[categoryKey{a.AssessmentType, a.CategoryID.Int32}] = a.AverageScore
+	}
+
+	idealByKey := make(map[categoryKey]int32)
+	for _, t := range targets {
+		idealByKey[categoryKey{t.AssessmentType, t.CategoryID}] = t.IdealScore
+	}
+
+	axes := make([]ComparisonAxis, 0, len(maxScores))
+	for _, m := range maxScores {
+		key := categoryKey{m.AssessmentType, m.CategoryID}
+		axis := ComparisonAxis{
+			AssessmentType: m.AssessmentType,
+			CategoryID:     m.CategoryID,
+			CategoryName:   m.CategoryName.String,
+			MaxScore:       m.MaxScore,
+		}
+		if avg, ok := averageByKey[key]; ok {
+			axis.CohortAverage = &avg
+		}
+		if ideal, ok := idealByKey[key]; ok {
+			axis.IdealScore = &ideal
+		}
+		axes = append(axes, axis)
+	}
+
+	scoreByUser := make(map[int32]map[categoryKey]int32)
+	for _, s := range scores {
+		if !s.CategoryID.Valid {
+			continue
+		}
+		if scoreByUser[s.UserID] == nil {
+			scoreByUser[s.UserID] = make(map[categoryKey]int32)
+		}
+		scoreByUser[s.UserID][categoryKey{s.AssessmentType, s.CategoryID.Int32}] = s.Score.Int32
+	}
+
+	comparisons := make([]CandidateComparison, 0, len(candidates))
+	for _, c := range candidates {
+		comparison := CandidateComparison{
+			UserID: c.ID,
+			Name:   c.Name,
+			Email:  c.Email,
+			Scores: make([]ComparedScore, len(axes)),
+		}
+		for idx, axis := range axes {
+			score, ok := scoreByUser[c.ID][categoryKey{axis.AssessmentType, axis.CategoryID}]
+			if !ok {
+				continue
+			}
+			compared := ComparedScore{Score: &score}
+			if axis.MaxScore > 0 {
+				compared.Percent = roundedFloat(float64(score) / float64(axis.MaxScore) * 100)
+			}
+			if axis.IdealScore != nil {
+				compared.DeltaIdeal = roundedFloat(float64(score - *axis.IdealScore))
+			}
+			if axis.CohortAverage != nil {
+				compared.DeltaCohort = roundedFloat(float64(score) - *axis.CohortAverage)
+			}
+			comparison.Scores[idx] = compared
+		}
+		comparisons = append(comparisons, comparison)
+	}
+
+	return axes, comparisons
+}
+
+func roundedFloat(v float64) *float64 {
+	rounded := math.Round(v*100) / 100
+	return &rounded
+}
@@ -0,0 +1,305 @@
+package candidates
+
+import (
+	"backend/pkg/filters"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/xuri/excelize/v2"
+)
+
+// streamCandidateExport is not generated by sqlc: the export has to be read
+// row by row from the result cursor instead of being collected into a slice.
+const streamCandidateExport = `
+SELECT
+  u.id,
+  u.name,
+  u.email,
+  u.created_at,
+  COALESCE((
+    SELECT jsonb_agg(jsonb_build_object(
+      'assessment_type', s.assessment_type,
+      'started_at', to_char(s.started_at, 'YYYY-MM-DD HH24:MI:SS'),
+      'completed_at', to_char(s.completed_at, 'YYYY-MM-DD HH24:MI:SS'),
+      'scores', COALESCE((
+        SELECT jsonb_object_agg(sc.category_id, sc.score)
+        FROM user_assessment_scores sc
+        WHERE sc.session_id = s.id AND sc.category_id IS NOT NULL
+      ), '{}'::jsonb)
+    ))
+    FROM (
+      SELECT DISTINCT ON (assessment_type) id, assessment_type, started_at, completed_at
+      FROM user_assessment_sessions
+      WHERE user_id = u.id
+      ORDER BY assessment_type, started_at DESC, id DESC
+    ) s
+  ), '[]'::jsonb) AS sessions
+FROM users u
+WHERE
+  u.id IN (SELECT user_id FROM candidate_listing) AND
+  ($1::text IS NULL OR u.name ILIKE '%' || $1 || '%' OR u.email ILIKE '%' || $1 || '%') AND
+  ($2::text IS NULL OR EXISTS (
+    SELECT 1 FROM user_assessment_sessions s
+    WHERE s.user_id = u.id AND s.assessment_type = $2 AND s.completed_at IS NOT NULL
+  )) AND
+  ($3::timestamp IS NULL OR EXISTS (
+    SELECT 1 FROM user_assessment_sessions s
+    WHERE s.user_id = u.id AND s.completed_at >= $3
+  )) AND
+  ($4::timestamp IS NULL OR EXISTS (
+    SELECT 1 FROM user_assessment_sessions s
+    WHERE s.user_id = u.id AND s.completed_at < $4
+  ))
+ORDER BY u.id
+`
+
+type exportSession struct {
+	AssessmentType string            `json:"assessment_type"`
+	StartedAt      *string           `json:"started_at"`
+	CompletedAt    *string           `json:"completed_at"`
+	Scores         map[string]*int32 `json:"scores"`
+}
+
+type exportCandidate struct {
+	UserID    int32
+	Name      string
+	Email     string
+	CreatedAt pgtype.Timestamp
+	Sessions  []exportSession
+}
+
+// StreamCandidateExport calls fn once per candidate matching the filter, in
+// user ID order. Candidates and filters are the same as in the candidate
+// listing, so an export holds exactly the people staff were looking at.
+func (q *Queries) StreamCandidateExport(ctx context.Context, filter filters.CandidateFilter, fn func(exportCandidate) error) error {
+	rows, err := q.db.Query(ctx, streamCandidateExport,
+		filter.Search,
+		filter.AssessmentType,
+		filter.CompletedFrom,
+		filter.CompletedTo,
+	)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var i exportCandidate
+		var sessions []byte
+		if err := rows.Scan(
+			&i.UserID,
+			&i.Name,
+			&i.Email,
+			&i.CreatedAt,
+			&sessions,
+		); err != nil {
+			return err
+		}
+		if err := json.Unmarshal(sessions, &i.Sessions); err != nil {
+			return err
+		}
+		if err := fn(i); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+// exportColumns lists the spreadsheet columns: the candidate profile followed,
+// per assessment type, by session dates, status and one column per category.
+func exportColumns(axes []ListCategoryMaxScoresRow) []string {
+	columns := []string{"User ID", "Name", "Email", "Registered At"}
+	for _, assessmentType := range assessmentTypes {
+		title := strings.ToUpper(assessmentType[:1]) + assessmentType[1:]
+		columns = append(columns, title+" Status", title+" Started At", title+" Completed At")
+		for _, axis := range axes {
+			if axis.AssessmentType == assessmentType {
+				columns = append(columns, fmt.Sprintf("%s: %s", title, axis.CategoryName.String))
+			}
+		}
+	}
+	return columns
+}
+
+func exportRecord(candidate exportCandidate, axes []ListCategoryMaxScoresRow) []string {
+	record := []string{
+		strconv.Itoa(int(candidate.UserID)),
+		candidate.Name,
+		candidate.Email,
+		"",
+	}
+	if candidate.CreatedAt.Valid {
+		record[3] = candidate.CreatedAt.Time.Format("2006-01-02 15:04:05")
+	}
+
+	for _, assessmentType := range assessmentTypes {
+		var session *exportSession
+		for i := range candidate.Sessions {
+			if candidate.Sessions[i].AssessmentType == assessmentType {
+				session = &candidate.Sessions[i]
+				break
+			}
+		}
+
+		status, startedAt, completedAt := "Not Started", "", ""
+		if session != nil {
+			status = "In Progress"
+			if session.StartedAt != nil {
+				startedAt = *session.StartedAt
+			}
+			if session.CompletedAt != nil {
+				status = "Completed"
+				completedAt = *session.CompletedAt
+			}
+		}
+		record = append(record, status, startedAt, completedAt)
+
+		for _, axis := range axes {
+			if axis.AssessmentType != assessmentType {
+				continue
+			}
+			value := ""
+			if session != nil {
+				if score := session.Scores[strconv.Itoa(int(axis.CategoryID))]; score != nil {
+					value = strconv.Itoa(int(*score))
+				}
+			}
+			record = append(record, value)
+		}
+	}
+	return record
+}
+
+// exportContentTypes are the formats an export can be written in.
+var exportContentTypes = map[string]string{
+	"csv":  "text/csv; charset=utf-8",
+	"xlsx": "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// writeExport writes every candidate matching the filter to w in the given
+// format, one row per candidate after a header row.
+func (h *CandidateHandler) writeExport(ctx context.Context, w io.Writer, format string, filter filters.CandidateFilter) error {
+	axes, err := h.queries.ListCategoryMaxScores(ctx)
+	if err != nil {
+		return fmt.Errorf("fetching categories: %w", err)
+	}
+
+	var writer exportWriter
+	if format == "xlsx" {
+		xlsxWriter, err := newXLSXExportWriter(w)
+		if err != nil {
+			return fmt.Errorf("creating spreadsheet: %w", err)
+		}
+		writer = xlsxWriter
+	} else {
+		writer = newCSVExportWriter(w)
+	}
+
+	if err := writer.Write(exportColumns(axes)); err != nil {
+		writer.Discard()
+		return err
+	}
+	err = h.queries.StreamCandidateExport(ctx, filter, func(candidate exportCandidate) error {
+		return writer.Write(exportRecord(candidate, axes))
+	})
+	if err != nil {
+		writer.Discard()
+		return err
+	}
+	return writer.Close()
+}
+
+// escapeFormula stops a cell from being read as a formula when the export is
+// opened in a spreadsheet. Candidates choose their own names, so a name like
+// "=HYPERLINK(...)" must come out as text.
+func escapeFormula(value string) string {
+	if value != "" && strings.ContainsRune("=+-@\t\r", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
+// exportWriter writes an export one record at a time. Close finishes the
+// output; Discard releases resources when the export is abandoned.
+type exportWriter interface {
+	Write(record []string) error
+	Close() error
+	Discard()
+}
+
+type csvExportWriter struct {
+	w *csv.Writer
+}
+
+func newCSVExportWriter(w io.Writer) *csvExportWriter {
+	return &csvExportWriter{w: csv.NewWriter(w)}
+}
+
+func (e *csvExportWriter) Write(record []string) error {
+	escaped := make([]string, len(record))
+	for i, v := range record {
+		escaped[i] = escapeFormula(v)
+	}
+	return e.w.Write(escaped)
+}
+
+func (e *csvExportWriter) Close() error {
+	e.w.Flush()
+	return e.w.Error()
+}
+
+func (e *csvExportWriter) Discard() {}
+
+// xlsxExportWriter uses excelize's stream writer, which spills rows to a
+// temporary file instead of keeping the whole sheet in memory.
+type xlsxExportWriter struct {
+	out    io.Writer
+	file   *excelize.File
+	stream *excelize.StreamWriter
+	row    int
+}
+
+func newXLSXExportWriter(w io.Writer) (*xlsxExportWriter, error) {
+	file := excelize.NewFile()
+	stream, err := file.NewStreamWriter("Sheet1")
+	if err != nil {
+		file.Close()
+		return nil, err
+	}
+	return &xlsxExportWriter{out: w, file: file, stream: stream}, nil
+}
+
+func (e *xlsxExportWriter) Write(record []string) error {
+	e.row++
+	cell, err := excelize.CoordinatesToCellName(1, e.row)
+	if err != nil {
+		return err
+	}
+	values := make([]interface{}, len(record))
+	for i, v := range record {
+		// Keep numbers numeric so they can be charted and summed in the sheet.
+		if n, err := strconv.Atoi(v); err == nil && e.row > 1 {
+			values[i] = n
+		} else {
+			values[i] = escapeFormula(v)
+		}
+	}
+	return e.stream.SetRow(cell, values)
+}
+
+func (e *xlsxExportWriter) Close() error {
+	defer e.file.Close()
+	if err := e.stream.Flush(); err != nil {
+		return err
+	}
+	return e.file.Write(e.out)
+}
+
+func (e *xlsxExportWriter) Discard() {
+	e.file.Close()
+}
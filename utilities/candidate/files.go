@@ -0,0 +1,346 @@
+package candidates
+
+import (
+	"backend/pkg/filters"
+	"backend/pkg/storage"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// Background jobs that generate the files staff request.
+const (
+	ReportJobKind = "candidate.report"
+	ExportJobKind = "candidate.export"
+)
+
+// FileJob is the payload of a ReportJobKind or ExportJobKind job.
+type FileJob struct {
+	FileID int64 `json:"file_id"`
+}
+
+type reportParams struct {
+	CandidateID int32 `json:"candidate_id"`
+}
+
+type exportParams struct {
+	Format string                  `json:"format"`
+	Filter filters.CandidateFilter `json:"filter"`
+}
+
+// File is a requested report or export as shown to staff.
+type File struct {
+	ID         int64      `json:"id"`
+	Kind       string     `json:"kind"`
+	Status     string     `json:"status"`
+	FileName   string     `json:"file_name,omitempty"`
+	SizeBytes  int64      `json:"size_bytes,omitempty"`
+	CreatedAt  time.Time  `json:"created_at"`
+	FinishedAt *time.Time `json:"finished_at,omitempty"`
+}
+
+func newFile(row GetCandidateFileRow) File {
+	file := File{
+		ID:        row.ID,
+		Kind:      row.Kind,
+		Status:    row.Status,
+		FileName:  row.Filename.String,
+		SizeBytes: row.SizeBytes.Int64,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	if row.FinishedAt.Valid {
+		file.FinishedAt = &row.FinishedAt.Time
+	}
+	return file
+}
+
+// RequestReport queues a candidate's PDF report. The response points at the
+// file, which can be downloaded once its status is ready.
+func (h *CandidateHandler) RequestReport(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+	if _, err := h.queries.GetCandidate(c, int32(id)); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+		return
+	}
+	h.requestFile(c, "report", ReportJobKind, reportParams{CandidateID: int32(id)})
+}
+
+// GetCandidateReport renders a candidate's PDF report on request. A single
+// report is small enough to build while the client waits; RequestReport
+// stores one for later download instead.
+func (h *CandidateHandler) GetCandidateReport(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+	candidate, err := h.queries.GetCandidate(c, int32(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+		return
+	}
+	report, err := h.buildReport(c, candidate)
+	if err != nil {
+		log.Printf("candidate: building report for %d: %v", candidate.ID, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to build report"})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := renderCandidateReport(&buf, report); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to generate report: %v", err)})
+		return
+	}
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="candidate-%d-report.pdf"`, candidate.ID))
+	c.Data(http.StatusOK, "application/pdf", buf.Bytes())
+}
+
+// RequestExport queues a spreadsheet of the candidates matching the filters.
+func (h *CandidateHandler) RequestExport(c *gin.Context) {
+	format := c.DefaultQuery("format", "csv")
+	if _, ok := exportContentTypes[format]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format must be csv or xlsx"})
+		return
+	}
+	filter, err := filters.ParseCandidateFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	h.requestFile(c, "export", ExportJobKind, exportParams{Format: format, Filter: filter})
+}
+
+// requestFile records a file request and queues the job that generates it.
+func (h *CandidateHandler) requestFile(c *gin.Context, kind, jobKind string, params any) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	requestedBy, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	encoded, err := json.Marshal(params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request file"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	created, err := qtx.CreateCandidateFile(c, CreateCandidateFileParams{
+		Kind:        kind,
+		Params:      encoded,
+		RequestedBy: int32(requestedBy),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request file"})
+		return
+	}
+	payload, err := json.Marshal(FileJob{FileID: created.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request file"})
+		return
+	}
+	jobID, err := qtx.EnqueueJob(c, EnqueueJobParams{Kind: jobKind, Payload: payload})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue file"})
+		return
+	}
+	err = qtx.SetCandidateFileJob(c, SetCandidateFileJobParams{
+		ID:    created.ID,
+		JobID: pgtype.Int8{Int64: jobID, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue file"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to request file"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, gin.H{"file": File{
+		ID:        created.ID,
+		Kind:      created.Kind,
+		Status:    "pending",
+		CreatedAt: created.CreatedAt.Time,
+	}})
+}
+
+// loadFile looks up the file named in the URL, writing the response itself
+// when it cannot.
+func (h *CandidateHandler) loadFile(c *gin.Context) (GetCandidateFileRow, bool) {
+	id, err := strconv.ParseInt(c.Param("fileId"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid file ID"})
+		return GetCandidateFileRow{}, false
+	}
+	row, err := h.queries.GetCandidateFile(c, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File not found"})
+		return row, false
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load file"})
+		return row, false
+	}
+	return row, true
+}
+
+func (h *CandidateHandler) GetFile(c *gin.Context) {
+	row, ok := h.loadFile(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, newFile(row))
+}
+
+func (h *CandidateHandler) DownloadFile(c *gin.Context) {
+	row, ok := h.loadFile(c)
+	if !ok {
+		return
+	}
+	if row.Status != "ready" {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("File is %s", row.Status)})
+		return
+	}
+	body, err := h.storage.Get(c, row.StorageKey.String)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "File is missing"})
+		return
+	}
+	if err != nil {
+		log.Printf("candidate: reading %s: %v", row.StorageKey.String, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer body.Close()
+
+	c.DataFromReader(http.StatusOK, row.SizeBytes.Int64, row.ContentType.String, body, map[string]string{
+		"Content-Disposition": mime.FormatMediaType("attachment", map[string]string{"filename": row.Filename.String}),
+		"Cache-Control":       "private, no-store",
+	})
+}
+
+// GenerateReport runs a ReportJobKind job.
+func (h *CandidateHandler) GenerateReport(ctx context.Context, job FileJob) error {
+	row, params, done, err := loadFileJob[reportParams](ctx, h.queries, job)
+	if err != nil || done {
+		return err
+	}
+	candidate, err := h.queries.GetCandidate(ctx, params.CandidateID)
+	if err != nil {
+		return fmt.Errorf("fetching candidate %d: %w", params.CandidateID, err)
+	}
+	report, err := h.buildReport(ctx, candidate)
+	if err != nil {
+		return err
+	}
+	filename := fmt.Sprintf("candidate-%d-report.pdf", candidate.ID)
+	return h.storeFile(ctx, row.ID, filename, "application/pdf", func(w io.Writer) error {
+		if err := renderCandidateReport(w, report); err != nil {
+			return fmt.Errorf("rendering report: %w", err)
+		}
+		return nil
+	})
+}
+
+// GenerateExport runs an ExportJobKind job.
+func (h *CandidateHandler) GenerateExport(ctx context.Context, job FileJob) error {
+	row, params, done, err := loadFileJob[exportParams](ctx, h.queries, job)
+	if err != nil || done {
+		return err
+	}
+	contentType, ok := exportContentTypes[params.Format]
+	if !ok {
+		return fmt.Errorf("unknown export format %q", params.Format)
+	}
+	filename := fmt.Sprintf("candidates-%s.%s", row.CreatedAt.Time.Format("20060102-150405"), params.Format)
+	return h.storeFile(ctx, row.ID, filename, contentType, func(w io.Writer) error {
+		if err := h.writeExport(ctx, w, params.Format, params.Filter); err != nil {
+			return fmt.Errorf("writing export: %w", err)
+		}
+		return nil
+	})
+}
+
+// loadFileJob loads the file a job generates and decodes its parameters.
+// done is set when there is nothing left to do: the file was deleted or a
+// previous attempt already finished it.
+func loadFileJob[T any](ctx context.Context, q *Queries, job FileJob) (row GetCandidateFileRow, params T, done bool, err error) {
+	row, err = q.GetCandidateFile(ctx, job.FileID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return row, params, true, nil
+	}
+	if err != nil {
+		return row, params, false, err
+	}
+	if row.FinishedAt.Valid {
+		return row, params, true, nil
+	}
+	if err := json.Unmarshal(row.Params, &params); err != nil {
+		return row, params, false, fmt.Errorf("decoding file %d parameters: %w", row.ID, err)
+	}
+	return row, params, false, nil
+}
+
+// storeFile streams a generated file into storage and marks it ready. write
+// runs alongside the upload through a pipe, so an export read row by row
+// from the database is never held in memory whole.
+func (h *CandidateHandler) storeFile(ctx context.Context, fileID int64, filename, contentType string, write func(io.Writer) error) error {
+	key := fmt.Sprintf("candidate-files/%d/%s", fileID, filename)
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(write(pw))
+	}()
+	body := &countingReader{r: pr}
+	if err := h.storage.Put(ctx, key, body, contentType); err != nil {
+		// Unblock the writer if storage gave up before reading everything.
+		pr.CloseWithError(err)
+		return fmt.Errorf("storing file: %w", err)
+	}
+	return h.queries.FinishCandidateFile(ctx, FinishCandidateFileParams{
+		ID:          fileID,
+		Filename:    pgtype.Text{String: filename, Valid: true},
+		ContentType: pgtype.Text{String: contentType, Valid: true},
+		StorageKey:  pgtype.Text{String: key, Valid: true},
+		SizeBytes:   pgtype.Int8{Int64: body.n, Valid: true},
+	})
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.r.Read(p)
+	c.n += int64(n)
+	return n, err
+}
@@ -0,0 +1,249 @@
+package candidates
+
+import (
+	"backend/pkg/events"
+	"backend/pkg/storage"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// pipelineStages are the hiring stages a candidate can be moved through.
+var pipelineStages = []string{"applied", "screening", "assessment", "interview", "offer", "hired", "rejected"}
+
+type CandidateHandler struct {
+	db      *pgxpool.Pool
+	queries *Queries
+	storage storage.Storage
+}
+
+// NewCandidateHandler keeps generated reports and exports in store.
+func NewCandidateHandler(db *pgxpool.Pool, queries *Queries, store storage.Storage) *CandidateHandler {
+	return &CandidateHandler{
+		db:      db,
+		queries: queries,
+		storage: store,
+	}
+}
+
+func (h *CandidateHandler) CompareCandidates(c *gin.Context) {
+	userIDs, err := parseIDList(c.Query("ids"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(userIDs) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one candidate ID is required"})
+		return
+	}
+	if len(userIDs) > maxComparedCandidates {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("At most %d candidates can be compared", maxComparedCandidates)})
+		return
+	}
+
+	candidates, err := h.queries.ListCandidatesByIDs(c, userIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch candidates"})
+		return
+	}
+	if len(candidates) != len(userIDs) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "One or more candidates not found"})
+		return
+	}
+
+	scores, err := h.queries.ListLatestCandidateScores(c, userIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch candidate scores"})
+		return
+	}
+
+	maxScores, err := h.queries.ListCategoryMaxScores(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch category maximums"})
+		return
+	}
+
+	averages, err := h.queries.ListCohortCategoryAverages(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch cohort averages"})
+		return
+	}
+
+	var targets []JobProfileTarget
+	var jobProfileID int32
+	if raw := c.Query("job_profile_id"); raw != "" {
+		id, err := strconv.ParseInt(raw, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job profile ID"})
+			return
+		}
+		jobProfileID = int32(id)
+
+		exists, err := h.queries.JobProfileExists(c, jobProfileID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch job profile"})
+			return
+		}
+		if !exists {
+			c.JSON(http.StatusNotFound, gin.H{"error": "Job profile not found"})
+			return
+		}
+		targets, err = h.queries.ListJobProfileTargets(c, jobProfileID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch job profile"})
+			return
+		}
+	}
+
+	axes, comparisons := buildComparison(candidates, scores, maxScores, averages, targets)
+
+	c.JSON(http.StatusOK, gin.H{
+		"job_profile_id": jobProfileID,
+		"axes":           axes,
+		"candidates":     comparisons,
+	})
+}
+
+// parseIDList parses a comma separated list of IDs, dropping duplicates.
+func parseIDList(raw string) ([]int32, error) {
+	var ids []int32
+	seen := make(map[int32]bool)
+	for _, part := range strings.Split(raw, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, err := strconv.ParseInt(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("Invalid candidate ID: %s", part)
+		}
+		if !seen[int32(id)] {
+			seen[int32(id)] = true
+			ids = append(ids, int32(id))
+		}
+	}
+	return ids, nil
+}
+
+func (h *CandidateHandler) GetCandidateStage(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+
+	stage, err := h.queries.GetCandidateStage(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusOK, gin.H{"user_id": id, "stage": nil})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve stage"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":    stage.UserID,
+		"stage":      stage.Stage,
+		"changed_at": stage.ChangedAt.Time,
+	})
+}
+
+func (h *CandidateHandler) SetCandidateStage(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	changedBy, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+
+	var req struct {
+		Stage string `json:"stage" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !slices.Contains(pipelineStages, req.Stage) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Stage must be one of %s", strings.Join(pipelineStages, ", "))})
+		return
+	}
+
+	candidate, err := h.queries.GetCandidate(c, int32(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	stage, err := qtx.SetCandidateStage(c, SetCandidateStageParams{
+		UserID:    candidate.ID,
+		Stage:     req.Stage,
+		ChangedBy: pgtype.Int4{Int32: int32(changedBy), Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update stage"})
+		return
+	}
+	if stage.PreviousStage.String != stage.Stage {
+		payload, err := json.Marshal(events.StageChangedData{
+			UserID:    candidate.ID,
+			From:      stage.PreviousStage.String,
+			To:        stage.Stage,
+			ChangedBy: int32(changedBy),
+			ChangedAt: stage.ChangedAt.Time,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record stage change"})
+			return
+		}
+		err = qtx.EnqueueOutboxEvent(c, EnqueueOutboxEventParams{
+			EventType: events.StageChanged,
+			DedupKey:  events.DedupKey(events.StageChanged, candidate.ID, stage.ChangedAt.Time.UnixMicro()),
+			Payload:   payload,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record stage change"})
+			return
+		}
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit stage"})
+		return
+	}
+
+	var previous *string
+	if stage.PreviousStage.Valid {
+		previous = &stage.PreviousStage.String
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":        candidate.ID,
+		"stage":          stage.Stage,
+		"previous_stage": previous,
+		"changed_at":     stage.ChangedAt.Time,
+	})
+}
@@ -0,0 +1,216 @@
+-- name: ListCandidatesByIDs :many
+SELECT id, name, email, created_at
+FROM users
+WHERE id = ANY(@user_ids::int[])
+ORDER BY id;
+
+-- name: ListLatestCandidateScores :many
+-- Category scores from each candidate's latest completed session per assessment type
+WITH latest_sessions AS (
+  SELECT DISTINCT ON (user_id, assessment_type)
+    id,
+    user_id,
+    assessment_type,
+    completed_at
+  FROM user_assessment_sessions
+  WHERE
+    user_id = ANY(@user_ids::int[]) AND
+    completed_at IS NOT NULL
+  ORDER BY user_id, assessment_type, completed_at DESC
+)
+SELECT
+  ls.user_id,
+  ls.id AS session_id,
+  ls.assessment_type,
+  ls.completed_at,
+  uas.category_id,
+  sac.name AS category_name,
+  uas.score
+FROM latest_sessions ls
+JOIN user_assessment_scores uas ON uas.session_id = ls.id
+JOIN self_assessment_categories sac ON sac.id = uas.category_id
+ORDER BY ls.user_id, ls.assessment_type, sac.name;
+
+-- name: ListCohortCategoryAverages :many
+-- Average category score over every candidate's latest completed session per assessment type
+WITH latest_sessions AS (
+  SELECT DISTINCT ON (user_id, assessment_type)
+    id,
+    assessment_type
+  FROM user_assessment_sessions
+  WHERE completed_at IS NOT NULL
+  ORDER BY user_id, assessment_type, completed_at DESC
+)
+SELECT
+  ls.assessment_type,
+  uas.category_id,
+  AVG(uas.score)::float8 AS average_score,
+  COUNT(*) AS candidate_count
+FROM latest_sessions ls
+JOIN user_assessment_scores uas ON uas.session_id = ls.id
+GROUP BY ls.assessment_type, uas.category_id;
+
+-- name: ListCategoryMaxScores :many
+-- Highest score reachable per category, summing the best answer to every
+-- mapped question: every positive option of a multi-select, the top rank of a
+-- ranking, full marks on an open-ended rubric or an active coding problem,
+-- otherwise the best single option (never below zero for a situational
+-- question, whose responses may only take points away)
+WITH question_max AS (
+  SELECT
+    q.type::text AS type,
+    m.question_id,
+    m.category_id,
+    CASE q.format
+      WHEN 'multi_select' THEN SUM(GREATEST(m.points, 0))
+      WHEN 'ranking' THEN MAX(m.points) * ((SELECT COUNT(*) FROM jsonb_object_keys(q.options)) - 1)
+      WHEN 'situational' THEN GREATEST(MAX(m.points), 0)
+      ELSE MAX(m.points)
+    END AS max_points
+  FROM self_assessment_mappings m
+  JOIN self_assessment_questions q ON q.id = m.question_id
+  GROUP BY q.id, m.question_id, m.category_id
+  UNION ALL
+  SELECT
+    q.type::text,
+    r.question_id,
+    (c->>'category_id')::int,
+    SUM((c->>'max_points')::int)
+  FROM self_assessment_rubrics r
+  JOIN self_assessment_questions q ON q.id = r.question_id
+  CROSS JOIN LATERAL jsonb_array_elements(r.criteria) c
+  GROUP BY q.type, r.question_id, (c->>'category_id')::int
+  UNION ALL
+  SELECT 'coding', p.id, sac.id, p.max_points
+  FROM coding_problems p
+  JOIN self_assessment_categories sac ON sac.name = 'Coding'
+  WHERE p.active
+)
+SELECT
+  qm.type::text AS assessment_type,
+  qm.category_id,
+  sac.name AS category_name,
+  SUM(qm.max_points)::int AS max_score
+FROM question_max qm
+JOIN self_assessment_categories sac ON sac.id = qm.category_id
+GROUP BY qm.type, qm.category_id, sac.name
+ORDER BY qm.type, sac.name;
+
+-- name: JobProfileExists :one
+SELECT EXISTS (
+  SELECT 1 FROM job_profiles WHERE id = $1
+);
+
+-- name: ListJobProfileTargets :many
+SELECT id, job_profile_id, assessment_type, category_id, ideal_score
+FROM job_profile_targets
+WHERE job_profile_id = $1;
+
+-- name: GetCandidate :one
+SELECT id, name, email, created_at
+FROM users
+WHERE id = $1 LIMIT 1;
+
+-- name: GetCandidateAssessmentResults :many
+SELECT 
+  uas.id,
+  uas.user_id,
+  uas.session_id,
+  uas.category_id,
+  uas.score,
+  sac.name as category_name,
+  sac.description as category_description,
+  sess.started_at as assessment_date,
+  sess.assessment_type
+FROM user_assessment_scores uas
+JOIN self_assessment_categories sac ON uas.category_id = sac.id
+JOIN user_assessment_sessions sess ON uas.session_id = sess.id
+WHERE 
+  uas.user_id = $1 AND
+  sess.assessment_type = $2
+ORDER BY sac.name;
+
+-- name: ListCandidateCompletedSessions :many
+SELECT id, user_id, assessment_type, started_at, completed_at
+FROM user_assessment_sessions
+WHERE user_id = $1 AND completed_at IS NOT NULL
+ORDER BY completed_at DESC;
+
+-- name: ListFlaggedValidityScales :many
+-- Validity scales on which a session reached the threshold
+SELECT
+  s.name,
+  svs.score,
+  svs.item_count,
+  s.threshold
+FROM session_validity_scores svs
+JOIN validity_scales s ON s.id = svs.scale_id
+WHERE svs.session_id = $1 AND svs.flagged
+ORDER BY s.name;
+
+-- name: SessionHasAccommodation :one
+SELECT EXISTS (
+  SELECT 1 FROM session_accommodations WHERE session_id = $1
+);
+
+-- name: GetCandidateStage :one
+SELECT user_id, stage, changed_by, changed_at FROM candidate_stages
+WHERE user_id = $1;
+
+-- name: SetCandidateStage :one
+-- Move a candidate to a stage, returning the stage they were in before
+WITH previous AS (
+  SELECT stage FROM candidate_stages WHERE user_id = @user_id FOR UPDATE
+)
+INSERT INTO candidate_stages (user_id, stage, changed_by, changed_at)
+VALUES (@user_id, @stage, @changed_by, CURRENT_TIMESTAMP)
+ON CONFLICT (user_id) DO UPDATE
+SET stage = EXCLUDED.stage,
+    changed_by = EXCLUDED.changed_by,
+    changed_at = EXCLUDED.changed_at
+RETURNING (SELECT stage FROM previous)::text AS previous_stage, stage, changed_at;
+
+-- name: CreateCandidateFile :one
+INSERT INTO candidate_files (kind, params, requested_by)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: SetCandidateFileJob :exec
+UPDATE candidate_files
+SET job_id = $2
+WHERE id = $1;
+
+-- name: GetCandidateFile :one
+-- A requested file and how far it has got. A file whose job gave up is
+-- reported as failed.
+SELECT
+  f.*,
+  CASE
+    WHEN f.finished_at IS NOT NULL THEN 'ready'
+    WHEN j.status = 'failed' THEN 'failed'
+    ELSE 'pending'
+  END::text AS status
+FROM candidate_files f
+LEFT JOIN background_jobs j ON j.id = f.job_id
+WHERE f.id = $1;
+
+-- name: FinishCandidateFile :exec
+UPDATE candidate_files
+SET filename = $2,
+    content_type = $3,
+    storage_key = $4,
+    size_bytes = $5,
+    finished_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: EnqueueJob :one
+-- Queue background work; run it in the transaction it belongs to
+INSERT INTO background_jobs (kind, payload)
+VALUES ($1, $2)
+RETURNING id;
+
+-- name: EnqueueOutboxEvent :exec
+-- Record an event in the outbox; run it in the transaction making the change
+INSERT INTO outbox_events (event_type, dedup_key, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (dedup_key) DO NOTHING;
@@ -0,0 +1,234 @@
+package candidates
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jung-kurt/gofpdf"
+)
+
+var assessmentTypes = []string{"behavioral", "personality", "cognitive", "coding"}
+
+type reportCategory struct {
+	Name        string
+	Description string
+	Score       int32
+	MaxScore    int32
+	Percent     float64
+	Band        string
+}
+
+type reportSection struct {
+	AssessmentType string
+	CompletedAt    *time.Time
+	Categories     []reportCategory
+	// Cautions name the validity scales the session was flagged on.
+	Cautions []string
+	// Accommodated is set when the session ran under an accommodation.
+	// Its details are deliberately left out of the report.
+	Accommodated bool
+}
+
+type candidateReport struct {
+	Candidate   GetCandidateRow
+	Sections    []reportSection
+	GeneratedAt time.Time
+}
+
+// buildReport gathers the latest completed session of every assessment type
+// for a candidate's report.
+func (h *CandidateHandler) buildReport(ctx context.Context, candidate GetCandidateRow) (candidateReport, error) {
+	report := candidateReport{
+		Candidate:   candidate,
+		GeneratedAt: time.Now(),
+	}
+
+	sessions, err := h.queries.ListCandidateCompletedSessions(ctx, candidate.ID)
+	if err != nil {
+		return report, fmt.Errorf("fetching assessment sessions: %w", err)
+	}
+	maxScores, err := h.queries.ListCategoryMaxScores(ctx)
+	if err != nil {
+		return report, fmt.Errorf("fetching category maximums: %w", err)
+	}
+	maxByKey := make(map[categoryKey]int32)
+	for _, m := range maxScores {
+		maxByKey[categoryKey{m.AssessmentType, m.CategoryID}] = m.MaxScore
+	}
+
+	for _, assessmentType := range assessmentTypes {
+		section := reportSection{AssessmentType: assessmentType}
+
+		// Sessions are ordered newest first, so the first match is the latest attempt.
+		var latest *UserAssessmentSession
+		for i := range sessions {
+			if sessions[i].AssessmentType == assessmentType {
+				latest = &sessions[i]
+				break
+			}
+		}
+		if latest == nil {
+			report.Sections = append(report.Sections, section)
+			continue
+		}
+		completedAt := latest.CompletedAt.Time
+		section.CompletedAt = &completedAt
+
+		flagged, err := h.queries.ListFlaggedValidityScales(ctx, latest.ID)
+		if err != nil {
+			return report, fmt.Errorf("fetching validity scores: %w", err)
+		}
+		for _, scale := range flagged {
+			section.Cautions = append(section.Cautions, fmt.Sprintf("%s %d of %d items (threshold %d)", scale.Name, scale.Score, scale.ItemCount, scale.Threshold))
+		}
+		section.Accommodated, err = h.queries.SessionHasAccommodation(ctx, latest.ID)
+		if err != nil {
+			return report, fmt.Errorf("fetching accommodations: %w", err)
+		}
+
+		results, err := h.queries.GetCandidateAssessmentResults(ctx, GetCandidateAssessmentResultsParams{
+			UserID:         pgtype.Int4{Int32: candidate.ID, Valid: true},
+			AssessmentType: assessmentType,
+		})
+		if err != nil {
+			return report, fmt.Errorf("fetching assessment details: %w", err)
+		}
+
+		for _, result := range results {
+			if result.SessionID.Int32 != latest.ID {
+				continue
+			}
+			category := reportCategory{
+				Name:        result.CategoryName.String,
+				Description: result.CategoryDescription.String,
+				Score:       result.Score.Int32,
+				MaxScore:    maxByKey[categoryKey{assessmentType, result.CategoryID.Int32}],
+			}
+			if category.MaxScore > 0 {
+				category.Percent = float64(category.Score) / float64(category.MaxScore) * 100
+			}
+			category.Band = scoreBand(category.Percent)
+			section.Categories = append(section.Categories, category)
+		}
+		report.Sections = append(report.Sections, section)
+	}
+	return report, nil
+}
+
+// scoreBand places a normalized score into a coarse band for readers of the report.
+func scoreBand(percent float64) string {
+	switch {
+	case percent >= 75:
+		return "High"
+	case percent >= 50:
+		return "Moderate"
+	case percent >= 25:
+		return "Developing"
+	default:
+		return "Low"
+	}
+}
+
+// interpretation turns a band and category description into a short narrative.
+func interpretation(category reportCategory) string {
+	var lead string
+	switch category.Band {
+	case "High":
+		lead = "A clear strength."
+	case "Moderate":
+		lead = "A solid, dependable level."
+	case "Developing":
+		lead = "Some evidence, with room to grow."
+	default:
+		lead = "A likely development area."
+	}
+	if category.Description == "" {
+		return lead
+	}
+	return fmt.Sprintf("%s %s measures: %s.", lead, category.Name, strings.TrimSuffix(category.Description, "."))
+}
+
+func renderCandidateReport(w io.Writer, report candidateReport) error {
+	pdf := gofpdf.New("P", "mm", "A4", "")
+	tr := pdf.UnicodeTranslatorFromDescriptor("")
+	pdf.SetTitle(tr("Assessment report - "+report.Candidate.Name), false)
+	pdf.AliasNbPages("")
+	pdf.SetFooterFunc(func() {
+		pdf.SetY(-15)
+		pdf.SetFont("Helvetica", "I", 8)
+		pdf.SetTextColor(128, 128, 128)
+		pdf.CellFormat(0, 10, fmt.Sprintf("Generated %s - page %d/{nb}", report.GeneratedAt.Format("2 Jan 2006 15:04"), pdf.PageNo()), "", 0, "C", false, 0, "")
+	})
+	pdf.AddPage()
+
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.CellFormat(0, 10, "Candidate Assessment Report", "", 1, "L", false, 0, "")
+	pdf.Ln(2)
+
+	pdf.SetFont("Helvetica", "", 11)
+	pdf.CellFormat(30, 7, "Name", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 7, tr(report.Candidate.Name), "", 1, "L", false, 0, "")
+	pdf.CellFormat(30, 7, "Email", "", 0, "L", false, 0, "")
+	pdf.CellFormat(0, 7, tr(report.Candidate.Email), "", 1, "L", false, 0, "")
+	if report.Candidate.CreatedAt.Valid {
+		pdf.CellFormat(30, 7, "Registered", "", 0, "L", false, 0, "")
+		pdf.CellFormat(0, 7, report.Candidate.CreatedAt.Time.Format("2 Jan 2006"), "", 1, "L", false, 0, "")
+	}
+
+	for _, section := range report.Sections {
+		pdf.Ln(6)
+		pdf.SetFont("Helvetica", "B", 14)
+		pdf.SetTextColor(0, 0, 0)
+		pdf.CellFormat(0, 9, strings.ToUpper(section.AssessmentType[:1])+section.AssessmentType[1:]+" Assessment", "B", 1, "L", false, 0, "")
+
+		pdf.SetFont("Helvetica", "", 10)
+		if section.CompletedAt == nil {
+			pdf.CellFormat(0, 7, "Not completed yet.", "", 1, "L", false, 0, "")
+			continue
+		}
+		pdf.CellFormat(0, 7, "Completed "+section.CompletedAt.Format("2 Jan 2006 15:04"), "", 1, "L", false, 0, "")
+		if len(section.Cautions) > 0 {
+			pdf.SetFont("Helvetica", "B", 10)
+			pdf.SetTextColor(180, 0, 0)
+			pdf.MultiCell(0, 5, tr("Interpret with caution: "+strings.Join(section.Cautions, "; ")), "", "L", false)
+			pdf.SetTextColor(0, 0, 0)
+			pdf.SetFont("Helvetica", "", 10)
+		}
+		if section.Accommodated {
+			pdf.SetFont("Helvetica", "I", 10)
+			pdf.CellFormat(0, 6, "Completed with approved accommodations.", "", 1, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "", 10)
+		}
+		pdf.Ln(1)
+
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.SetFillColor(230, 230, 230)
+		pdf.CellFormat(80, 7, "Category", "1", 0, "L", true, 0, "")
+		pdf.CellFormat(25, 7, "Score", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(25, 7, "Percent", "1", 0, "C", true, 0, "")
+		pdf.CellFormat(0, 7, "Band", "1", 1, "C", true, 0, "")
+
+		pdf.SetFont("Helvetica", "", 10)
+		for _, category := range section.Categories {
+			pdf.CellFormat(80, 7, tr(category.Name), "1", 0, "L", false, 0, "")
+			pdf.CellFormat(25, 7, fmt.Sprintf("%d / %d", category.Score, category.MaxScore), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(25, 7, fmt.Sprintf("%.0f%%", category.Percent), "1", 0, "C", false, 0, "")
+			pdf.CellFormat(0, 7, category.Band, "1", 1, "C", false, 0, "")
+		}
+
+		pdf.Ln(3)
+		for _, category := range section.Categories {
+			pdf.SetFont("Helvetica", "B", 10)
+			pdf.CellFormat(0, 6, tr(category.Name), "", 1, "L", false, 0, "")
+			pdf.SetFont("Helvetica", "", 10)
+			pdf.MultiCell(0, 5, tr(interpretation(category)), "", "L", false)
+			pdf.Ln(1)
+		}
+	}
+
+	return pdf.Output(w)
+}
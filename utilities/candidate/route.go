@@ -0,0 +1,20 @@
+package candidates
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesCandidate(r *gin.Engine, candidateHandler *CandidateHandler) {
+	admin := r.Group("candidates")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.GET("/compare", candidateHandler.CompareCandidates)
+	admin.POST("/export", candidateHandler.RequestExport)
+	admin.GET("/:id/report.pdf", candidateHandler.GetCandidateReport)
+	admin.POST("/:id/report", candidateHandler.RequestReport)
+	admin.GET("/files/:fileId", candidateHandler.GetFile)
+	admin.GET("/files/:fileId/download", candidateHandler.DownloadFile)
+	admin.GET("/:id/stage", candidateHandler.GetCandidateStage)
+	admin.PUT("/:id/stage", candidateHandler.SetCandidateStage)
+}
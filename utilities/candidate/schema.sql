@@ -0,0 +1,214 @@
+CREATE TABLE IF NOT EXISTS users(
+    id SERIAL PRIMARY KEY,
+    role_id integer null,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password varchar(100) NOT NULL,
+    created_at timestamp default now()
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_categories(
+    id SERIAL PRIMARY KEY,
+    name varchar(255),
+    description text
+);
+
+CREATE TYPE question_type as ENUM ('personality','cognitive','behavioral');
+
+CREATE TYPE question_format as ENUM ('single_choice','multi_select','ranking','numeric','free_text','open_ended','situational');
+
+CREATE TABLE IF NOT EXISTS self_assessment_questions (
+    id SERIAL PRIMARY KEY,
+    question text not null,
+    type question_type not null,
+    options JSONB NOT NULL DEFAULT '{}'::jsonb,
+    correct_answer VARCHAR(255) null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    format question_format NOT NULL DEFAULT 'single_choice'
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_mappings (
+    id SERIAL PRIMARY KEY,
+    question_id int not null, 
+    answer_value int,
+    category_id int not null,
+    points int
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_rubrics(
+    question_id int PRIMARY KEY,
+    criteria JSONB NOT NULL DEFAULT '[]'::jsonb,
+    double_marked boolean not null DEFAULT false,
+    max_disagreement int not null DEFAULT 0,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_sessions(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    started_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    completed_at timestamp null
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_scores(
+    id SERIAL PRIMARY KEY,
+    user_id int,
+    session_id int, 
+    category_id int,
+    score int
+);
+
+CREATE TABLE IF NOT EXISTS job_profiles(
+    id SERIAL PRIMARY KEY,
+    title varchar(255) not null,
+    description text,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS job_profile_targets(
+    id SERIAL PRIMARY KEY,
+    job_profile_id int not null,
+    assessment_type varchar(50) not null,
+    category_id int not null,
+    ideal_score int not null
+);
+
+CREATE TABLE IF NOT EXISTS coding_problems(
+    id SERIAL PRIMARY KEY,
+    title varchar(255) not null,
+    statement text not null,
+    time_limit_ms int not null DEFAULT 2000,
+    memory_limit_mb int not null DEFAULT 256,
+    max_points int not null DEFAULT 10,
+    active boolean not null DEFAULT true,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TYPE validity_scale_kind as ENUM ('lie','infrequency');
+
+CREATE TABLE IF NOT EXISTS validity_scales(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) not null UNIQUE,
+    kind validity_scale_kind not null,
+    assessment_type varchar(50) not null,
+    threshold int not null,
+    description text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS session_validity_scores(
+    session_id int not null,
+    scale_id int not null,
+    score int not null,
+    item_count int not null,
+    flagged boolean not null,
+    PRIMARY KEY (session_id, scale_id)
+);
+
+CREATE TABLE IF NOT EXISTS candidate_accommodations(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    session_id int null,
+    time_multiplier double precision not null DEFAULT 1,
+    break_minutes int not null DEFAULT 0,
+    alternative_formats text[] not null DEFAULT '{}',
+    reason text not null,
+    approved_by int not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    revoked_at timestamp null,
+    revoked_by int null,
+    constraint fk_accommodation_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_accommodation_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_accommodation_approver foreign key (approved_by) REFERENCES users(id),
+    constraint fk_accommodation_revoker foreign key (revoked_by) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS session_accommodations(
+    session_id int PRIMARY KEY,
+    accommodation_id int not null,
+    constraint fk_session_accommodation_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_session_accommodation_accommodation foreign key (accommodation_id) REFERENCES candidate_accommodations(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS candidate_stages(
+    user_id int PRIMARY KEY,
+    stage varchar(30) not null,
+    changed_by int null,
+    changed_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_candidate_stage_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_candidate_stage_changed_by foreign key (changed_by) REFERENCES users(id) on delete SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events(
+    id BIGSERIAL PRIMARY KEY,
+    event_type varchar(50) not null,
+    dedup_key varchar(255) not null UNIQUE,
+    payload JSONB not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    available_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    attempts int not null DEFAULT 0,
+    last_error text null,
+    dispatched_at timestamp null
+);
+
+CREATE TYPE background_job_status as ENUM ('queued','running','succeeded','failed');
+
+CREATE TABLE IF NOT EXISTS background_jobs(
+    id BIGSERIAL PRIMARY KEY,
+    kind varchar(100) not null,
+    payload JSONB not null,
+    status background_job_status not null DEFAULT 'queued',
+    attempts int not null DEFAULT 0,
+    max_attempts int not null DEFAULT 5,
+    run_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamp null,
+    last_error text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    started_at timestamp null,
+    finished_at timestamp null,
+    constraint ck_background_job_max_attempts check (max_attempts > 0)
+);
+
+CREATE TABLE IF NOT EXISTS candidate_files(
+    id BIGSERIAL PRIMARY KEY,
+    kind varchar(20) not null,
+    params JSONB not null,
+    job_id bigint null,
+    filename varchar(255) null,
+    content_type varchar(100) null,
+    storage_key varchar(255) null,
+    size_bytes bigint null,
+    requested_by int not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    finished_at timestamp null,
+    constraint fk_candidate_file_job foreign key (job_id) REFERENCES background_jobs(id) on delete SET NULL,
+    constraint fk_candidate_file_requester foreign key (requested_by) REFERENCES users(id) on delete CASCADE
+);
+
+CREATE VIEW candidate_listing AS
+WITH candidate_behavioral_scores AS (
+SELECT
+ uas.user_id,
+ uas.session_id,
+ sac.name AS category_name,
+ uas.score,
+ RANK() OVER (PARTITION BY uas.user_id ORDER BY uas.score DESC) as score_rank
+FROM
+ user_assessment_scores uas
+JOIN
+ self_assessment_categories sac ON uas.category_id = sac.id
+JOIN
+ user_assessment_sessions sessions ON uas.session_id = sessions.id
+WHERE
+ sessions.assessment_type = 'behavioral'
+)
+SELECT
+ user_id,
+ session_id,
+ category_name AS top_behavioral_trait,
+ score AS top_behavioral_score
+FROM
+ candidate_behavioral_scores
+WHERE
+ score_rank = 1;
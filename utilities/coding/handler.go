@@ -0,0 +1,698 @@
+package coding
+
+import (
+	"backend/pkg/events"
+	"backend/pkg/sandbox"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type CodingHandler struct {
+	db      *pgxpool.Pool
+	queries *Queries
+	runner  sandbox.Runner
+}
+
+// NewCodingHandler returns a handler that judges submissions with runner.
+// Without a runner the assessment can be set up and reviewed, but solutions
+// cannot be submitted.
+func NewCodingHandler(db *pgxpool.Pool, queries *Queries, runner sandbox.Runner) *CodingHandler {
+	return &CodingHandler{
+		db:      db,
+		queries: queries,
+		runner:  runner,
+	}
+}
+
+// Example is a visible test case, shown to candidates.
+type Example struct {
+	Input          string `json:"input"`
+	ExpectedOutput string `json:"expected_output"`
+}
+
+// Problem is a coding problem as shown to candidates.
+type Problem struct {
+	ID            int32     `json:"id"`
+	Title         string    `json:"title"`
+	Statement     string    `json:"statement"`
+	TimeLimitMs   int32     `json:"time_limit_ms"`
+	MemoryLimitMb int32     `json:"memory_limit_mb"`
+	MaxPoints     int32     `json:"max_points"`
+	Examples      []Example `json:"examples"`
+}
+
+// TestResult is the outcome of one test case. Candidates only get results
+// for visible test cases; reviewers get all of them.
+type TestResult struct {
+	TestCaseID     int32         `json:"test_case_id"`
+	Hidden         bool          `json:"hidden"`
+	Weight         int32         `json:"weight"`
+	Verdict        CodingVerdict `json:"verdict"`
+	Input          string        `json:"input"`
+	ExpectedOutput string        `json:"expected_output"`
+	Stdout         string        `json:"stdout"`
+	Stderr         string        `json:"stderr"`
+	ExitCode       int32         `json:"exit_code"`
+	DurationMs     int32         `json:"duration_ms"`
+}
+
+// Submission is a judged or pending solution.
+type Submission struct {
+	ID            int32                  `json:"id"`
+	SessionID     int32                  `json:"session_id"`
+	ProblemID     int32                  `json:"problem_id"`
+	Language      CodingLanguage         `json:"language"`
+	Status        CodingSubmissionStatus `json:"status"`
+	CompileOutput *string                `json:"compile_output,omitempty"`
+	TestsPassed   int                    `json:"tests_passed"`
+	TestsTotal    int                    `json:"tests_total"`
+	Score         *int32                 `json:"score,omitempty"`
+	Source        string                 `json:"source,omitempty"`
+	Results       []TestResult           `json:"results"`
+	CreatedAt     pgtype.Timestamp       `json:"created_at"`
+	FinishedAt    pgtype.Timestamp       `json:"finished_at"`
+}
+
+// presentSubmission builds the view of a submission. Reviewers see the
+// source, the score and every test; candidates see visible tests only.
+func presentSubmission(submission CodingSubmission, results []ListSubmissionResultsRow, reviewer bool) Submission {
+	view := Submission{
+		ID:         submission.ID,
+		SessionID:  submission.SessionID,
+		ProblemID:  submission.ProblemID,
+		Language:   submission.Language,
+		Status:     submission.Status,
+		Results:    []TestResult{},
+		CreatedAt:  submission.CreatedAt,
+		FinishedAt: submission.FinishedAt,
+	}
+	if submission.CompileOutput.Valid {
+		view.CompileOutput = &submission.CompileOutput.String
+	}
+	if reviewer {
+		view.Source = submission.Source
+		view.Score = &submission.Score
+	}
+	for _, result := range results {
+		view.TestsTotal++
+		if result.Verdict == CodingVerdictPassed {
+			view.TestsPassed++
+		}
+		if result.Hidden && !reviewer {
+			continue
+		}
+		view.Results = append(view.Results, TestResult{
+			TestCaseID:     result.TestCaseID,
+			Hidden:         result.Hidden,
+			Weight:         result.Weight,
+			Verdict:        result.Verdict,
+			Input:          result.Input,
+			ExpectedOutput: result.ExpectedOutput,
+			Stdout:         result.Stdout,
+			Stderr:         result.Stderr,
+			ExitCode:       result.ExitCode,
+			DurationMs:     result.DurationMs,
+		})
+	}
+	return view
+}
+
+func (h *CodingHandler) StartAssessment(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	problems, err := h.queries.ListActiveProblems(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load problems"})
+		return
+	}
+	if len(problems) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "No coding problems are available"})
+		return
+	}
+	problemIDs := make([]int32, len(problems))
+	for i, problem := range problems {
+		problemIDs[i] = problem.ID
+	}
+	examples, err := h.queries.ListExampleTestCases(c, problemIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load problems"})
+		return
+	}
+	examplesByProblem := make(map[int32][]Example)
+	for _, example := range examples {
+		examplesByProblem[example.ProblemID] = append(examplesByProblem[example.ProblemID], Example{
+			Input:          example.Input,
+			ExpectedOutput: example.ExpectedOutput,
+		})
+	}
+
+	session, err := h.queries.GetOpenCodingSession(c, int32(userID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		session, err = h.createSession(c, int32(userID))
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start session"})
+		return
+	}
+
+	views := make([]Problem, len(problems))
+	for i, problem := range problems {
+		views[i] = Problem{
+			ID:            problem.ID,
+			Title:         problem.Title,
+			Statement:     problem.Statement,
+			TimeLimitMs:   problem.TimeLimitMs,
+			MemoryLimitMb: problem.MemoryLimitMb,
+			MaxPoints:     problem.MaxPoints,
+			Examples:      examplesByProblem[problem.ID],
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id": session.ID,
+		"started_at": session.StartedAt,
+		"languages":  []CodingLanguage{CodingLanguageGo, CodingLanguagePython},
+		"problems":   views,
+	})
+}
+
+// createSession starts a coding session and records that it started.
+func (h *CodingHandler) createSession(ctx context.Context, userID int32) (UserAssessmentSession, error) {
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return UserAssessmentSession{}, err
+	}
+	defer tx.Rollback(ctx)
+	qtx := h.queries.WithTx(tx)
+
+	session, err := qtx.CreateCodingSession(ctx, userID)
+	if err != nil {
+		return UserAssessmentSession{}, err
+	}
+	err = enqueueEvent(ctx, qtx, events.SessionStarted, session.ID, events.SessionStartedData{
+		SessionID:      session.ID,
+		UserID:         session.UserID,
+		AssessmentType: "coding",
+		StartedAt:      session.StartedAt.Time,
+	})
+	if err != nil {
+		return UserAssessmentSession{}, err
+	}
+	return session, tx.Commit(ctx)
+}
+
+// enqueueEvent records an event about a session in the outbox. Run it in
+// the transaction making the change.
+func enqueueEvent(ctx context.Context, q *Queries, eventType string, sessionID int32, data any) error {
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return err
+	}
+	return q.EnqueueOutboxEvent(ctx, EnqueueOutboxEventParams{
+		EventType: eventType,
+		DedupKey:  events.DedupKey(eventType, sessionID),
+		Payload:   payload,
+	})
+}
+
+func (h *CodingHandler) SubmitSolution(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	sessionID, err := strconv.ParseInt(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	var req struct {
+		ProblemID int32          `json:"problem_id" binding:"required"`
+		Language  CodingLanguage `json:"language" binding:"required"`
+		Source    string         `json:"source" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if !sandbox.Language(req.Language).Valid() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Language must be go or python"})
+		return
+	}
+	if len(req.Source) > maxSourceBytes {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Source is limited to %d bytes", maxSourceBytes)})
+		return
+	}
+	if strings.TrimSpace(req.Source) == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Source is required"})
+		return
+	}
+	if h.runner == nil {
+		c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Code execution is not available"})
+		return
+	}
+
+	problem, err := h.queries.GetProblem(c, req.ProblemID)
+	if err != nil || !problem.Active {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	session, err := qtx.LockSession(c, int32(sessionID))
+	if err != nil || session.UserID != int32(userID) || session.AssessmentType != "coding" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if session.CompletedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Session has already been submitted"})
+		return
+	}
+
+	attempts, err := qtx.CountProblemSubmissions(c, CountProblemSubmissionsParams{
+		SessionID: session.ID,
+		ProblemID: problem.ID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check attempts"})
+		return
+	}
+	if attempts >= maxSubmissionsPerProblem {
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("Each problem allows %d submissions", maxSubmissionsPerProblem)})
+		return
+	}
+
+	submission, err := qtx.CreateSubmission(c, CreateSubmissionParams{
+		SessionID: session.ID,
+		UserID:    int32(userID),
+		ProblemID: problem.ID,
+		Language:  req.Language,
+		Source:    req.Source,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save submission"})
+		return
+	}
+	payload, err := json.Marshal(JudgeJob{SubmissionID: submission.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save submission"})
+		return
+	}
+	if _, err := qtx.EnqueueJob(c, EnqueueJobParams{Kind: JudgeJobKind, Payload: payload}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue submission"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save submission"})
+		return
+	}
+
+	c.JSON(http.StatusAccepted, presentSubmission(submission, nil, false))
+}
+
+func (h *CodingHandler) GetSubmission(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid submission ID"})
+		return
+	}
+
+	submission, err := h.queries.GetSubmission(c, int32(id))
+	if err != nil || submission.UserID != int32(userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Submission not found"})
+		return
+	}
+	results, err := h.queries.ListSubmissionResults(c, submission.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load results"})
+		return
+	}
+	c.JSON(http.StatusOK, presentSubmission(submission, results, false))
+}
+
+func (h *CodingHandler) FinishAssessment(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	sessionID, err := strconv.ParseInt(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	category, err := h.queries.GetCodingCategory(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Coding category is missing"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	session, err := qtx.LockSession(c, int32(sessionID))
+	if err != nil || session.UserID != int32(userID) || session.AssessmentType != "coding" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if session.CompletedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Session has already been submitted"})
+		return
+	}
+	pending, err := qtx.CountPendingSubmissions(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check submissions"})
+		return
+	}
+	if pending > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Wait for your submissions to finish running"})
+		return
+	}
+
+	finals, err := qtx.ListFinalSubmissionScores(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load submissions"})
+		return
+	}
+	var score int32
+	for _, final := range finals {
+		score += final.Score
+	}
+
+	err = qtx.InsertScore(c, InsertScoreParams{
+		UserID:     pgtype.Int4{Int32: session.UserID, Valid: true},
+		SessionID:  pgtype.Int4{Int32: session.ID, Valid: true},
+		CategoryID: pgtype.Int4{Int32: category.ID, Valid: true},
+		Score:      pgtype.Int4{Int32: score, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save score"})
+		return
+	}
+	if err := qtx.CompleteSession(c, session.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete session"})
+		return
+	}
+	err = enqueueEvent(c, qtx, events.ScoresCalculated, session.ID, events.ScoresCalculatedData{
+		SessionID:      session.ID,
+		UserID:         session.UserID,
+		AssessmentType: "coding",
+		Scores: []events.CategoryScore{{
+			CategoryID:   category.ID,
+			CategoryName: category.Name.String,
+			Score:        score,
+		}},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record scores"})
+		return
+	}
+	err = enqueueEvent(c, qtx, events.AssessmentCompleted, session.ID, events.AssessmentCompletedData{
+		SessionID:      session.ID,
+		UserID:         session.UserID,
+		AssessmentType: "coding",
+		CompletedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record completion"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete session"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"message":    "coding assessment completed successfully",
+		"session_id": session.ID,
+		"problems":   len(finals),
+	})
+}
+
+func (h *CodingHandler) CreateProblem(c *gin.Context) {
+	var req struct {
+		Title         string `json:"title" binding:"required"`
+		Statement     string `json:"statement" binding:"required"`
+		TimeLimitMs   int32  `json:"time_limit_ms"`
+		MemoryLimitMb int32  `json:"memory_limit_mb"`
+		MaxPoints     int32  `json:"max_points"`
+		TestCases     []struct {
+			Input          string `json:"input"`
+			ExpectedOutput string `json:"expected_output"`
+			Hidden         *bool  `json:"hidden"`
+			Weight         int32  `json:"weight"`
+		} `json:"test_cases" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if req.TimeLimitMs == 0 {
+		req.TimeLimitMs = 2000
+	}
+	if req.MemoryLimitMb == 0 {
+		req.MemoryLimitMb = 256
+	}
+	if req.MaxPoints == 0 {
+		req.MaxPoints = 10
+	}
+	if req.TimeLimitMs < 100 || req.TimeLimitMs > 10000 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Time limit must be between 100 and 10000 ms"})
+		return
+	}
+	if req.MemoryLimitMb < 32 || req.MemoryLimitMb > 1024 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Memory limit must be between 32 and 1024 MB"})
+		return
+	}
+	if req.MaxPoints < 1 || req.MaxPoints > 100 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Max points must be between 1 and 100"})
+		return
+	}
+	hidden := 0
+	for i, test := range req.TestCases {
+		if test.Weight < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Test case %d has a negative weight", i+1)})
+			return
+		}
+		if test.Hidden == nil || *test.Hidden {
+			hidden++
+		}
+	}
+	if hidden == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one hidden test case is required"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	problem, err := qtx.CreateProblem(c, CreateProblemParams{
+		Title:         req.Title,
+		Statement:     req.Statement,
+		TimeLimitMs:   req.TimeLimitMs,
+		MemoryLimitMb: req.MemoryLimitMb,
+		MaxPoints:     req.MaxPoints,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create problem"})
+		return
+	}
+	tests := make([]CodingTestCase, 0, len(req.TestCases))
+	for _, test := range req.TestCases {
+		weight := test.Weight
+		if weight == 0 {
+			weight = 1
+		}
+		created, err := qtx.CreateTestCase(c, CreateTestCaseParams{
+			ProblemID:      problem.ID,
+			Input:          test.Input,
+			ExpectedOutput: test.ExpectedOutput,
+			Hidden:         test.Hidden == nil || *test.Hidden,
+			Weight:         weight,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create test case"})
+			return
+		}
+		tests = append(tests, created)
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create problem"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"problem":    problem,
+		"test_cases": tests,
+	})
+}
+
+func (h *CodingHandler) ListProblems(c *gin.Context) {
+	problems, err := h.queries.ListProblems(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve problems"})
+		return
+	}
+	c.JSON(http.StatusOK, problems)
+}
+
+func (h *CodingHandler) GetProblem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid problem ID"})
+		return
+	}
+	problem, err := h.queries.GetProblem(c, int32(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+	tests, err := h.queries.ListTestCases(c, problem.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve test cases"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"problem":    problem,
+		"test_cases": tests,
+	})
+}
+
+func (h *CodingHandler) SetProblemActive(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid problem ID"})
+		return
+	}
+	var req struct {
+		Active *bool `json:"active" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	problem, err := h.queries.SetProblemActive(c, SetProblemActiveParams{ID: int32(id), Active: *req.Active})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Problem not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update problem"})
+		return
+	}
+	c.JSON(http.StatusOK, problem)
+}
+
+func (h *CodingHandler) ReviewSession(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+	session, err := h.queries.GetSession(c, int32(sessionID))
+	if err != nil || session.AssessmentType != "coding" {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+
+	submissions, err := h.queries.ListSessionSubmissions(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve submissions"})
+		return
+	}
+	finals, err := h.queries.ListFinalSubmissionScores(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve submissions"})
+		return
+	}
+	counted := make(map[int32]bool, len(finals))
+	for _, final := range finals {
+		counted[final.ID] = true
+	}
+
+	type reviewedSubmission struct {
+		Submission
+		Counted bool `json:"counted"`
+	}
+	views := make([]reviewedSubmission, 0, len(submissions))
+	for _, submission := range submissions {
+		results, err := h.queries.ListSubmissionResults(c, submission.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve results"})
+			return
+		}
+		views = append(views, reviewedSubmission{
+			Submission: presentSubmission(submission, results, true),
+			Counted:    counted[submission.ID],
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":   session.ID,
+		"user_id":      session.UserID,
+		"started_at":   session.StartedAt,
+		"completed_at": session.CompletedAt,
+		"submissions":  views,
+	})
+}
@@ -0,0 +1,189 @@
+package coding
+
+import (
+	"backend/pkg/sandbox"
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// maxSourceBytes bounds a submitted solution.
+	maxSourceBytes = 64 << 10
+	// maxSubmissionsPerProblem bounds the attempts at one problem in a session.
+	maxSubmissionsPerProblem = 10
+	// maxOutputBytes is how much of each test run's output is kept.
+	maxOutputBytes = 64 << 10
+	// startupAllowance covers sandbox start-up, which counts against the wall
+	// clock but not against the CPU limit.
+	startupAllowance = 2 * time.Second
+)
+
+// JudgeJobKind is the background job that judges a submission. How many
+// submissions are judged at once is set by the size of the job worker pool.
+const JudgeJobKind = "coding.judge"
+
+// JudgeJob is the payload of a JudgeJobKind job.
+type JudgeJob struct {
+	SubmissionID int32 `json:"submission_id"`
+}
+
+// limitsFor turns a problem's limits into sandbox limits for one test run.
+func limitsFor(problem CodingProblem) sandbox.Limits {
+	cpu := time.Duration(problem.TimeLimitMs) * time.Millisecond
+	return sandbox.Limits{
+		WallTime:    2*cpu + startupAllowance,
+		CPUTime:     cpu,
+		MemoryBytes: int64(problem.MemoryLimitMb) << 20,
+		OutputBytes: maxOutputBytes,
+	}
+}
+
+// normalizeOutput drops trailing whitespace on every line and trailing blank
+// lines, so a missing final newline does not fail a test.
+func normalizeOutput(s string) string {
+	lines := strings.Split(strings.ReplaceAll(s, "\r\n", "\n"), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	return strings.TrimRight(strings.Join(lines, "\n"), "\n")
+}
+
+// verdictFor judges one test run against the expected output.
+func verdictFor(result sandbox.Result, expected string) CodingVerdict {
+	switch result.Status {
+	case sandbox.StatusTimeLimit:
+		return CodingVerdictTimeLimit
+	case sandbox.StatusMemoryLimit:
+		return CodingVerdictMemoryLimit
+	case sandbox.StatusRuntimeError:
+		return CodingVerdictRuntimeError
+	}
+	if result.Truncated || normalizeOutput(result.Stdout) != normalizeOutput(expected) {
+		return CodingVerdictWrongAnswer
+	}
+	return CodingVerdictPassed
+}
+
+// storable makes program output safe to keep in a text column, which takes
+// neither NUL bytes nor invalid UTF-8.
+func storable(s string) string {
+	return strings.ToValidUTF8(strings.ReplaceAll(s, "\x00", ""), "\uFFFD")
+}
+
+// submissionScore scales a problem's points by the weight of the tests passed.
+func submissionScore(maxPoints, passed, total int32) int32 {
+	if total == 0 {
+		return 0
+	}
+	return int32(math.Round(float64(maxPoints) * float64(passed) / float64(total)))
+}
+
+// JudgeSubmission runs a JudgeJobKind job. A submission that has already
+// been judged is left alone, so a repeated job does nothing.
+func (h *CodingHandler) JudgeSubmission(ctx context.Context, job JudgeJob) error {
+	if h.runner == nil {
+		return errors.New("code execution is not available")
+	}
+	return h.judge(ctx, job.SubmissionID)
+}
+
+func (h *CodingHandler) judge(ctx context.Context, id int32) error {
+	submission, err := h.queries.ClaimSubmission(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	problem, err := h.queries.GetProblem(ctx, submission.ProblemID)
+	if err != nil {
+		return h.fail(ctx, submission.ID, err)
+	}
+	tests, err := h.queries.ListTestCases(ctx, problem.ID)
+	if err != nil {
+		return h.fail(ctx, submission.ID, err)
+	}
+	inputs := make([]string, len(tests))
+	var totalWeight int32
+	for i, test := range tests {
+		inputs[i] = test.Input
+		totalWeight += test.Weight
+	}
+
+	execution, err := h.runner.Run(ctx, sandbox.Language(submission.Language), submission.Source, inputs, limitsFor(problem))
+	if err != nil {
+		return h.fail(ctx, submission.ID, err)
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return h.fail(ctx, submission.ID, err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := h.queries.WithTx(tx)
+
+	if err := qtx.DeleteTestResults(ctx, submission.ID); err != nil {
+		return h.fail(ctx, submission.ID, err)
+	}
+	var passedWeight int32
+	for i, result := range execution.Results {
+		verdict := verdictFor(result, tests[i].ExpectedOutput)
+		if verdict == CodingVerdictPassed {
+			passedWeight += tests[i].Weight
+		}
+		err := qtx.InsertTestResult(ctx, InsertTestResultParams{
+			SubmissionID: submission.ID,
+			TestCaseID:   tests[i].ID,
+			Verdict:      verdict,
+			Stdout:       storable(result.Stdout),
+			Stderr:       storable(result.Stderr),
+			ExitCode:     int32(result.ExitCode),
+			DurationMs:   int32(result.Duration.Milliseconds()),
+		})
+		if err != nil {
+			return h.fail(ctx, submission.ID, err)
+		}
+	}
+
+	err = qtx.FinishSubmission(ctx, FinishSubmissionParams{
+		ID:     submission.ID,
+		Status: CodingSubmissionStatusCompleted,
+		CompileOutput: pgtype.Text{
+			String: storable(execution.CompileOutput),
+			Valid:  execution.CompileFailed,
+		},
+		PassedWeight: passedWeight,
+		TotalWeight:  totalWeight,
+		Score:        submissionScore(problem.MaxPoints, passedWeight, totalWeight),
+	})
+	if err != nil {
+		return h.fail(ctx, submission.ID, err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return h.fail(ctx, submission.ID, err)
+	}
+	return nil
+}
+
+// fail marks a submission that could not be judged, so the candidate can
+// submit again instead of waiting forever. It is recorded even when judging
+// was cancelled.
+func (h *CodingHandler) fail(ctx context.Context, id int32, cause error) error {
+	err := h.queries.FinishSubmission(context.WithoutCancel(ctx), FinishSubmissionParams{
+		ID:            id,
+		Status:        CodingSubmissionStatusFailed,
+		CompileOutput: pgtype.Text{String: "The submission could not be run. Please submit again.", Valid: true},
+	})
+	if err != nil {
+		return fmt.Errorf("%w (marking failed: %v)", cause, err)
+	}
+	return cause
+}
@@ -0,0 +1,193 @@
+-- name: CreateProblem :one
+INSERT INTO coding_problems(
+    title,
+    statement,
+    time_limit_ms,
+    memory_limit_mb,
+    max_points
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)RETURNING *;
+
+-- name: CreateTestCase :one
+INSERT INTO coding_test_cases(
+    problem_id,
+    input,
+    expected_output,
+    hidden,
+    weight
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)RETURNING *;
+
+-- name: GetProblem :one
+SELECT * FROM coding_problems
+WHERE id = $1 LIMIT 1;
+
+-- name: ListProblems :many
+SELECT * FROM coding_problems
+ORDER BY id;
+
+-- name: ListActiveProblems :many
+SELECT * FROM coding_problems
+WHERE active
+ORDER BY id;
+
+-- name: SetProblemActive :one
+UPDATE coding_problems
+SET active = $2
+WHERE id = $1
+RETURNING *;
+
+-- name: ListTestCases :many
+SELECT * FROM coding_test_cases
+WHERE problem_id = $1
+ORDER BY id;
+
+-- name: ListExampleTestCases :many
+-- Visible test cases, shown to candidates as examples
+SELECT * FROM coding_test_cases
+WHERE problem_id = ANY(@problem_ids::int[]) AND NOT hidden
+ORDER BY problem_id, id;
+
+-- name: GetOpenCodingSession :one
+SELECT * FROM user_assessment_sessions
+WHERE user_id = $1 AND assessment_type = 'coding' AND completed_at IS NULL
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: CreateCodingSession :one
+INSERT INTO user_assessment_sessions (user_id, assessment_type)
+VALUES ($1, 'coding')
+RETURNING *;
+
+-- name: GetSession :one
+SELECT * FROM user_assessment_sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: LockSession :one
+-- Serializes submissions and finishing for a session
+SELECT * FROM user_assessment_sessions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CountProblemSubmissions :one
+SELECT COUNT(*) FROM coding_submissions
+WHERE session_id = $1 AND problem_id = $2 AND status <> 'failed';
+
+-- name: CountPendingSubmissions :one
+SELECT COUNT(*) FROM coding_submissions
+WHERE session_id = $1 AND status IN ('queued', 'running');
+
+-- name: CreateSubmission :one
+INSERT INTO coding_submissions(
+    session_id,
+    user_id,
+    problem_id,
+    language,
+    source
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+)RETURNING *;
+
+-- name: GetSubmission :one
+SELECT * FROM coding_submissions
+WHERE id = $1 LIMIT 1;
+
+-- name: ClaimSubmission :one
+-- Moves a submission to running. A running one is claimed again when its job
+-- is retried after its worker was lost; no row means it has been judged.
+UPDATE coding_submissions
+SET status = 'running'
+WHERE id = $1 AND status IN ('queued', 'running')
+RETURNING *;
+
+-- name: DeleteTestResults :exec
+DELETE FROM coding_test_results
+WHERE submission_id = $1;
+
+-- name: InsertTestResult :exec
+INSERT INTO coding_test_results(
+    submission_id,
+    test_case_id,
+    verdict,
+    stdout,
+    stderr,
+    exit_code,
+    duration_ms
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+);
+
+-- name: FinishSubmission :exec
+UPDATE coding_submissions
+SET status = $2,
+    compile_output = $3,
+    passed_weight = $4,
+    total_weight = $5,
+    score = $6,
+    finished_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: ListSubmissionResults :many
+SELECT r.id, r.submission_id, r.test_case_id, r.verdict, r.stdout, r.stderr, r.exit_code, r.duration_ms,
+       t.hidden, t.input, t.expected_output, t.weight
+FROM coding_test_results r
+JOIN coding_test_cases t ON t.id = r.test_case_id
+WHERE r.submission_id = $1
+ORDER BY t.id;
+
+-- name: ListSessionSubmissions :many
+SELECT * FROM coding_submissions
+WHERE session_id = $1
+ORDER BY problem_id, id;
+
+-- name: ListFinalSubmissionScores :many
+-- The latest judged submission for each problem is the one that counts
+SELECT DISTINCT ON (problem_id) problem_id, id, score
+FROM coding_submissions
+WHERE session_id = $1 AND status = 'completed'
+ORDER BY problem_id, id DESC;
+
+-- name: GetCodingCategory :one
+SELECT * FROM self_assessment_categories
+WHERE name = 'Coding' LIMIT 1;
+
+-- name: InsertScore :exec
+INSERT INTO user_assessment_scores (user_id, session_id, category_id, score)
+VALUES ($1, $2, $3, $4);
+
+-- name: CompleteSession :exec
+UPDATE user_assessment_sessions
+SET completed_at = CURRENT_TIMESTAMP, submitted_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: EnqueueOutboxEvent :exec
+-- Record an event in the outbox; run it in the transaction making the change
+INSERT INTO outbox_events (event_type, dedup_key, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (dedup_key) DO NOTHING;
+
+-- name: EnqueueJob :one
+-- Queue background work; run it in the transaction it belongs to
+INSERT INTO background_jobs (kind, payload)
+VALUES ($1, $2)
+RETURNING id;
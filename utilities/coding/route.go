@@ -0,0 +1,24 @@
+package coding
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesCoding(r *gin.Engine, codingHandler *CodingHandler) {
+	auth := r.Group("coding")
+	auth.Use(middleware.AuthMiddleware())
+	auth.POST("/start", codingHandler.StartAssessment)
+	auth.POST("/sessions/:session_id/submissions", codingHandler.SubmitSolution)
+	auth.POST("/sessions/:session_id/finish", codingHandler.FinishAssessment)
+	auth.GET("/submissions/:id", codingHandler.GetSubmission)
+
+	admin := r.Group("coding")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.POST("/problems", codingHandler.CreateProblem)
+	admin.GET("/problems", codingHandler.ListProblems)
+	admin.GET("/problems/:id", codingHandler.GetProblem)
+	admin.PUT("/problems/:id/active", codingHandler.SetProblemActive)
+	admin.GET("/sessions/:session_id/submissions", codingHandler.ReviewSession)
+}
@@ -0,0 +1,128 @@
+CREATE TABLE IF NOT EXISTS users(
+    id SERIAL PRIMARY KEY,
+    role_id integer null,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password varchar(100) NOT NULL,
+    created_at timestamp default now(),
+    constraint fk_role foreign key (role_id) REFERENCES roles(id) on delete SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_categories(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) UNIQUE,
+    description text
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_sessions(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    started_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    completed_at timestamp null,
+    blueprint_id int null,
+    quality_flags JSONB NOT NULL DEFAULT '[]'::jsonb,
+    submitted_at timestamp null
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_scores(
+    id SERIAL PRIMARY KEY,
+    user_id int,
+    session_id int, 
+    category_id int,
+    score int default 0,
+    theta double precision null,
+    theta_se double precision null,
+    constraint fk_user_score foreign key (user_id) REFERENCES users(id) on delete SET NULL,
+    constraint fk_session_score foreign key (session_id) REFERENCES user_assessment_session(id) on delete SET NULL,
+    constraint fk_category_score foreign key (category_id) REFERENCES self_assessment_categories(id) on delete SET NULL
+);
+
+CREATE TYPE coding_language as ENUM ('go','python');
+
+CREATE TYPE coding_submission_status as ENUM ('queued','running','completed','failed');
+
+CREATE TYPE coding_verdict as ENUM ('passed','wrong_answer','runtime_error','time_limit','memory_limit');
+
+CREATE TABLE IF NOT EXISTS coding_problems(
+    id SERIAL PRIMARY KEY,
+    title varchar(255) not null,
+    statement text not null,
+    time_limit_ms int not null DEFAULT 2000,
+    memory_limit_mb int not null DEFAULT 256,
+    max_points int not null DEFAULT 10,
+    active boolean not null DEFAULT true,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS coding_test_cases(
+    id SERIAL PRIMARY KEY,
+    problem_id int not null,
+    input text not null DEFAULT '',
+    expected_output text not null,
+    hidden boolean not null DEFAULT true,
+    weight int not null DEFAULT 1,
+    constraint fk_coding_test_case_problem foreign key (problem_id) REFERENCES coding_problems(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS coding_submissions(
+    id SERIAL PRIMARY KEY,
+    session_id int not null,
+    user_id int not null,
+    problem_id int not null,
+    language coding_language not null,
+    source text not null,
+    status coding_submission_status not null DEFAULT 'queued',
+    compile_output text null,
+    passed_weight int not null DEFAULT 0,
+    total_weight int not null DEFAULT 0,
+    score int not null DEFAULT 0,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    finished_at timestamp null,
+    constraint fk_coding_submission_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_coding_submission_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_coding_submission_problem foreign key (problem_id) REFERENCES coding_problems(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS coding_test_results(
+    id SERIAL PRIMARY KEY,
+    submission_id int not null,
+    test_case_id int not null,
+    verdict coding_verdict not null,
+    stdout text not null DEFAULT '',
+    stderr text not null DEFAULT '',
+    exit_code int not null DEFAULT 0,
+    duration_ms int not null DEFAULT 0,
+    constraint fk_coding_test_result_submission foreign key (submission_id) REFERENCES coding_submissions(id) on delete CASCADE,
+    constraint fk_coding_test_result_case foreign key (test_case_id) REFERENCES coding_test_cases(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events(
+    id BIGSERIAL PRIMARY KEY,
+    event_type varchar(50) not null,
+    dedup_key varchar(255) not null UNIQUE,
+    payload JSONB not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    available_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    attempts int not null DEFAULT 0,
+    last_error text null,
+    dispatched_at timestamp null
+);
+
+CREATE TYPE background_job_status as ENUM ('queued','running','succeeded','failed');
+
+CREATE TABLE IF NOT EXISTS background_jobs(
+    id BIGSERIAL PRIMARY KEY,
+    kind varchar(100) not null,
+    payload JSONB not null,
+    status background_job_status not null DEFAULT 'queued',
+    attempts int not null DEFAULT 0,
+    max_attempts int not null DEFAULT 5,
+    run_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamp null,
+    last_error text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    started_at timestamp null,
+    finished_at timestamp null,
+    constraint ck_background_job_max_attempts check (max_attempts > 0)
+);
@@ -0,0 +1,76 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"net/http"
+	"path"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	contentTypePDF  = "application/pdf"
+	contentTypeDOCX = "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+	contentTypeDOC  = "application/msword"
+	maxFileNameLen  = 255
+)
+
+// oleMagic starts every legacy (Word 97-2003) .doc file.
+var oleMagic = []byte{0xD0, 0xCF, 0x11, 0xE0, 0xA1, 0xB1, 0x1A, 0xE1}
+
+var errUnsupportedType = errors.New("only PDF, Word and plain text files are accepted")
+
+// sniffContentType works out what an upload is from its bytes, ignoring
+// whatever type and extension the client claimed.
+func sniffContentType(content []byte) (string, error) {
+	detected := http.DetectContentType(content)
+	switch {
+	case detected == contentTypePDF:
+		return contentTypePDF, nil
+	case strings.HasPrefix(detected, "text/plain"):
+		return detected, nil
+	case detected == "application/zip" && isDOCX(content):
+		return contentTypeDOCX, nil
+	case bytes.HasPrefix(content, oleMagic):
+		return contentTypeDOC, nil
+	}
+	return "", errUnsupportedType
+}
+
+// isDOCX reports whether a zip archive is a Word document rather than any
+// other zip.
+func isDOCX(content []byte) bool {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return false
+	}
+	for _, f := range archive.File {
+		if f.Name == "word/document.xml" {
+			return true
+		}
+	}
+	return false
+}
+
+// cleanFileName keeps the base name of an uploaded file, without control
+// characters, for showing and for the download's Content-Disposition.
+func cleanFileName(name string) string {
+	name = path.Base(strings.ReplaceAll(name, "\\", "/"))
+	name = strings.TrimSpace(strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return -1
+		}
+		return r
+	}, name))
+	for len(name) > maxFileNameLen {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	if name == "" || name == "." || name == "/" {
+		return "document"
+	}
+	return name
+}
@@ -0,0 +1,82 @@
+package document
+
+import (
+	"archive/zip"
+	"bytes"
+	"strings"
+	"testing"
+)
+
+// zipWith builds a zip archive holding empty files with the given names.
+func zipWith(t *testing.T, names ...string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w := zip.NewWriter(&buf)
+	for _, name := range names {
+		if _, err := w.Create(name); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestSniffContentType(t *testing.T) {
+	tests := []struct {
+		name    string
+		content []byte
+		want    string
+		wantErr bool
+	}{
+		{name: "pdf", content: []byte("%PDF-1.7\n%âãÏÓ\n1 0 obj"), want: contentTypePDF},
+		{name: "plain text", content: []byte("Jane Doe\nSoftware engineer\n"), want: "text/plain; charset=utf-8"},
+		{name: "docx", content: zipWith(t, "[Content_Types].xml", "word/document.xml"), want: contentTypeDOCX},
+		{name: "legacy doc", content: append(append([]byte{}, oleMagic...), make([]byte, 504)...), want: contentTypeDOC},
+		{name: "zip that is not a Word document", content: zipWith(t, "readme.txt"), wantErr: true},
+		{name: "html", content: []byte("<!DOCTYPE html><html><script>alert(1)</script></html>"), wantErr: true},
+		{name: "png", content: []byte("\x89PNG\r\n\x1a\n\x00\x00\x00\rIHDR"), wantErr: true},
+		{name: "executable", content: []byte("MZ\x90\x00\x03\x00\x00\x00"), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := sniffContentType(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("sniffContentType = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("sniffContentType: %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("sniffContentType = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCleanFileName(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{name: "plain", in: "cv.pdf", want: "cv.pdf"},
+		{name: "unix path", in: "/home/jane/cv.pdf", want: "cv.pdf"},
+		{name: "windows path", in: `C:\Users\jane\cv.pdf`, want: "cv.pdf"},
+		{name: "control characters", in: "cv\r\n.pdf", want: "cv.pdf"},
+		{name: "empty", in: "", want: "document"},
+		{name: "only a slash", in: "/", want: "document"},
+		{name: "too long", in: strings.Repeat("é", 300), want: strings.Repeat("é", 127)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanFileName(tt.in); got != tt.want {
+				t.Errorf("cleanFileName(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
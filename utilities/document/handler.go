@@ -0,0 +1,379 @@
+package document
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/pkg/storage"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+)
+
+const (
+	defaultMaxBytes     = 10 << 20
+	defaultMaxDocuments = 20
+	defaultURLExpiry    = 15 * time.Minute
+	// multipartOverhead is room in the request body for the multipart
+	// envelope around the file.
+	multipartOverhead = 1 << 20
+)
+
+var documentKinds = []string{"resume", "cover_letter", "other"}
+
+// Scanner checks uploads for malware. Scan returns the name of the threat
+// found, or "" if the content is clean.
+type Scanner interface {
+	Scan(ctx context.Context, content []byte) (string, error)
+}
+
+// Config sets upload limits.
+type Config struct {
+	// MaxBytes is the largest file accepted.
+	MaxBytes int64
+	// MaxDocuments is how many documents one candidate may keep at once.
+	MaxDocuments int64
+	// URLExpiry is how long a signed download link works.
+	URLExpiry time.Duration
+}
+
+type DocumentHandler struct {
+	queries   *Queries
+	storage   storage.Storage
+	scanner   Scanner
+	secretKey string
+	cfg       Config
+}
+
+// NewDocumentHandler stores uploads in store. Without a scanner, uploads are
+// accepted unscanned and marked as such.
+func NewDocumentHandler(queries *Queries, store storage.Storage, scanner Scanner, secretKey string, cfg Config) *DocumentHandler {
+	if cfg.MaxBytes <= 0 {
+		cfg.MaxBytes = defaultMaxBytes
+	}
+	if cfg.MaxDocuments <= 0 {
+		cfg.MaxDocuments = defaultMaxDocuments
+	}
+	if cfg.URLExpiry <= 0 {
+		cfg.URLExpiry = defaultURLExpiry
+	}
+	return &DocumentHandler{
+		queries:   queries,
+		storage:   store,
+		scanner:   scanner,
+		secretKey: secretKey,
+		cfg:       cfg,
+	}
+}
+
+// Document is an uploaded file as shown to its owner and staff.
+type Document struct {
+	ID          int32              `json:"id"`
+	UserID      int32              `json:"user_id"`
+	Kind        string             `json:"kind"`
+	FileName    string             `json:"file_name"`
+	ContentType string             `json:"content_type"`
+	SizeBytes   int64              `json:"size_bytes"`
+	Sha256      string             `json:"sha256"`
+	ScanStatus  DocumentScanStatus `json:"scan_status"`
+	UploadedAt  time.Time          `json:"uploaded_at"`
+}
+
+func presentDocument(d CandidateDocument) Document {
+	return Document{
+		ID:          d.ID,
+		UserID:      d.UserID,
+		Kind:        d.Kind,
+		FileName:    d.FileName,
+		ContentType: d.ContentType,
+		SizeBytes:   d.SizeBytes,
+		Sha256:      d.Sha256,
+		ScanStatus:  d.ScanStatus,
+		UploadedAt:  d.UploadedAt.Time,
+	}
+}
+
+func presentDocuments(docs []CandidateDocument) []Document {
+	presented := make([]Document, 0, len(docs))
+	for _, doc := range docs {
+		presented = append(presented, presentDocument(doc))
+	}
+	return presented
+}
+
+func (h *DocumentHandler) UploadDocument(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	// Checked before the body is read, so a candidate at the limit cannot
+	// keep pushing files at the scanner and storage.
+	count, err := h.queries.CountUserDocuments(c, int32(userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check documents"})
+		return
+	}
+	if count >= h.cfg.MaxDocuments {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("You can keep at most %d documents, delete one to upload another", h.cfg.MaxDocuments)})
+		return
+	}
+
+	tooLarge := fmt.Sprintf("File must be at most %d MB", h.cfg.MaxBytes>>20)
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, h.cfg.MaxBytes+multipartOverhead)
+	fileHeader, err := c.FormFile("file")
+	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": tooLarge})
+			return
+		}
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A file is required"})
+		return
+	}
+	kind := c.DefaultPostForm("kind", "resume")
+	if !slices.Contains(documentKinds, kind) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Kind must be one of %s", strings.Join(documentKinds, ", "))})
+		return
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	defer file.Close()
+	content, err := io.ReadAll(io.LimitReader(file, h.cfg.MaxBytes+1))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Failed to read file"})
+		return
+	}
+	if int64(len(content)) > h.cfg.MaxBytes {
+		c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": tooLarge})
+		return
+	}
+	if len(content) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "File is empty"})
+		return
+	}
+	contentType, err := sniffContentType(content)
+	if err != nil {
+		c.JSON(http.StatusUnsupportedMediaType, gin.H{"error": "Only PDF, Word and plain text files are accepted"})
+		return
+	}
+
+	scanStatus := DocumentScanStatusUnscanned
+	if h.scanner != nil {
+		threat, err := h.scanner.Scan(c, content)
+		if err != nil {
+			log.Printf("document: scanning upload: %v", err)
+			c.JSON(http.StatusServiceUnavailable, gin.H{"error": "Virus scanning is unavailable, please try again later"})
+			return
+		}
+		if threat != "" {
+			log.Printf("document: rejected upload from user %d: %s", userID, threat)
+			c.JSON(http.StatusUnprocessableEntity, gin.H{"error": "File did not pass the virus scan"})
+			return
+		}
+		scanStatus = DocumentScanStatusClean
+	}
+
+	key, err := newStorageKey(int32(userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store document"})
+		return
+	}
+	if err := h.storage.Put(c, key, bytes.NewReader(content), contentType); err != nil {
+		log.Printf("document: storing %s: %v", key, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store document"})
+		return
+	}
+	sum := sha256.Sum256(content)
+	doc, err := h.queries.CreateDocument(c, CreateDocumentParams{
+		UserID:      int32(userID),
+		Kind:        kind,
+		FileName:    cleanFileName(fileHeader.Filename),
+		ContentType: contentType,
+		SizeBytes:   int64(len(content)),
+		Sha256:      hex.EncodeToString(sum[:]),
+		StorageKey:  key,
+		ScanStatus:  scanStatus,
+	})
+	if err != nil {
+		if err := h.storage.Delete(context.WithoutCancel(c), key); err != nil {
+			log.Printf("document: removing orphaned %s: %v", key, err)
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save document"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, presentDocument(doc))
+}
+
+func (h *DocumentHandler) ListMyDocuments(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	docs, err := h.queries.ListUserDocuments(c, int32(userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve documents"})
+		return
+	}
+	c.JSON(http.StatusOK, presentDocuments(docs))
+}
+
+func (h *DocumentHandler) DeleteDocument(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	doc, err := h.queries.DeleteUserDocument(c, DeleteUserDocumentParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete document"})
+		return
+	}
+	// The record is gone, so a file left behind is unreachable; it is only
+	// worth a log line.
+	if err := h.storage.Delete(c, doc.StorageKey); err != nil {
+		log.Printf("document: deleting %s: %v", doc.StorageKey, err)
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Document deleted"})
+}
+
+func (h *DocumentHandler) ListCandidateDocuments(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid candidate ID"})
+		return
+	}
+
+	candidate, err := h.queries.GetCandidate(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Candidate not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load candidate"})
+		return
+	}
+	docs, err := h.queries.ListUserDocuments(c, candidate.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve documents"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"candidate_id": candidate.ID,
+		"name":         candidate.Name,
+		"email":        candidate.Email,
+		"documents":    presentDocuments(docs),
+	})
+}
+
+func (h *DocumentHandler) GetDownloadURL(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+
+	doc, err := h.queries.GetDocument(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load document"})
+		return
+	}
+	expiresAt := time.Now().Add(h.cfg.URLExpiry).Truncate(time.Second)
+	c.JSON(http.StatusOK, gin.H{
+		"url":        downloadURL(h.secretKey, doc.ID, expiresAt),
+		"expires_at": expiresAt.UTC(),
+	})
+}
+
+func (h *DocumentHandler) DownloadDocument(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid document ID"})
+		return
+	}
+	if !verifyDownload(h.secretKey, int32(id), c.Query("expires"), c.Query("signature"), time.Now()) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "Download link is invalid or has expired"})
+		return
+	}
+
+	doc, err := h.queries.GetDocument(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load document"})
+		return
+	}
+	body, err := h.storage.Get(c, doc.StorageKey)
+	if errors.Is(err, storage.ErrNotFound) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Document file is missing"})
+		return
+	}
+	if err != nil {
+		log.Printf("document: reading %s: %v", doc.StorageKey, err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read document"})
+		return
+	}
+	defer body.Close()
+
+	// Always an attachment, and never sniffed, so an upload cannot be made
+	// to render in the browser.
+	c.DataFromReader(http.StatusOK, doc.SizeBytes, doc.ContentType, body, map[string]string{
+		"Content-Disposition":    mime.FormatMediaType("attachment", map[string]string{"filename": doc.FileName}),
+		"X-Content-Type-Options": "nosniff",
+		"Cache-Control":          "private, no-store",
+	})
+}
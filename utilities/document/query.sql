@@ -0,0 +1,30 @@
+-- name: CreateDocument :one
+INSERT INTO candidate_documents (
+    user_id, kind, file_name, content_type, size_bytes, sha256, storage_key, scan_status
+) VALUES (
+    $1, $2, $3, $4, $5, $6, $7, $8
+)
+RETURNING *;
+
+-- name: ListUserDocuments :many
+SELECT * FROM candidate_documents
+WHERE user_id = $1
+ORDER BY uploaded_at DESC, id DESC;
+
+-- name: GetDocument :one
+SELECT * FROM candidate_documents
+WHERE id = $1 LIMIT 1;
+
+-- name: DeleteUserDocument :one
+DELETE FROM candidate_documents
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: CountUserDocuments :one
+SELECT count(*) FROM candidate_documents
+WHERE user_id = $1;
+
+-- name: GetCandidate :one
+SELECT u.id, u.name, u.email FROM users u
+WHERE u.id = $1
+  AND u.role_id IS DISTINCT FROM (SELECT id FROM roles WHERE name = 'admin');
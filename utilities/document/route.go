@@ -0,0 +1,24 @@
+package document
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesDocument(r *gin.Engine, documentHandler *DocumentHandler) {
+	// Signed links carry their own authorization so staff can open them
+	// directly in the browser.
+	r.GET("/documents/:id/download", documentHandler.DownloadDocument)
+
+	auth := r.Group("documents")
+	auth.Use(middleware.AuthMiddleware())
+	auth.POST("", documentHandler.UploadDocument)
+	auth.GET("", documentHandler.ListMyDocuments)
+	auth.DELETE("/:id", documentHandler.DeleteDocument)
+
+	admin := r.Group("documents")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.GET("/candidate/:id", documentHandler.ListCandidateDocuments)
+	admin.GET("/:id/url", documentHandler.GetDownloadURL)
+}
@@ -0,0 +1,33 @@
+CREATE TABLE IF NOT EXISTS roles(
+    id SERIAL PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    created_at timestamp default now()
+);
+
+CREATE TABLE IF NOT EXISTS users(
+    id SERIAL PRIMARY KEY,
+    role_id integer null,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password varchar(100) NOT NULL,
+    created_at timestamp default now(),
+    constraint fk_role foreign key (role_id) REFERENCES roles(id) on delete SET NULL
+);
+
+CREATE TYPE document_scan_status as ENUM ('clean','unscanned');
+
+CREATE TABLE IF NOT EXISTS candidate_documents(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    kind varchar(30) not null,
+    file_name varchar(255) not null,
+    content_type varchar(100) not null,
+    size_bytes bigint not null,
+    sha256 char(64) not null,
+    storage_key varchar(255) not null UNIQUE,
+    scan_status document_scan_status not null,
+    uploaded_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_candidate_document_user foreign key (user_id) REFERENCES users(id) on delete CASCADE
+);
+
+CREATE INDEX IF NOT EXISTS idx_candidate_documents_user ON candidate_documents(user_id);
@@ -0,0 +1,49 @@
+package document
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// downloadSignature signs a document ID and expiry. The key is prefixed so
+// a download link can never pass for anything else signed with the same
+// secret.
+func downloadSignature(secret string, documentID int32, expires int64) []byte {
+	mac := hmac.New(sha256.New, []byte("document-download:"+secret))
+	fmt.Fprintf(mac, "%d.%d", documentID, expires)
+	return mac.Sum(nil)
+}
+
+// downloadURL makes a link to a document that works without signing in
+// until it expires.
+func downloadURL(secret string, documentID int32, expiresAt time.Time) string {
+	expires := expiresAt.Unix()
+	signature := base64.RawURLEncoding.EncodeToString(downloadSignature(secret, documentID, expires))
+	return fmt.Sprintf("/documents/%d/download?expires=%d&signature=%s", documentID, expires, signature)
+}
+
+// verifyDownload checks a download link's signature and expiry.
+func verifyDownload(secret string, documentID int32, expires, signature string, now time.Time) bool {
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() >= expiresAt {
+		return false
+	}
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	return err == nil && hmac.Equal(got, downloadSignature(secret, documentID, expiresAt))
+}
+
+// newStorageKey picks where an upload is stored. The name is random so keys
+// reveal nothing about the file and cannot collide.
+func newStorageKey(userID int32) (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("documents/%d/%s", userID, hex.EncodeToString(b)), nil
+}
@@ -0,0 +1,465 @@
+package invitation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/pkg/events"
+
+	"github.com/gin-gonic/gin"
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	defaultExpiry = 72 * time.Hour
+	maxExpiry     = 30 * 24 * time.Hour
+)
+
+var assessmentTypes = []string{"behavioral", "personality", "cognitive", "coding"}
+
+var invitationStatuses = []string{"sent", "opened", "started", "completed", "expired"}
+
+// SendJobKind is the background job that emails an invitation.
+const SendJobKind = "invitation.send"
+
+// SendJob is the payload of a SendJobKind job.
+type SendJob struct {
+	InvitationID int32 `json:"invitation_id"`
+}
+
+// Notifier emails candidates the links to their invitations.
+type Notifier interface {
+	SendInvitation(ctx context.Context, invitationID int32, userID pgtype.Int4, email, name, token string, expiresAt time.Time) error
+	InvitationSent(ctx context.Context, invitationID int32) (bool, error)
+}
+
+type InvitationHandler struct {
+	db        *pgxpool.Pool
+	queries   *Queries
+	secretKey string
+	notifier  Notifier
+}
+
+func NewInvitationHandler(db *pgxpool.Pool, queries *Queries, secretKey string, notifier Notifier) *InvitationHandler {
+	return &InvitationHandler{
+		db:        db,
+		queries:   queries,
+		secretKey: secretKey,
+		notifier:  notifier,
+	}
+}
+
+// signIn issues the same session token as logging in does.
+func (h *InvitationHandler) signIn(userID int32, email string, roleID pgtype.Int4) (string, error) {
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub":     strconv.Itoa(int(userID)),
+		"email":   email,
+		"role_id": roleID.Int32,
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(24 * time.Hour).Unix(),
+	})
+	return token.SignedString([]byte(h.secretKey))
+}
+
+// findInvitation looks up the invitation a token from a link belongs to.
+func (h *InvitationHandler) findInvitation(c *gin.Context) (Invitation, error) {
+	token := c.Param("token")
+	if err := verifyToken(h.secretKey, token, time.Now()); err != nil {
+		return Invitation{}, err
+	}
+	invitation, err := h.queries.GetInvitationByTokenHash(c, tokenHash(token))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return Invitation{}, errInvalidToken
+	}
+	return invitation, err
+}
+
+// respondTokenError writes the response for a link that cannot be used.
+func respondTokenError(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, errTokenExpired):
+		c.JSON(http.StatusGone, gin.H{"error": "Invitation link has expired"})
+	case errors.Is(err, errInvalidToken):
+		c.JSON(http.StatusNotFound, gin.H{"error": "Invitation link is invalid"})
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load invitation"})
+	}
+}
+
+func (h *InvitationHandler) CreateInvitation(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	inviterID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req struct {
+		Email           string   `json:"email" binding:"required,email"`
+		Name            string   `json:"name" binding:"required"`
+		AssessmentTypes []string `json:"assessment_types" binding:"required"`
+		ExpiresInHours  int      `json:"expires_in_hours"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.AssessmentTypes) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one assessment type is required"})
+		return
+	}
+	for i, assessmentType := range req.AssessmentTypes {
+		if !slices.Contains(assessmentTypes, assessmentType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid assessment type %q", assessmentType)})
+			return
+		}
+		if slices.Contains(req.AssessmentTypes[:i], assessmentType) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Assessment type %q appears more than once", assessmentType)})
+			return
+		}
+	}
+	expiry := defaultExpiry
+	if req.ExpiresInHours != 0 {
+		expiry = time.Duration(req.ExpiresInHours) * time.Hour
+	}
+	if expiry <= 0 || expiry > maxExpiry {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invitations must expire within %d hours", int(maxExpiry.Hours()))})
+		return
+	}
+
+	// Candidates who already have an account are linked straight away.
+	var userID pgtype.Int4
+	existing, err := h.queries.GetUserByEmail(c, req.Email)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up user"})
+		return
+	}
+	if err == nil {
+		if existing.IsAdmin {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Staff accounts cannot be invited"})
+			return
+		}
+		userID = pgtype.Int4{Int32: existing.ID, Valid: true}
+	}
+
+	// The email job issues the link that is sent, so the token made here is
+	// never seen by anyone; it only fills the column until then.
+	expiresAt := time.Now().Add(expiry).Truncate(time.Second)
+	token, err := newToken(h.secretKey, expiresAt)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation link"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	invitation, err := qtx.CreateInvitation(c, CreateInvitationParams{
+		Email:           strings.TrimSpace(req.Email),
+		Name:            strings.TrimSpace(req.Name),
+		UserID:          userID,
+		AssessmentTypes: req.AssessmentTypes,
+		TokenHash:       tokenHash(token),
+		InvitedBy:       int32(inviterID),
+		ExpiresAt:       pgtype.Timestamp{Time: expiresAt, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create invitation"})
+		return
+	}
+
+	// The email is queued with the invitation, so it goes out, and is
+	// retried, even if the server stops before sending it.
+	payload, err := json.Marshal(SendJob{InvitationID: invitation.ID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue invitation email"})
+		return
+	}
+	if err := qtx.EnqueueJob(c, EnqueueJobParams{Kind: SendJobKind, Payload: payload}); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue invitation email"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit invitation"})
+		return
+	}
+
+	// The token only ever goes to the candidate's inbox, and the database
+	// keeps its hash, so staff cannot use a link to act as the candidate.
+	c.JSON(http.StatusCreated, gin.H{"invitation": invitation})
+}
+
+// SendInvitationEmail runs a SendJobKind job. Only a token's hash is stored,
+// so each attempt issues a fresh link and the latest email holds the one
+// that works. Once an email has gone out, repeats leave its link alone.
+func (h *InvitationHandler) SendInvitationEmail(ctx context.Context, job SendJob) error {
+	sent, err := h.notifier.InvitationSent(ctx, job.InvitationID)
+	if err != nil {
+		return err
+	}
+	if sent {
+		return nil
+	}
+
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := h.queries.WithTx(tx)
+
+	invitation, err := qtx.LockInvitation(ctx, job.InvitationID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if invitation.AcceptedAt.Valid || !time.Now().Before(invitation.ExpiresAt.Time) {
+		return nil
+	}
+	token, err := newToken(h.secretKey, invitation.ExpiresAt.Time)
+	if err != nil {
+		return err
+	}
+	err = qtx.UpdateInvitationTokenHash(ctx, UpdateInvitationTokenHashParams{
+		ID:        invitation.ID,
+		TokenHash: tokenHash(token),
+	})
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return err
+	}
+
+	return h.notifier.SendInvitation(ctx, invitation.ID, invitation.UserID, invitation.Email, invitation.Name, token, invitation.ExpiresAt.Time)
+}
+
+func (h *InvitationHandler) ListInvitations(c *gin.Context) {
+	var status pgtype.Text
+	if s := c.Query("status"); s != "" {
+		if !slices.Contains(invitationStatuses, s) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Status must be one of %s", strings.Join(invitationStatuses, ", "))})
+			return
+		}
+		status = pgtype.Text{String: s, Valid: true}
+	}
+
+	invitations, err := h.queries.ListInvitations(c, status)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve invitations"})
+		return
+	}
+	if invitations == nil {
+		invitations = []ListInvitationsRow{}
+	}
+	c.JSON(http.StatusOK, invitations)
+}
+
+func (h *InvitationHandler) OpenInvitation(c *gin.Context) {
+	invitation, err := h.findInvitation(c)
+	if err != nil {
+		respondTokenError(c, err)
+		return
+	}
+	if invitation.AcceptedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Invitation has already been used"})
+		return
+	}
+	if err := h.queries.MarkInvitationOpened(c, invitation.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to open invitation"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"email":            invitation.Email,
+		"name":             invitation.Name,
+		"assessment_types": invitation.AssessmentTypes,
+		"expires_at":       invitation.ExpiresAt.Time,
+		"has_account":      invitation.UserID.Valid,
+	})
+}
+
+func (h *InvitationHandler) AcceptInvitation(c *gin.Context) {
+	var req struct {
+		Password string `json:"password"`
+	}
+	if c.Request.ContentLength > 0 {
+		if err := c.ShouldBindJSON(&req); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	found, err := h.findInvitation(c)
+	if err != nil {
+		respondTokenError(c, err)
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	// Locking the invitation makes a second click on the same link wait for
+	// the first, then see it used.
+	invitation, err := qtx.LockInvitation(c, found.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load invitation"})
+		return
+	}
+	if invitation.AcceptedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Invitation has already been used"})
+		return
+	}
+
+	// The link went to the invited address, so following it proves the
+	// candidate owns it. That is enough to create an account with the chosen
+	// password, but an existing account still signs in with its own
+	// password: the link alone never opens someone else's session.
+	var userID int32
+	var email string
+	var roleID pgtype.Int4
+	var created bool
+	existing, err := qtx.GetUserByEmail(c, invitation.Email)
+	switch {
+	case err == nil:
+		if existing.IsAdmin {
+			c.JSON(http.StatusForbidden, gin.H{"error": "Staff accounts cannot accept invitations"})
+			return
+		}
+		userID, email, roleID = existing.ID, existing.Email, existing.RoleID
+	case errors.Is(err, pgx.ErrNoRows):
+		if len(req.Password) < 8 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Password must be at least 8 characters"})
+			return
+		}
+		hashedPassword, err := bcrypt.GenerateFromPassword([]byte(req.Password), bcrypt.DefaultCost)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to hash password"})
+			return
+		}
+		user, err := qtx.CreateCandidateUser(c, CreateCandidateUserParams{
+			Name:     invitation.Name,
+			Email:    invitation.Email,
+			Password: string(hashedPassword),
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create user"})
+			return
+		}
+		userID, email, roleID = user.ID, user.Email, user.RoleID
+		created = true
+	default:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up user"})
+		return
+	}
+
+	invitation, err = qtx.AcceptInvitation(c, AcceptInvitationParams{
+		ID:     invitation.ID,
+		UserID: pgtype.Int4{Int32: userID, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to accept invitation"})
+		return
+	}
+	err = qtx.AssignAssessments(c, AssignAssessmentsParams{
+		UserID:          userID,
+		AssessmentTypes: invitation.AssessmentTypes,
+		InvitationID:    invitation.ID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign assessments"})
+		return
+	}
+	if created {
+		payload, err := json.Marshal(events.CandidateCreatedData{
+			UserID:       userID,
+			Name:         invitation.Name,
+			Email:        email,
+			InvitationID: &invitation.ID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record new candidate"})
+			return
+		}
+		err = qtx.EnqueueOutboxEvent(c, EnqueueOutboxEventParams{
+			EventType: events.CandidateCreated,
+			DedupKey:  events.DedupKey(events.CandidateCreated, userID),
+			Payload:   payload,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record new candidate"})
+			return
+		}
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit invitation"})
+		return
+	}
+	if !created {
+		c.JSON(http.StatusOK, gin.H{
+			"login_required":   true,
+			"assessment_types": invitation.AssessmentTypes,
+		})
+		return
+	}
+	signedToken, err := h.signIn(userID, email, roleID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate token"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"token":            signedToken,
+		"login_required":   false,
+		"assessment_types": invitation.AssessmentTypes,
+	})
+}
+
+func (h *InvitationHandler) ListAssignedAssessments(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	assigned, err := h.queries.ListAssignedAssessments(c, int32(userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve assigned assessments"})
+		return
+	}
+	if assigned == nil {
+		assigned = []ListAssignedAssessmentsRow{}
+	}
+	c.JSON(http.StatusOK, assigned)
+}
@@ -0,0 +1,147 @@
+-- name: GetUserByEmail :one
+SELECT
+  u.id,
+  u.name,
+  u.email,
+  u.role_id,
+  u.role_id IS NOT DISTINCT FROM (SELECT id FROM roles WHERE name = 'admin') AS is_admin
+FROM users u
+WHERE lower(u.email) = lower($1)
+LIMIT 1;
+
+-- name: CreateCandidateUser :one
+INSERT INTO users(
+    name,
+    email,
+    password,
+    role_id,
+    created_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    (SELECT id FROM roles WHERE name = 'candidate'),
+    CURRENT_TIMESTAMP
+) RETURNING *;
+
+-- name: CreateInvitation :one
+INSERT INTO invitations (
+    email,
+    name,
+    user_id,
+    assessment_types,
+    token_hash,
+    invited_by,
+    expires_at
+) VALUES (
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    $6,
+    $7
+) RETURNING *;
+
+-- name: GetInvitationByTokenHash :one
+SELECT * FROM invitations
+WHERE token_hash = $1;
+
+-- name: LockInvitation :one
+SELECT * FROM invitations
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpdateInvitationTokenHash :exec
+UPDATE invitations
+SET token_hash = $2
+WHERE id = $1;
+
+-- name: MarkInvitationOpened :exec
+UPDATE invitations
+SET opened_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND opened_at IS NULL;
+
+-- name: AcceptInvitation :one
+UPDATE invitations
+SET user_id = $2,
+    accepted_at = CURRENT_TIMESTAMP,
+    opened_at = COALESCE(opened_at, CURRENT_TIMESTAMP)
+WHERE id = $1 AND accepted_at IS NULL
+RETURNING *;
+
+-- name: AssignAssessments :exec
+INSERT INTO assigned_assessments (user_id, assessment_type, invitation_id)
+SELECT @user_id::int, unnest(@assessment_types::text[]), @invitation_id::int
+ON CONFLICT (user_id, assessment_type) DO UPDATE
+SET invitation_id = EXCLUDED.invitation_id,
+    assigned_at = CURRENT_TIMESTAMP;
+
+-- name: ListInvitations :many
+-- Invitations newest first with their status, optionally narrowed to one
+-- status. Only sessions started after the invitation count towards it.
+WITH tracked AS (
+  SELECT
+    i.id,
+    i.email,
+    i.name,
+    i.user_id,
+    i.assessment_types,
+    i.invited_by,
+    i.expires_at,
+    i.opened_at,
+    i.accepted_at,
+    i.created_at,
+    CASE
+      WHEN i.accepted_at IS NOT NULL AND NOT EXISTS (
+        SELECT 1 FROM unnest(i.assessment_types) AS t(assessment_type)
+        WHERE NOT EXISTS (
+          SELECT 1 FROM user_assessment_sessions s
+          WHERE s.user_id = i.user_id
+            AND s.assessment_type = t.assessment_type
+            AND s.completed_at IS NOT NULL
+            AND s.started_at >= i.created_at
+        )
+      ) THEN 'completed'
+      WHEN i.accepted_at IS NOT NULL AND EXISTS (
+        SELECT 1 FROM user_assessment_sessions s
+        WHERE s.user_id = i.user_id
+          AND s.assessment_type = ANY(i.assessment_types)
+          AND s.started_at >= i.created_at
+      ) THEN 'started'
+      WHEN i.accepted_at IS NULL AND i.expires_at < CURRENT_TIMESTAMP THEN 'expired'
+      WHEN i.opened_at IS NOT NULL THEN 'opened'
+      ELSE 'sent'
+    END::text AS status
+  FROM invitations i
+)
+SELECT id, email, name, user_id, assessment_types, invited_by, expires_at, opened_at, accepted_at, created_at, status
+FROM tracked
+WHERE sqlc.narg(status)::text IS NULL OR status = sqlc.narg(status)::text
+ORDER BY created_at DESC, id DESC;
+
+-- name: ListAssignedAssessments :many
+SELECT
+  a.assessment_type,
+  a.assigned_at,
+  EXISTS (
+    SELECT 1 FROM user_assessment_sessions s
+    WHERE s.user_id = a.user_id
+      AND s.assessment_type = a.assessment_type
+      AND s.completed_at IS NOT NULL
+      AND s.started_at >= a.assigned_at
+  ) AS completed
+FROM assigned_assessments a
+WHERE a.user_id = $1
+ORDER BY a.assessment_type;
+
+-- name: EnqueueOutboxEvent :exec
+-- Record an event in the outbox; run it in the transaction making the change
+INSERT INTO outbox_events (event_type, dedup_key, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (dedup_key) DO NOTHING;
+
+-- name: EnqueueJob :exec
+-- Queue background work; run it in the transaction it belongs to
+INSERT INTO background_jobs (kind, payload)
+VALUES ($1, $2);
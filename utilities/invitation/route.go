@@ -0,0 +1,23 @@
+package invitation
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesInvitation(r *gin.Engine, invitationHandler *InvitationHandler) {
+	// Invitation links are followed before the candidate has an account.
+	public := r.Group("invitations")
+	public.GET("/token/:token", invitationHandler.OpenInvitation)
+	public.POST("/token/:token/accept", invitationHandler.AcceptInvitation)
+
+	auth := r.Group("invitations")
+	auth.Use(middleware.AuthMiddleware())
+	auth.GET("/assigned", invitationHandler.ListAssignedAssessments)
+
+	admin := r.Group("invitations")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.POST("", invitationHandler.CreateInvitation)
+	admin.GET("", invitationHandler.ListInvitations)
+}
@@ -0,0 +1,82 @@
+CREATE TABLE IF NOT EXISTS roles(
+    id SERIAL PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    created_at timestamp default now()
+);
+
+CREATE TABLE IF NOT EXISTS users(
+    id SERIAL PRIMARY KEY,
+    role_id integer null,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password varchar(100) NOT NULL,
+    created_at timestamp default now(),
+    constraint fk_role foreign key (role_id) REFERENCES roles(id) on delete SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_sessions(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    started_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    completed_at timestamp null,
+    blueprint_id int null,
+    quality_flags JSONB NOT NULL DEFAULT '[]'::jsonb,
+    submitted_at timestamp null
+);
+
+CREATE TABLE IF NOT EXISTS invitations(
+    id SERIAL PRIMARY KEY,
+    email varchar(100) not null,
+    name varchar(100) not null,
+    user_id int null,
+    assessment_types text[] not null,
+    token_hash varchar(64) not null UNIQUE,
+    invited_by int not null,
+    expires_at timestamp not null,
+    opened_at timestamp null,
+    accepted_at timestamp null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_invitation_user foreign key (user_id) REFERENCES users(id) on delete SET NULL,
+    constraint fk_invitation_inviter foreign key (invited_by) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS assigned_assessments(
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    invitation_id int null,
+    assigned_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, assessment_type),
+    constraint fk_assigned_assessment_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_assigned_assessment_invitation foreign key (invitation_id) REFERENCES invitations(id) on delete SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events(
+    id BIGSERIAL PRIMARY KEY,
+    event_type varchar(50) not null,
+    dedup_key varchar(255) not null UNIQUE,
+    payload JSONB not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    available_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    attempts int not null DEFAULT 0,
+    last_error text null,
+    dispatched_at timestamp null
+);
+
+CREATE TYPE background_job_status as ENUM ('queued','running','succeeded','failed');
+
+CREATE TABLE IF NOT EXISTS background_jobs(
+    id BIGSERIAL PRIMARY KEY,
+    kind varchar(100) not null,
+    payload JSONB not null,
+    status background_job_status not null DEFAULT 'queued',
+    attempts int not null DEFAULT 0,
+    max_attempts int not null DEFAULT 5,
+    run_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamp null,
+    last_error text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    started_at timestamp null,
+    finished_at timestamp null,
+    constraint ck_background_job_max_attempts check (max_attempts > 0)
+);
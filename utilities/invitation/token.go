@@ -0,0 +1,70 @@
+package invitation
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	errInvalidToken = errors.New("invitation link is invalid")
+	errTokenExpired = errors.New("invitation link has expired")
+)
+
+// tokenSignature signs an invitation token's payload. The key is prefixed so
+// a token can never pass for anything else signed with the same secret.
+func tokenSignature(secret, payload string) []byte {
+	mac := hmac.New(sha256.New, []byte("invitation:"+secret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+// newToken makes a signed invitation token of the form
+// "<expiry>.<nonce>.<signature>", expiring at the given time.
+func newToken(secret string, expiresAt time.Time) (string, error) {
+	nonce := make([]byte, 24)
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+	payload := strconv.FormatInt(expiresAt.Unix(), 10) + "." + base64.RawURLEncoding.EncodeToString(nonce)
+	return payload + "." + base64.RawURLEncoding.EncodeToString(tokenSignature(secret, payload)), nil
+}
+
+// verifyToken checks a token's signature and expiry. It says nothing about
+// whether the token has been used; that is up to the stored invitation.
+func verifyToken(secret, token string, now time.Time) error {
+	i := strings.LastIndex(token, ".")
+	if i < 0 {
+		return errInvalidToken
+	}
+	payload, signature := token[:i], token[i+1:]
+	got, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(got, tokenSignature(secret, payload)) {
+		return errInvalidToken
+	}
+	expiry, _, ok := strings.Cut(payload, ".")
+	if !ok {
+		return errInvalidToken
+	}
+	expiresAt, err := strconv.ParseInt(expiry, 10, 64)
+	if err != nil {
+		return errInvalidToken
+	}
+	if now.Unix() >= expiresAt {
+		return errTokenExpired
+	}
+	return nil
+}
+
+// tokenHash is what is stored to find an invitation by its token, so a
+// leaked table does not leak working links.
+func tokenHash(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
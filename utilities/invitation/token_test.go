@@ -0,0 +1,57 @@
+package invitation
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestVerifyToken(t *testing.T) {
+	const secret = "test-secret"
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	valid, err := newToken(secret, now.Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expired, err := newToken(secret, now.Add(-time.Second))
+	if err != nil {
+		t.Fatal(err)
+	}
+	expiresNow, err := newToken(secret, now)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	parts := strings.Split(valid, ".")
+	// A later expiry under the original signature.
+	extended := "9999999999." + parts[1] + "." + parts[2]
+	// A payload with no nonce, correctly signed.
+	noNonce := "9999999999." + base64.RawURLEncoding.EncodeToString(tokenSignature(secret, "9999999999"))
+
+	tests := []struct {
+		name  string
+		token string
+		want  error
+	}{
+		{name: "valid", token: valid, want: nil},
+		{name: "expired", token: expired, want: errTokenExpired},
+		{name: "expires at this instant", token: expiresNow, want: errTokenExpired},
+		{name: "empty", token: "", want: errInvalidToken},
+		{name: "no signature", token: parts[0] + "." + parts[1], want: errInvalidToken},
+		{name: "tampered expiry", token: extended, want: errInvalidToken},
+		{name: "signature not base64", token: parts[0] + "." + parts[1] + ".!!!", want: errInvalidToken},
+		{name: "payload without nonce", token: noNonce, want: errInvalidToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := verifyToken(secret, tt.token, now); got != tt.want {
+				t.Errorf("verifyToken = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if err := verifyToken("other-secret", valid, now); err != errInvalidToken {
+		t.Errorf("verifyToken with another secret = %v, want %v", err, errInvalidToken)
+	}
+}
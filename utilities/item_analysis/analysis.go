@@ -0,0 +1,547 @@
+package item_analysis
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+)
+
+const (
+	// minItemResponses is the fewest answers an item needs before any
+	// statistic is reported for it.
+	minItemResponses = 10
+	// minPairResponses is the fewest sessions two items must share for their
+	// covariance to count towards a category's alpha.
+	minPairResponses = 5
+
+	lowCorrelation  = 0.2
+	easyThreshold   = 0.95
+	hardThreshold   = 0.2
+	rareDistractor  = 0.05
+	alphaGainMargin = 0.01
+	// discriminationGroup is the share of candidates in each of the upper and
+	// lower groups of the discrimination index.
+	discriminationGroup = 0.27
+)
+
+// Item flags.
+const (
+	flagInsufficientResponses = "insufficient_responses"
+	flagLowCorrelation        = "low_item_total_correlation"
+	flagNegativeCorrelation   = "negative_item_total_correlation"
+	flagTooEasy               = "too_easy"
+	flagTooHard               = "too_hard"
+	flagLowersAlpha           = "lowers_category_alpha"
+	flagWeakDistractor        = "weak_distractor"
+	flagAttractiveDistractor  = "distractor_attracts_high_scorers"
+)
+
+type OptionStats struct {
+	Option        string   `json:"option"`
+	Text          string   `json:"text"`
+	Count         int      `json:"count"`
+	Proportion    float64  `json:"proportion"`
+	IsKey         bool     `json:"is_key"`
+	MeanRestScore *float64 `json:"mean_rest_score"`
+	// Correlation is the point-biserial correlation between choosing the
+	// option and the rest score. Distractors should be negative.
+	Correlation *float64 `json:"correlation"`
+	Flags       []string `json:"flags"`
+}
+
+type ItemStats struct {
+	QuestionID int32    `json:"question_id"`
+	Question   string   `json:"question"`
+	Categories []string `json:"categories"`
+	Responses  int      `json:"responses"`
+	// Difficulty is the p-value: the mean item score as a share of the
+	// maximum, which for cognitive items is the proportion answering correctly.
+	Difficulty           *float64      `json:"difficulty"`
+	ItemTotalCorrelation *float64      `json:"item_total_correlation"`
+	DiscriminationIndex  *float64      `json:"discrimination_index"`
+	Options              []OptionStats `json:"options"`
+	Flags                []string      `json:"flags"`
+}
+
+type ItemAlpha struct {
+	QuestionID int32    `json:"question_id"`
+	Alpha      *float64 `json:"alpha"`
+}
+
+type CategoryStats struct {
+	CategoryID     int32       `json:"category_id"`
+	Name           string      `json:"name"`
+	Items          int         `json:"items"`
+	Alpha          *float64    `json:"alpha"`
+	AlphaIfDeleted []ItemAlpha `json:"alpha_if_item_deleted"`
+	// HarmfulItems lists items whose removal would raise alpha.
+	HarmfulItems []int32 `json:"items_hurting_reliability"`
+}
+
+type Analysis struct {
+	AssessmentType string          `json:"assessment_type"`
+	Sessions       int             `json:"sessions"`
+	Items          []ItemStats     `json:"items"`
+	Categories     []CategoryStats `json:"categories"`
+}
+
+// item gathers what is known about one question: how each option scores
+// per category and what every session answered.
+type item struct {
+	row        ListItemsRow
+	options    map[string]string
+	points     map[int32]map[int32]float64 // answer value -> category -> points
+	categories []int32
+	maxScore   float64
+	key        string
+	answers    map[int32]string // session -> selected option
+}
+
+// score is what a selected option earns in one category.
+func (it *item) score(selected string, categoryID int32) float64 {
+	value, err := strconv.Atoi(selected)
+	if err != nil {
+		return 0
+	}
+	return it.points[int32(value)][categoryID]
+}
+
+// total is what a selected option earns across the item's categories.
+func (it *item) total(selected string) float64 {
+	var sum float64
+	for _, categoryID := range it.categories {
+		sum += it.score(selected, categoryID)
+	}
+	return sum
+}
+
+func buildAnalysis(assessmentType string, rows []ListItemsRow, mappings []ListItemMappingsRow, responses []ListItemResponsesRow, scores []ListSessionCategoryScoresRow) Analysis {
+	items := make(map[int32]*item)
+	var order []int32
+	for _, row := range rows {
+		items[row.ID] = &item{
+			row:     row,
+			options: optionTexts(row.Options),
+			points:  make(map[int32]map[int32]float64),
+			answers: make(map[int32]string),
+		}
+		order = append(order, row.ID)
+	}
+
+	categoryNames := make(map[int32]string)
+	for _, m := range mappings {
+		it, ok := items[m.QuestionID]
+		if !ok || !m.AnswerValue.Valid {
+			continue
+		}
+		categoryNames[m.CategoryID] = m.CategoryName.String
+		if it.points[m.AnswerValue.Int32] == nil {
+			it.points[m.AnswerValue.Int32] = make(map[int32]float64)
+		}
+		it.points[m.AnswerValue.Int32][m.CategoryID] += float64(m.Points.Int32)
+	}
+	for _, it := range items {
+		seen := make(map[int32]bool)
+		for answer, byCategory := range it.points {
+			var sum float64
+			for categoryID, points := range byCategory {
+				sum += points
+				if !seen[categoryID] {
+					seen[categoryID] = true
+					it.categories = append(it.categories, categoryID)
+				}
+			}
+			if sum > it.maxScore {
+				it.maxScore = sum
+				it.key = strconv.Itoa(int(answer))
+			}
+		}
+		sort.Slice(it.categories, func(i, j int) bool { return it.categories[i] < it.categories[j] })
+	}
+
+	sessions := make(map[int32]bool)
+	for _, r := range responses {
+		it, ok := items[r.QuestionID.Int32]
+		if !ok || !r.SessionID.Valid {
+			continue
+		}
+		it.answers[r.SessionID.Int32] = r.Selected
+		sessions[r.SessionID.Int32] = true
+	}
+
+	// categoryScores[session][category] is the stored score.
+	categoryScores := make(map[int32]map[int32]float64)
+	for _, s := range scores {
+		if !s.SessionID.Valid || !s.CategoryID.Valid {
+			continue
+		}
+		if categoryScores[s.SessionID.Int32] == nil {
+			categoryScores[s.SessionID.Int32] = make(map[int32]float64)
+		}
+		categoryScores[s.SessionID.Int32][s.CategoryID.Int32] = float64(s.Score.Int32)
+	}
+
+	analysis := Analysis{
+		AssessmentType: assessmentType,
+		Sessions:       len(sessions),
+		Items:          []ItemStats{},
+		Categories:     []CategoryStats{},
+	}
+	statsByID := make(map[int32]*ItemStats)
+	for _, id := range order {
+		stats := analyzeItem(assessmentType, items[id], categoryNames, categoryScores)
+		analysis.Items = append(analysis.Items, stats)
+	}
+	for i := range analysis.Items {
+		statsByID[analysis.Items[i].QuestionID] = &analysis.Items[i]
+	}
+
+	var categoryIDs []int32
+	for categoryID := range categoryNames {
+		categoryIDs = append(categoryIDs, categoryID)
+	}
+	sort.Slice(categoryIDs, func(i, j int) bool { return categoryIDs[i] < categoryIDs[j] })
+	for _, categoryID := range categoryIDs {
+		var members []*item
+		for _, id := range order {
+			it := items[id]
+			if len(it.answers) >= minItemResponses && contains(it.categories, categoryID) {
+				members = append(members, it)
+			}
+		}
+		stats := analyzeCategory(categoryID, categoryNames[categoryID], members)
+		for _, id := range stats.HarmfulItems {
+			statsByID[id].Flags = appendFlag(statsByID[id].Flags, flagLowersAlpha)
+		}
+		analysis.Categories = append(analysis.Categories, stats)
+	}
+	return analysis
+}
+
+func analyzeItem(assessmentType string, it *item, categoryNames map[int32]string, categoryScores map[int32]map[int32]float64) ItemStats {
+	stats := ItemStats{
+		QuestionID: it.row.ID,
+		Question:   it.row.Question,
+		Categories: []string{},
+		Responses:  len(it.answers),
+		Options:    []OptionStats{},
+		Flags:      []string{},
+	}
+	for _, categoryID := range it.categories {
+		stats.Categories = append(stats.Categories, categoryNames[categoryID])
+	}
+
+	// Rest score: the session's score in the item's categories without the
+	// item itself, so the item is not correlated with itself.
+	var sessionIDs []int32
+	var itemScores, restScores []float64
+	for sessionID, selected := range it.answers {
+		byCategory, ok := categoryScores[sessionID]
+		if !ok {
+			continue
+		}
+		var rest float64
+		for _, categoryID := range it.categories {
+			rest += byCategory[categoryID] - it.score(selected, categoryID)
+		}
+		sessionIDs = append(sessionIDs, sessionID)
+		itemScores = append(itemScores, it.total(selected))
+		restScores = append(restScores, rest)
+	}
+
+	counts := make(map[string]int)
+	for _, selected := range it.answers {
+		counts[selected]++
+	}
+	keys := make([]string, 0, len(it.options))
+	for key := range it.options {
+		keys = append(keys, key)
+	}
+	for key := range counts {
+		if _, ok := it.options[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sortOptionKeys(keys)
+
+	cognitive := assessmentType == "cognitive"
+	for _, key := range keys {
+		option := OptionStats{
+			Option: key,
+			Text:   it.options[key],
+			Count:  counts[key],
+			IsKey:  cognitive && key == it.key,
+			Flags:  []string{},
+		}
+		if len(it.answers) > 0 {
+			option.Proportion = roundStat(float64(counts[key]) / float64(len(it.answers)))
+		}
+		if len(it.answers) >= minItemResponses {
+			chose := make([]float64, len(sessionIDs))
+			var restSum float64
+			for i, sessionID := range sessionIDs {
+				if it.answers[sessionID] == key {
+					chose[i] = 1
+					restSum += restScores[i]
+				}
+			}
+			if counts[key] > 0 {
+				option.MeanRestScore = statPtr(restSum / float64(counts[key]))
+			}
+			if r, ok := correlation(chose, restScores); ok {
+				option.Correlation = statPtr(r)
+			}
+			if cognitive && key != it.key && it.key != "" {
+				if option.Proportion < rareDistractor {
+					option.Flags = append(option.Flags, flagWeakDistractor)
+					stats.Flags = appendFlag(stats.Flags, flagWeakDistractor)
+				}
+				if option.Correlation != nil && *option.Correlation > 0 {
+					option.Flags = append(option.Flags, flagAttractiveDistractor)
+					stats.Flags = appendFlag(stats.Flags, flagAttractiveDistractor)
+				}
+			}
+		}
+		stats.Options = append(stats.Options, option)
+	}
+
+	if len(it.answers) < minItemResponses {
+		stats.Flags = appendFlag(stats.Flags, flagInsufficientResponses)
+		return stats
+	}
+
+	if it.maxScore > 0 {
+		var sum float64
+		for _, selected := range it.answers {
+			sum += it.total(selected)
+		}
+		p := sum / float64(len(it.answers)) / it.maxScore
+		stats.Difficulty = statPtr(p)
+		if cognitive && p > easyThreshold {
+			stats.Flags = appendFlag(stats.Flags, flagTooEasy)
+		}
+		if cognitive && p < hardThreshold {
+			stats.Flags = appendFlag(stats.Flags, flagTooHard)
+		}
+	}
+
+	if r, ok := correlation(itemScores, restScores); ok {
+		stats.ItemTotalCorrelation = statPtr(r)
+		if r < 0 {
+			stats.Flags = appendFlag(stats.Flags, flagNegativeCorrelation)
+		} else if r < lowCorrelation {
+			stats.Flags = appendFlag(stats.Flags, flagLowCorrelation)
+		}
+	}
+
+	if cognitive && it.maxScore > 0 {
+		stats.DiscriminationIndex = discriminationIndex(it, sessionIDs, restScores)
+	}
+	return stats
+}
+
+// discriminationIndex is the proportion correct in the top 27% of sessions by
+// rest score minus the proportion correct in the bottom 27%.
+func discriminationIndex(it *item, sessionIDs []int32, restScores []float64) *float64 {
+	order := make([]int, len(sessionIDs))
+	for i := range order {
+		order[i] = i
+	}
+	sort.Slice(order, func(i, j int) bool { return restScores[order[i]] > restScores[order[j]] })
+	size := int(math.Round(float64(len(order)) * discriminationGroup))
+	if size == 0 {
+		return nil
+	}
+	correct := func(group []int) float64 {
+		var n float64
+		for _, i := range group {
+			if it.total(it.answers[sessionIDs[i]]) >= it.maxScore {
+				n++
+			}
+		}
+		return n / float64(len(group))
+	}
+	return statPtr(correct(order[:size]) - correct(order[len(order)-size:]))
+}
+
+// analyzeCategory computes Cronbach's alpha for the items of a category and
+// alpha with each item left out. Sessions rarely see every item once question
+// sets are randomized, so variances and covariances are taken pairwise over
+// the sessions that answered the items involved.
+func analyzeCategory(categoryID int32, name string, members []*item) CategoryStats {
+	stats := CategoryStats{
+		CategoryID:     categoryID,
+		Name:           name,
+		Items:          len(members),
+		AlphaIfDeleted: []ItemAlpha{},
+		HarmfulItems:   []int32{},
+	}
+
+	k := len(members)
+	cov := make([][]float64, k)
+	known := make([][]bool, k)
+	for i := range members {
+		cov[i] = make([]float64, k)
+		known[i] = make([]bool, k)
+	}
+	for i := 0; i < k; i++ {
+		for j := i; j < k; j++ {
+			var x, y []float64
+			for sessionID, selected := range members[i].answers {
+				other, ok := members[j].answers[sessionID]
+				if !ok {
+					continue
+				}
+				x = append(x, members[i].score(selected, categoryID))
+				y = append(y, members[j].score(other, categoryID))
+			}
+			if len(x) < minPairResponses {
+				continue
+			}
+			c := covariance(x, y)
+			cov[i][j], cov[j][i] = c, c
+			known[i][j], known[j][i] = true, true
+		}
+	}
+
+	alpha := func(skip int) *float64 {
+		var n int
+		var itemVariance, totalVariance float64
+		for i := 0; i < k; i++ {
+			if i == skip {
+				continue
+			}
+			n++
+			for j := 0; j < k; j++ {
+				if j == skip {
+					continue
+				}
+				if !known[i][j] {
+					return nil
+				}
+				totalVariance += cov[i][j]
+			}
+			itemVariance += cov[i][i]
+		}
+		if n < 2 || totalVariance <= 0 {
+			return nil
+		}
+		a := float64(n) / float64(n-1) * (1 - itemVariance/totalVariance)
+		return &a
+	}
+
+	full := alpha(-1)
+	if full != nil {
+		stats.Alpha = statPtr(*full)
+	}
+	for i, it := range members {
+		without := alpha(i)
+		entry := ItemAlpha{QuestionID: it.row.ID}
+		if without != nil {
+			entry.Alpha = statPtr(*without)
+			if full != nil && *without > *full+alphaGainMargin {
+				stats.HarmfulItems = append(stats.HarmfulItems, it.row.ID)
+			}
+		}
+		stats.AlphaIfDeleted = append(stats.AlphaIfDeleted, entry)
+	}
+	return stats
+}
+
+func mean(values []float64) float64 {
+	var sum float64
+	for _, v := range values {
+		sum += v
+	}
+	return sum / float64(len(values))
+}
+
+// covariance is the sample covariance of two equally long series.
+func covariance(x, y []float64) float64 {
+	if len(x) < 2 {
+		return 0
+	}
+	mx, my := mean(x), mean(y)
+	var sum float64
+	for i := range x {
+		sum += (x[i] - mx) * (y[i] - my)
+	}
+	return sum / float64(len(x)-1)
+}
+
+// correlation is Pearson's r; it is undefined when either series is constant.
+func correlation(x, y []float64) (float64, bool) {
+	if len(x) < 2 {
+		return 0, false
+	}
+	vx, vy := covariance(x, x), covariance(y, y)
+	if vx == 0 || vy == 0 {
+		return 0, false
+	}
+	return covariance(x, y) / math.Sqrt(vx*vy), true
+}
+
+// optionTexts reads option labels, which are either plain strings or
+// objects with a "text" field.
+func optionTexts(raw []byte) map[string]string {
+	texts := make(map[string]string)
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(raw, &options); err != nil {
+		return texts
+	}
+	for key, value := range options {
+		var text string
+		if err := json.Unmarshal(value, &text); err == nil {
+			texts[key] = text
+			continue
+		}
+		var labelled struct {
+			Text string `json:"text"`
+		}
+		if err := json.Unmarshal(value, &labelled); err == nil {
+			texts[key] = labelled.Text
+		}
+	}
+	return texts
+}
+
+// sortOptionKeys orders keys numerically where possible so "10" follows "9".
+func sortOptionKeys(keys []string) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+}
+
+func contains(ids []int32, id int32) bool {
+	for _, v := range ids {
+		if v == id {
+			return true
+		}
+	}
+	return false
+}
+
+func appendFlag(flags []string, flag string) []string {
+	for _, f := range flags {
+		if f == flag {
+			return flags
+		}
+	}
+	return append(flags, flag)
+}
+
+// statPtr rounds a statistic to three decimals for reporting.
+func statPtr(v float64) *float64 {
+	r := roundStat(v)
+	return &r
+}
+
+func roundStat(v float64) float64 {
+	return math.Round(v*1000) / 1000
+}
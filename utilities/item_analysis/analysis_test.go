@@ -0,0 +1,156 @@
+package item_analysis
+
+import (
+	"math"
+	"strconv"
+	"testing"
+)
+
+func TestCovarianceAndCorrelation(t *testing.T) {
+	tests := []struct {
+		name       string
+		x, y       []float64
+		wantCov    float64
+		wantR      float64
+		wantDefine bool
+	}{
+		{name: "perfectly related", x: []float64{1, 2, 3, 4}, y: []float64{2, 4, 6, 8}, wantCov: 10.0 / 3, wantR: 1, wantDefine: true},
+		{name: "perfectly opposed", x: []float64{1, 2, 3}, y: []float64{3, 2, 1}, wantCov: -1, wantR: -1, wantDefine: true},
+		{name: "unrelated", x: []float64{1, 2, 3, 4}, y: []float64{1, -1, -1, 1}, wantCov: 0, wantR: 0, wantDefine: true},
+		{name: "constant series", x: []float64{1, 2, 3}, y: []float64{5, 5, 5}, wantCov: 0},
+		{name: "too short", x: []float64{1}, y: []float64{1}, wantCov: 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := covariance(tt.x, tt.y); math.Abs(got-tt.wantCov) > 1e-9 {
+				t.Errorf("covariance = %v, want %v", got, tt.wantCov)
+			}
+			r, ok := correlation(tt.x, tt.y)
+			if ok != tt.wantDefine {
+				t.Fatalf("correlation defined = %v, want %v", ok, tt.wantDefine)
+			}
+			if ok && math.Abs(r-tt.wantR) > 1e-9 {
+				t.Errorf("correlation = %v, want %v", r, tt.wantR)
+			}
+		})
+	}
+}
+
+// likertItem builds an item scoring its answer value in category 1, with
+// one answer per session.
+func likertItem(id int32, answers []int) *item {
+	it := &item{
+		row:        ListItemsRow{ID: id},
+		points:     make(map[int32]map[int32]float64),
+		categories: []int32{1},
+		answers:    make(map[int32]string),
+	}
+	for value := int32(1); value <= 5; value++ {
+		it.points[value] = map[int32]float64{1: float64(value)}
+	}
+	for session, answer := range answers {
+		it.answers[int32(session)] = strconv.Itoa(answer)
+	}
+	return it
+}
+
+func TestAnalyzeCategory(t *testing.T) {
+	varied := []int{1, 2, 3, 4, 5, 1, 2, 3, 4, 5}
+	constant := []int{3, 3, 3, 3, 3, 3, 3, 3, 3, 3}
+	opposed := []int{5, 4, 3, 2, 1, 5, 4, 3, 2, 1}
+
+	tests := []struct {
+		name        string
+		members     []*item
+		wantAlpha   *float64
+		wantHarmful []int32
+	}{
+		{
+			name:        "identical items are perfectly reliable",
+			members:     []*item{likertItem(1, varied), likertItem(2, varied), likertItem(3, varied)},
+			wantAlpha:   statPtr(1),
+			wantHarmful: []int32{},
+		},
+		{
+			// Two identical items and one that never varies: the total
+			// variance is 4v against an item variance of 2v, so alpha is
+			// 3/2 * (1 - 2/4). Dropping the constant item lifts it to 1.
+			name:        "an item that never varies lowers alpha",
+			members:     []*item{likertItem(1, varied), likertItem(2, varied), likertItem(3, constant)},
+			wantAlpha:   statPtr(0.75),
+			wantHarmful: []int32{3},
+		},
+		{
+			name:        "opposed items leave alpha undefined",
+			members:     []*item{likertItem(1, varied), likertItem(2, opposed)},
+			wantAlpha:   nil,
+			wantHarmful: []int32{},
+		},
+		{
+			name:        "items sharing too few sessions leave alpha undefined",
+			members:     []*item{likertItem(1, varied[:3]), likertItem(2, varied[:3])},
+			wantAlpha:   nil,
+			wantHarmful: []int32{},
+		},
+		{
+			name:        "a single item has no alpha",
+			members:     []*item{likertItem(1, varied)},
+			wantAlpha:   nil,
+			wantHarmful: []int32{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stats := analyzeCategory(1, "Openness", tt.members)
+			switch {
+			case tt.wantAlpha == nil && stats.Alpha != nil:
+				t.Errorf("alpha = %v, want none", *stats.Alpha)
+			case tt.wantAlpha != nil && stats.Alpha == nil:
+				t.Errorf("alpha = none, want %v", *tt.wantAlpha)
+			case tt.wantAlpha != nil && *stats.Alpha != *tt.wantAlpha:
+				t.Errorf("alpha = %v, want %v", *stats.Alpha, *tt.wantAlpha)
+			}
+			if len(stats.AlphaIfDeleted) != len(tt.members) {
+				t.Errorf("alpha if deleted has %d entries, want %d", len(stats.AlphaIfDeleted), len(tt.members))
+			}
+			if len(stats.HarmfulItems) != len(tt.wantHarmful) {
+				t.Fatalf("harmful items = %v, want %v", stats.HarmfulItems, tt.wantHarmful)
+			}
+			for i := range tt.wantHarmful {
+				if stats.HarmfulItems[i] != tt.wantHarmful[i] {
+					t.Fatalf("harmful items = %v, want %v", stats.HarmfulItems, tt.wantHarmful)
+				}
+			}
+		})
+	}
+}
+
+func TestDiscriminationIndex(t *testing.T) {
+	// A cognitive item keyed on option 1: sessions with the highest rest
+	// scores answer it correctly and those with the lowest do not.
+	it := &item{
+		points:     map[int32]map[int32]float64{1: {1: 1}, 2: {}},
+		categories: []int32{1},
+		maxScore:   1,
+		answers:    make(map[int32]string),
+	}
+	var sessionIDs []int32
+	var restScores []float64
+	for session := int32(0); session < 10; session++ {
+		answer := "2"
+		if session >= 5 {
+			answer = "1"
+		}
+		it.answers[session] = answer
+		sessionIDs = append(sessionIDs, session)
+		restScores = append(restScores, float64(session))
+	}
+
+	got := discriminationIndex(it, sessionIDs, restScores)
+	if got == nil {
+		t.Fatal("discrimination index = none, want 1")
+	}
+	if *got != 1 {
+		t.Fatalf("discrimination index = %v, want 1", *got)
+	}
+}
@@ -0,0 +1,48 @@
+package item_analysis
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ItemAnalysisHandler struct {
+	queries *Queries
+}
+
+func NewItemAnalysisHandler(queries *Queries) *ItemAnalysisHandler {
+	return &ItemAnalysisHandler{
+		queries: queries,
+	}
+}
+
+func (h *ItemAnalysisHandler) GetItemAnalysis(c *gin.Context) {
+	assessmentType := c.DefaultQuery("assessment_type", "cognitive")
+	if assessmentType != "behavioral" && assessmentType != "personality" && assessmentType != "cognitive" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assessment type"})
+		return
+	}
+
+	items, err := h.queries.ListItems(c, QuestionType(assessmentType))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch items"})
+		return
+	}
+	mappings, err := h.queries.ListItemMappings(c, QuestionType(assessmentType))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch item mappings"})
+		return
+	}
+	responses, err := h.queries.ListItemResponses(c, assessmentType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch responses"})
+		return
+	}
+	scores, err := h.queries.ListSessionCategoryScores(c, assessmentType)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch scores"})
+		return
+	}
+
+	c.JSON(http.StatusOK, buildAnalysis(assessmentType, items, mappings, responses, scores))
+}
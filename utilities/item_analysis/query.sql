@@ -0,0 +1,38 @@
+-- name: ListItems :many
+-- Only single-choice items have an option to analyze
+SELECT id, question, options FROM self_assessment_questions
+WHERE type = $1 AND format = 'single_choice'
+ORDER BY id;
+
+-- name: ListItemMappings :many
+-- Points each option of an item earns per category
+SELECT
+  m.question_id,
+  m.answer_value,
+  m.category_id,
+  m.points,
+  c.name AS category_name
+FROM self_assessment_mappings m
+JOIN self_assessment_questions q ON q.id = m.question_id
+JOIN self_assessment_categories c ON c.id = m.category_id
+WHERE q.type = $1
+ORDER BY m.question_id, m.answer_value;
+
+-- name: ListItemResponses :many
+-- The option selected for every item in completed sessions of a type
+SELECT
+  ua.session_id,
+  ua.question_id,
+  COALESCE(ua.answer_value->>'selected', '')::text AS selected
+FROM user_answers ua
+JOIN user_assessment_sessions s ON s.id = ua.session_id
+WHERE s.assessment_type = $1 AND s.completed_at IS NOT NULL;
+
+-- name: ListSessionCategoryScores :many
+SELECT
+  sc.session_id,
+  sc.category_id,
+  sc.score
+FROM user_assessment_scores sc
+JOIN user_assessment_sessions s ON s.id = sc.session_id
+WHERE s.assessment_type = $1 AND s.completed_at IS NOT NULL;
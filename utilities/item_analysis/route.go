@@ -0,0 +1,13 @@
+package item_analysis
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesItemAnalysis(r *gin.Engine, itemAnalysisHandler *ItemAnalysisHandler) {
+	admin := r.Group("item-analysis")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.GET("", itemAnalysisHandler.GetItemAnalysis)
+}
@@ -0,0 +1,57 @@
+CREATE TABLE IF NOT EXISTS self_assessment_categories(
+    id SERIAL PRIMARY KEY,
+    name varchar(255),
+    description text
+);
+
+CREATE TYPE question_type as ENUM ('personality','cognitive','behavioral');
+
+CREATE TYPE question_format as ENUM ('single_choice','multi_select','ranking','numeric','free_text','open_ended','situational');
+
+CREATE TABLE IF NOT EXISTS self_assessment_questions (
+    id SERIAL PRIMARY KEY,
+    question text not null,
+    type question_type not null,
+    options JSONB NOT NULL DEFAULT '{}'::jsonb,
+    correct_answer VARCHAR(255) null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    format question_format NOT NULL DEFAULT 'single_choice'
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_mappings (
+    id SERIAL PRIMARY KEY,
+    question_id int not null,
+    answer_value int,
+    category_id int not null,
+    points int
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_sessions(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    started_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    completed_at timestamp null,
+    blueprint_id int null,
+    quality_flags JSONB NOT NULL DEFAULT '[]'::jsonb,
+    submitted_at timestamp null
+);
+
+CREATE TABLE IF NOT EXISTS user_answers(
+     id SERIAL PRIMARY KEY,
+     user_id int,
+     session_id int,
+     question_id int,
+     answer_value JSONB not null,
+     answered_at timestamp null
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_scores(
+    id SERIAL PRIMARY KEY,
+    user_id int,
+    session_id int,
+    category_id int,
+    score int,
+    theta double precision null,
+    theta_se double precision null
+);
@@ -0,0 +1,119 @@
+package job_profiles
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type JobProfileHandler struct {
+	db      *pgxpool.Pool
+	queries *Queries
+}
+
+func NewJobProfileHandler(db *pgxpool.Pool, queries *Queries) *JobProfileHandler {
+	return &JobProfileHandler{
+		db:      db,
+		queries: queries,
+	}
+}
+
+func (h *JobProfileHandler) CreateJobProfile(c *gin.Context) {
+	var req struct {
+		Title       string `json:"title" binding:"required"`
+		Description string `json:"description"`
+		Targets     []struct {
+			AssessmentType string `json:"assessment_type" binding:"required"`
+			CategoryID     int32  `json:"category_id" binding:"required"`
+			IdealScore     int32  `json:"ideal_score"`
+		} `json:"targets"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// A profile is saved with all of its targets or not at all.
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	profile, err := qtx.CreateJobProfile(c, CreateJobProfileParams{
+		Title: req.Title,
+		Description: pgtype.Text{
+			String: req.Description,
+			Valid:  req.Description != "",
+		},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create job profile"})
+		return
+	}
+
+	targets := []JobProfileTarget{}
+	for _, t := range req.Targets {
+		target, err := qtx.UpsertJobProfileTarget(c, UpsertJobProfileTargetParams{
+			JobProfileID:   profile.ID,
+			AssessmentType: t.AssessmentType,
+			CategoryID:     t.CategoryID,
+			IdealScore:     t.IdealScore,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save job profile target"})
+			return
+		}
+		targets = append(targets, target)
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create job profile"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"job_profile": profile,
+		"targets":     targets,
+	})
+}
+
+func (h *JobProfileHandler) ListJobProfiles(c *gin.Context) {
+	profiles, err := h.queries.ListJobProfiles(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch job profiles"})
+		return
+	}
+
+	c.JSON(http.StatusOK, profiles)
+}
+
+func (h *JobProfileHandler) GetJobProfile(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job profile ID"})
+		return
+	}
+
+	profile, err := h.queries.GetJobProfile(c, int32(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job profile not found"})
+		return
+	}
+
+	targets, err := h.queries.ListJobProfileTargets(c, profile.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch job profile targets"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"job_profile": profile,
+		"targets":     targets,
+	})
+}
@@ -0,0 +1,38 @@
+-- name: CreateJobProfile :one
+INSERT INTO job_profiles(
+    title,
+    description
+)VALUES(
+    $1,
+    $2
+) RETURNING *;
+
+-- name: GetJobProfile :one
+SELECT * FROM job_profiles
+WHERE id = $1 LIMIT 1;
+
+-- name: ListJobProfiles :many
+SELECT * FROM job_profiles
+ORDER BY id;
+
+-- name: UpsertJobProfileTarget :one
+-- Set the ideal score of a category for a job profile
+INSERT INTO job_profile_targets(
+    job_profile_id,
+    assessment_type,
+    category_id,
+    ideal_score
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4
+)
+ON CONFLICT (job_profile_id, assessment_type, category_id)
+DO UPDATE SET ideal_score = EXCLUDED.ideal_score
+RETURNING *;
+
+-- name: ListJobProfileTargets :many
+SELECT * FROM job_profile_targets
+WHERE job_profile_id = $1
+ORDER BY assessment_type, category_id;
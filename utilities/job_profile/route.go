@@ -0,0 +1,15 @@
+package job_profiles
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesJobProfile(r *gin.Engine, jobProfileHandler *JobProfileHandler) {
+	auth := r.Group("job-profiles")
+	auth.Use(middleware.AuthMiddleware())
+	auth.POST("", jobProfileHandler.CreateJobProfile)
+	auth.GET("", jobProfileHandler.ListJobProfiles)
+	auth.GET("/:id", jobProfileHandler.GetJobProfile)
+}
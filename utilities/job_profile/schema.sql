@@ -0,0 +1,16 @@
+CREATE TABLE IF NOT EXISTS job_profiles(
+    id SERIAL PRIMARY KEY,
+    title varchar(255) not null,
+    description text,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS job_profile_targets(
+    id SERIAL PRIMARY KEY,
+    job_profile_id int not null,
+    assessment_type varchar(50) not null,
+    category_id int not null,
+    ideal_score int not null,
+    constraint fk_job_profile_target foreign key (job_profile_id) REFERENCES job_profiles(id) on delete CASCADE,
+    constraint uq_job_profile_target unique (job_profile_id, assessment_type, category_id)
+);
@@ -0,0 +1,182 @@
+package jobs
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultJobList = 100
+	maxJobList     = 1000
+)
+
+var jobStatuses = []string{
+	string(BackgroundJobStatusQueued),
+	string(BackgroundJobStatusRunning),
+	string(BackgroundJobStatusSucceeded),
+	string(BackgroundJobStatusFailed),
+}
+
+type JobHandler struct {
+	queries *Queries
+}
+
+func NewJobHandler(queries *Queries) *JobHandler {
+	return &JobHandler{
+		queries: queries,
+	}
+}
+
+// Job is a background job as shown to admins.
+type Job struct {
+	ID          int64               `json:"id"`
+	Kind        string              `json:"kind"`
+	Payload     json.RawMessage     `json:"payload"`
+	Status      BackgroundJobStatus `json:"status"`
+	Attempts    int32               `json:"attempts"`
+	MaxAttempts int32               `json:"max_attempts"`
+	RunAt       time.Time           `json:"run_at"`
+	LastError   *string             `json:"last_error"`
+	CreatedAt   time.Time           `json:"created_at"`
+	StartedAt   *time.Time          `json:"started_at"`
+	FinishedAt  *time.Time          `json:"finished_at"`
+}
+
+func presentJob(j BackgroundJob) Job {
+	job := Job{
+		ID:          j.ID,
+		Kind:        j.Kind,
+		Payload:     j.Payload,
+		Status:      j.Status,
+		Attempts:    j.Attempts,
+		MaxAttempts: j.MaxAttempts,
+		RunAt:       j.RunAt.Time,
+		CreatedAt:   j.CreatedAt.Time,
+	}
+	if j.LastError.Valid {
+		job.LastError = &j.LastError.String
+	}
+	if j.StartedAt.Valid {
+		job.StartedAt = &j.StartedAt.Time
+	}
+	if j.FinishedAt.Valid {
+		job.FinishedAt = &j.FinishedAt.Time
+	}
+	return job
+}
+
+func (h *JobHandler) ListJobs(c *gin.Context) {
+	params := ListJobsParams{RowLimit: defaultJobList}
+	if s := c.Query("status"); s != "" {
+		if !slices.Contains(jobStatuses, s) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Status must be one of %s", strings.Join(jobStatuses, ", "))})
+			return
+		}
+		params.Status = NullBackgroundJobStatus{BackgroundJobStatus: BackgroundJobStatus(s), Valid: true}
+	}
+	if s := c.Query("kind"); s != "" {
+		params.Kind = pgtype.Text{String: s, Valid: true}
+	}
+	if s := c.Query("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > maxJobList {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Limit must be between 1 and %d", maxJobList)})
+			return
+		}
+		params.RowLimit = int32(limit)
+	}
+
+	jobs, err := h.queries.ListJobs(c, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve jobs"})
+		return
+	}
+	presented := make([]Job, 0, len(jobs))
+	for _, job := range jobs {
+		presented = append(presented, presentJob(job))
+	}
+	c.JSON(http.StatusOK, presented)
+}
+
+func (h *JobHandler) GetJobStats(c *gin.Context) {
+	counts, err := h.queries.CountJobs(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve job stats"})
+		return
+	}
+	// Every kind gets every status, so a status with no jobs reads as zero
+	// rather than being missing.
+	stats := map[string]map[BackgroundJobStatus]int64{}
+	for _, count := range counts {
+		if stats[count.Kind] == nil {
+			stats[count.Kind] = map[BackgroundJobStatus]int64{}
+			for _, status := range jobStatuses {
+				stats[count.Kind][BackgroundJobStatus(status)] = 0
+			}
+		}
+		stats[count.Kind][count.Status] = count.Count
+	}
+	c.JSON(http.StatusOK, stats)
+}
+
+func (h *JobHandler) GetJob(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.queries.GetJob(c, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load job"})
+		return
+	}
+	c.JSON(http.StatusOK, presentJob(job))
+}
+
+func (h *JobHandler) RetryJob(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid job ID"})
+		return
+	}
+
+	job, err := h.queries.RetryJob(c, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Job not found or not failed"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry job"})
+		return
+	}
+	c.JSON(http.StatusOK, presentJob(job))
+}
+
+func (h *JobHandler) RetryFailedJobs(c *gin.Context) {
+	var kind pgtype.Text
+	if s := c.Query("kind"); s != "" {
+		kind = pgtype.Text{String: s, Valid: true}
+	}
+
+	retried, err := h.queries.RetryFailedJobs(c, kind)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retry jobs"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"retried": retried})
+}
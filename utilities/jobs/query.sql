@@ -0,0 +1,87 @@
+-- name: EnqueueJob :one
+INSERT INTO background_jobs (kind, payload, run_at)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: ClaimJob :one
+-- Take the oldest due job of a kind this worker handles, including running
+-- jobs whose lease has lapsed. Jobs another worker has locked are skipped.
+WITH next AS (
+    SELECT id FROM background_jobs
+    WHERE kind = ANY(@kinds::text[])
+      AND ((status = 'queued' AND run_at <= CURRENT_TIMESTAMP)
+        OR (status = 'running' AND locked_until < CURRENT_TIMESTAMP))
+    ORDER BY run_at, id
+    LIMIT 1
+    FOR UPDATE SKIP LOCKED
+)
+UPDATE background_jobs
+SET status = 'running',
+    attempts = background_jobs.attempts + 1,
+    started_at = CURRENT_TIMESTAMP,
+    locked_until = CURRENT_TIMESTAMP + make_interval(secs => @lease_seconds::int)
+FROM next
+WHERE background_jobs.id = next.id
+RETURNING background_jobs.*;
+
+-- name: CompleteJob :exec
+-- The attempt number guards against a worker whose lease lapsed recording
+-- over the worker that took the job on after it.
+UPDATE background_jobs
+SET status = 'succeeded',
+    locked_until = NULL,
+    last_error = NULL,
+    finished_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND attempts = $2 AND status = 'running';
+
+-- name: FailJob :exec
+UPDATE background_jobs
+SET status = CASE WHEN @give_up::boolean THEN 'failed' ELSE 'queued' END::background_job_status,
+    run_at = CURRENT_TIMESTAMP + make_interval(secs => @retry_after_seconds::int),
+    locked_until = NULL,
+    last_error = @last_error,
+    finished_at = CASE WHEN @give_up::boolean THEN CURRENT_TIMESTAMP END
+WHERE id = @id AND attempts = @attempts AND status = 'running';
+
+-- name: GetJob :one
+SELECT * FROM background_jobs
+WHERE id = $1 LIMIT 1;
+
+-- name: ListJobs :many
+-- Jobs newest first, optionally narrowed to a status or kind
+SELECT * FROM background_jobs
+WHERE (sqlc.narg(status)::background_job_status IS NULL OR status = sqlc.narg(status)::background_job_status)
+  AND (sqlc.narg(kind)::text IS NULL OR kind = sqlc.narg(kind)::text)
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit;
+
+-- name: CountJobs :many
+SELECT kind, status, COUNT(*) AS count
+FROM background_jobs
+GROUP BY kind, status
+ORDER BY kind, status;
+
+-- name: RetryJob :one
+-- Give a failed job a fresh set of attempts
+UPDATE background_jobs
+SET status = 'queued',
+    attempts = 0,
+    run_at = CURRENT_TIMESTAMP,
+    finished_at = NULL
+WHERE id = $1 AND status = 'failed'
+RETURNING *;
+
+-- name: RetryFailedJobs :execrows
+UPDATE background_jobs
+SET status = 'queued',
+    attempts = 0,
+    run_at = CURRENT_TIMESTAMP,
+    finished_at = NULL
+WHERE status = 'failed'
+  AND (sqlc.narg(kind)::text IS NULL OR kind = sqlc.narg(kind)::text);
+
+-- name: DeleteFinishedJobs :execrows
+-- Failed jobs are kept until someone retries or looks into them
+DELETE FROM background_jobs
+WHERE status = 'succeeded'
+  AND finished_at < CURRENT_TIMESTAMP - make_interval(days => @days::int);
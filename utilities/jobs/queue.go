@@ -0,0 +1,268 @@
+package jobs
+
+import (
+	"backend/pkg/backoff"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultWorkers         = 4
+	defaultShutdownTimeout = 30 * time.Second
+	// pollInterval is how long an idle worker waits before looking for work
+	// again.
+	pollInterval = time.Second
+	// jobTimeout bounds a single attempt at a job.
+	jobTimeout = 10 * time.Minute
+	// leaseDuration is how long a claimed job is left alone before another
+	// worker assumes its worker died. It must outlast jobTimeout.
+	leaseDuration = jobTimeout + 5*time.Minute
+	// baseRetryDelay and maxRetryDelay bound the exponential backoff between
+	// attempts.
+	baseRetryDelay = 10 * time.Second
+	maxRetryDelay  = time.Hour
+	// retentionDays is how long succeeded jobs are kept before they are
+	// pruned.
+	retentionDays = 7
+	// pruneInterval is how often succeeded jobs are pruned.
+	pruneInterval = time.Hour
+	// maxErrorLength bounds how much of a failure is kept on the job.
+	maxErrorLength = 500
+)
+
+// Config sizes the worker pool.
+type Config struct {
+	// Workers is how many jobs this process runs at once.
+	Workers int
+	// ShutdownTimeout is how long running jobs get to finish once the queue
+	// is stopped before they are cancelled.
+	ShutdownTimeout time.Duration
+}
+
+// handlerFunc runs one job from its raw payload.
+type handlerFunc func(ctx context.Context, payload []byte) error
+
+// Queue stores jobs in Postgres and runs them on a pool of workers. Jobs are
+// claimed with FOR UPDATE SKIP LOCKED, so several servers can share a queue.
+// Packages whose jobs must only run if their own changes commit insert into
+// background_jobs in the same transaction instead of calling Enqueue.
+type Queue struct {
+	queries  *Queries
+	cfg      Config
+	handlers map[string]handlerFunc
+}
+
+func NewQueue(queries *Queries, cfg Config) *Queue {
+	if cfg.Workers <= 0 {
+		cfg.Workers = defaultWorkers
+	}
+	if cfg.ShutdownTimeout <= 0 {
+		cfg.ShutdownTimeout = defaultShutdownTimeout
+	}
+	return &Queue{
+		queries:  queries,
+		cfg:      cfg,
+		handlers: map[string]handlerFunc{},
+	}
+}
+
+// Register sets the handler for a kind of job. The payload a job was
+// enqueued with is decoded into T before the handler is called. Handlers
+// must be registered before Run, and a job may run more than once if its
+// worker dies, so they should be safe to repeat.
+func Register[T any](q *Queue, kind string, handle func(ctx context.Context, payload T) error) {
+	if _, ok := q.handlers[kind]; ok {
+		panic(fmt.Sprintf("jobs: handler for %q registered twice", kind))
+	}
+	q.handlers[kind] = func(ctx context.Context, raw []byte) error {
+		var payload T
+		if err := json.Unmarshal(raw, &payload); err != nil {
+			return Permanent(fmt.Errorf("decoding payload: %w", err))
+		}
+		return handle(ctx, payload)
+	}
+}
+
+// permanentError marks a failure that retrying cannot fix.
+type permanentError struct {
+	err error
+}
+
+func (e permanentError) Error() string { return e.err.Error() }
+func (e permanentError) Unwrap() error { return e.err }
+
+// Permanent wraps an error so the job fails straight away instead of being
+// retried.
+func Permanent(err error) error {
+	return permanentError{err: err}
+}
+
+// Enqueue adds a job to run as soon as a worker is free.
+func (q *Queue) Enqueue(ctx context.Context, kind string, payload any) (int64, error) {
+	return q.Schedule(ctx, kind, payload, time.Now())
+}
+
+// Schedule adds a job that is not run before runAt.
+func (q *Queue) Schedule(ctx context.Context, kind string, payload any, runAt time.Time) (int64, error) {
+	if _, ok := q.handlers[kind]; !ok {
+		return 0, fmt.Errorf("jobs: no handler registered for %q", kind)
+	}
+	raw, err := json.Marshal(payload)
+	if err != nil {
+		return 0, fmt.Errorf("jobs: encoding %s payload: %w", kind, err)
+	}
+	job, err := q.queries.EnqueueJob(ctx, EnqueueJobParams{
+		Kind:    kind,
+		Payload: raw,
+		RunAt:   pgtype.Timestamp{Time: runAt, Valid: true},
+	})
+	if err != nil {
+		return 0, err
+	}
+	return job.ID, nil
+}
+
+// Run works through jobs until the context is cancelled. It then stops
+// taking new jobs and waits for running ones to finish, cancelling them if
+// they outlast the shutdown timeout, and returns once they have.
+func (q *Queue) Run(ctx context.Context) {
+	// Jobs get a context of their own so that stopping the queue lets them
+	// finish rather than cutting them off.
+	jobCtx, cancelJobs := context.WithCancel(context.WithoutCancel(ctx))
+	defer cancelJobs()
+
+	var wg sync.WaitGroup
+	for i := 0; i < q.cfg.Workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			q.work(ctx, jobCtx)
+		}()
+	}
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		q.prune(ctx)
+	}()
+
+	<-ctx.Done()
+	drained := make(chan struct{})
+	go func() {
+		wg.Wait()
+		close(drained)
+	}()
+	select {
+	case <-drained:
+	case <-time.After(q.cfg.ShutdownTimeout):
+		log.Printf("jobs: shutdown timeout reached, cancelling running jobs")
+		cancelJobs()
+		<-drained
+	}
+}
+
+// work is one worker: it claims and runs jobs one at a time until ctx is
+// cancelled.
+func (q *Queue) work(ctx, jobCtx context.Context) {
+	kinds := make([]string, 0, len(q.handlers))
+	for kind := range q.handlers {
+		kinds = append(kinds, kind)
+	}
+	for ctx.Err() == nil {
+		job, err := q.queries.ClaimJob(ctx, ClaimJobParams{
+			Kinds:        kinds,
+			LeaseSeconds: int32(leaseDuration.Seconds()),
+		})
+		if err != nil {
+			if !errors.Is(err, pgx.ErrNoRows) && ctx.Err() == nil {
+				log.Printf("jobs: claiming: %v", err)
+			}
+			select {
+			case <-ctx.Done():
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+		if err := q.process(jobCtx, job); err != nil {
+			log.Printf("jobs: recording job %d: %v", job.ID, err)
+		}
+	}
+}
+
+// process runs one attempt at a job and records how it went.
+func (q *Queue) process(ctx context.Context, job BackgroundJob) error {
+	var runErr error
+	if job.Attempts > job.MaxAttempts {
+		// The lease lapsed on the final attempt, most likely because the job
+		// took its worker down with it.
+		runErr = Permanent(errors.New("worker was lost during the final attempt"))
+	} else {
+		runErr = q.run(ctx, job)
+	}
+
+	// The outcome is recorded even if the job was cancelled, so it is not
+	// left waiting out its lease.
+	recordCtx := context.WithoutCancel(ctx)
+	if runErr == nil {
+		return q.queries.CompleteJob(recordCtx, CompleteJobParams{
+			ID:       job.ID,
+			Attempts: job.Attempts,
+		})
+	}
+
+	log.Printf("jobs: %s job %d attempt %d: %v", job.Kind, job.ID, job.Attempts, runErr)
+	msg := runErr.Error()
+	if len(msg) > maxErrorLength {
+		msg = msg[:maxErrorLength]
+	}
+	var permanent permanentError
+	return q.queries.FailJob(recordCtx, FailJobParams{
+		GiveUp:            errors.As(runErr, &permanent) || job.Attempts >= job.MaxAttempts,
+		RetryAfterSeconds: int32(backoff.Exponential(int(job.Attempts), baseRetryDelay, maxRetryDelay).Seconds()),
+		LastError:         pgtype.Text{String: msg, Valid: true},
+		ID:                job.ID,
+		Attempts:          job.Attempts,
+	})
+}
+
+// run calls the job's handler, turning a panic into an error so one bad job
+// cannot take the worker down.
+func (q *Queue) run(ctx context.Context, job BackgroundJob) (err error) {
+	handle, ok := q.handlers[job.Kind]
+	if !ok {
+		return Permanent(fmt.Errorf("no handler registered for %q", job.Kind))
+	}
+	ctx, cancel := context.WithTimeout(ctx, jobTimeout)
+	defer cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("jobs: %s job %d panicked: %v\n%s", job.Kind, job.ID, r, debug.Stack())
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return handle(ctx, job.Payload)
+}
+
+// prune deletes old succeeded jobs until ctx is cancelled.
+func (q *Queue) prune(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := q.queries.DeleteFinishedJobs(ctx, retentionDays); err != nil && ctx.Err() == nil {
+			log.Printf("jobs: pruning: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,17 @@
+package jobs
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesJob(r *gin.Engine, jobHandler *JobHandler) {
+	admin := r.Group("jobs")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.GET("", jobHandler.ListJobs)
+	admin.GET("/stats", jobHandler.GetJobStats)
+	admin.GET("/:id", jobHandler.GetJob)
+	admin.POST("/:id/retry", jobHandler.RetryJob)
+	admin.POST("/retry", jobHandler.RetryFailedJobs)
+}
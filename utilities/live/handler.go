@@ -0,0 +1,121 @@
+package live
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	// heartbeatInterval keeps idle streams from being closed by proxies and
+	// lets clients notice a dead connection.
+	heartbeatInterval = 15 * time.Second
+	// reconnectAfter is how long browsers wait before reconnecting a dropped
+	// stream, in milliseconds.
+	reconnectAfter = 3000
+	// maxBacklog bounds how many missed events a resuming client is sent.
+	maxBacklog = 1000
+)
+
+type LiveHandler struct {
+	hub     *Hub
+	queries *Queries
+}
+
+func NewLiveHandler(hub *Hub, queries *Queries) *LiveHandler {
+	return &LiveHandler{
+		hub:     hub,
+		queries: queries,
+	}
+}
+
+// writeEvent writes one event in the text/event-stream format. Payloads come
+// from JSONB, which never renders with line breaks, so they fit on a single
+// data line.
+func writeEvent(w io.Writer, event LiveEvent) error {
+	_, err := fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, event.EventType, event.Payload)
+	return err
+}
+
+func (h *LiveHandler) StreamEvents(c *gin.Context) {
+	// Browsers send Last-Event-ID when they reconnect; clients that cannot
+	// set headers may pass it as a query parameter instead.
+	lastEventID := c.GetHeader("Last-Event-ID")
+	if lastEventID == "" {
+		lastEventID = c.Query("last_event_id")
+	}
+	var lastID int64
+	if lastEventID != "" {
+		id, err := strconv.ParseInt(lastEventID, 10, 64)
+		if err != nil || id < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid Last-Event-ID"})
+			return
+		}
+		lastID = id
+	}
+
+	// Subscribe before reading the backlog so nothing recorded in between is
+	// lost; anything seen twice is skipped by ID.
+	live, unsubscribe := h.hub.subscribe()
+	defer unsubscribe()
+
+	var backlog []LiveEvent
+	if lastEventID != "" {
+		var err error
+		backlog, err = h.queries.ListLiveEventsAfter(c, ListLiveEventsAfterParams{
+			ID:    lastID,
+			Limit: maxBacklog,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load missed events"})
+			return
+		}
+	}
+
+	c.Header("Content-Type", "text/event-stream")
+	c.Header("Cache-Control", "no-cache")
+	c.Header("Connection", "keep-alive")
+	c.Header("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+
+	w := c.Writer
+	if _, err := fmt.Fprintf(w, "retry: %d\n\n", reconnectAfter); err != nil {
+		return
+	}
+	for _, event := range backlog {
+		if err := writeEvent(w, event); err != nil {
+			return
+		}
+		lastID = event.ID
+	}
+	w.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+	for {
+		select {
+		case <-c.Request.Context().Done():
+			return
+		case event, ok := <-live:
+			if !ok {
+				return
+			}
+			if event.ID <= lastID {
+				continue
+			}
+			if err := writeEvent(w, event); err != nil {
+				return
+			}
+			lastID = event.ID
+		case <-heartbeat.C:
+			if _, err := io.WriteString(w, ": heartbeat\n\n"); err != nil {
+				return
+			}
+		}
+		w.Flush()
+	}
+}
@@ -0,0 +1,237 @@
+package live
+
+import (
+	"context"
+	"log"
+	"slices"
+	"sync"
+	"time"
+
+	"backend/pkg/events"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+const (
+	// channel is the Postgres notification channel new events are
+	// announced on. It must match the one in RecordLiveEvent.
+	channel = "live_events"
+	// reconnectDelay is how long to wait before listening again after the
+	// connection is lost.
+	reconnectDelay = 5 * time.Second
+	// fetchLimit bounds how many events are read per query.
+	fetchLimit = 500
+	// subscriberBuffer is how many events a client may fall behind by
+	// before it is dropped and has to reconnect.
+	subscriberBuffer = 64
+	// retentionHours is how long events are kept for clients to resume from.
+	retentionHours = 24
+	// pruneInterval is how often old events are pruned.
+	pruneInterval = time.Hour
+)
+
+// streamedTypes are the events shown live on the staff dashboard.
+var streamedTypes = []string{events.SessionStarted, events.AssessmentCompleted, events.ScoresCalculated}
+
+// Hub records dashboard events as they leave the outbox and fans them out
+// to the clients connected to this server. Servers learn of new events
+// through Postgres LISTEN/NOTIFY, so a client sees every event whichever
+// server it is connected to.
+type Hub struct {
+	db      *pgxpool.Pool
+	queries *Queries
+
+	mu          sync.Mutex
+	subscribers map[chan LiveEvent]struct{}
+	stopped     bool
+}
+
+func NewHub(db *pgxpool.Pool, queries *Queries) *Hub {
+	return &Hub{
+		db:          db,
+		queries:     queries,
+		subscribers: map[chan LiveEvent]struct{}{},
+	}
+}
+
+// Consume records an outbox event for the dashboard and notifies every
+// server. Events the dashboard does not show are ignored.
+func (h *Hub) Consume(ctx context.Context, event events.Event) error {
+	if !slices.Contains(streamedTypes, event.Type) {
+		return nil
+	}
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := h.queries.WithTx(tx)
+
+	if err := qtx.LockLiveEvents(ctx); err != nil {
+		return err
+	}
+	err = qtx.RecordLiveEvent(ctx, RecordLiveEventParams{
+		OutboxEventID: event.ID,
+		EventType:     event.Type,
+		Payload:       event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// Run listens for new events and passes them to subscribers until the
+// context is cancelled, then disconnects every subscriber.
+func (h *Hub) Run(ctx context.Context) {
+	defer h.closeAll()
+	go h.prune(ctx)
+
+	// Only events recorded from now on are broadcast; clients catch up on
+	// earlier ones themselves.
+	var last int64
+	for {
+		latest, err := h.queries.GetLatestLiveEventID(ctx)
+		if err == nil {
+			last = latest
+			break
+		}
+		log.Printf("live: loading latest event: %v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+
+	for {
+		err := h.listen(ctx, &last)
+		if ctx.Err() != nil {
+			return
+		}
+		log.Printf("live: listening: %v", err)
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(reconnectDelay):
+		}
+	}
+}
+
+// listen holds a connection listening for notifications and broadcasts
+// every event after last as they arrive. It returns when the connection
+// fails or ctx is cancelled.
+func (h *Hub) listen(ctx context.Context, last *int64) error {
+	pooled, err := h.db.Acquire(ctx)
+	if err != nil {
+		return err
+	}
+	// The connection is taken out of the pool so the LISTEN does not
+	// follow it back in.
+	conn := pooled.Hijack()
+	defer conn.Close(context.Background())
+
+	if _, err := conn.Exec(ctx, "LISTEN "+channel); err != nil {
+		return err
+	}
+	// Pick up anything recorded while no connection was listening.
+	if err := h.broadcastAfter(ctx, last); err != nil {
+		return err
+	}
+	for {
+		// The notification only says something new is there; reading by
+		// ID also catches events whose notifications were coalesced.
+		if _, err := conn.WaitForNotification(ctx); err != nil {
+			return err
+		}
+		if err := h.broadcastAfter(ctx, last); err != nil {
+			return err
+		}
+	}
+}
+
+// broadcastAfter sends every event after last to the subscribers and moves
+// last along.
+func (h *Hub) broadcastAfter(ctx context.Context, last *int64) error {
+	for {
+		rows, err := h.queries.ListLiveEventsAfter(ctx, ListLiveEventsAfterParams{
+			ID:    *last,
+			Limit: fetchLimit,
+		})
+		if err != nil {
+			return err
+		}
+		for _, row := range rows {
+			h.broadcast(row)
+			*last = row.ID
+		}
+		if len(rows) < fetchLimit {
+			return nil
+		}
+	}
+}
+
+// subscribe registers a client. The channel is closed if the client falls
+// too far behind or the hub stops; the returned function unsubscribes.
+func (h *Hub) subscribe() (<-chan LiveEvent, func()) {
+	ch := make(chan LiveEvent, subscriberBuffer)
+	h.mu.Lock()
+	if h.stopped {
+		close(ch)
+	} else {
+		h.subscribers[ch] = struct{}{}
+	}
+	h.mu.Unlock()
+	return ch, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		if _, ok := h.subscribers[ch]; ok {
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// broadcast passes an event to every subscriber without waiting on any of
+// them. A subscriber whose buffer is full is dropped; its client reconnects
+// and resumes from the last event it received.
+func (h *Hub) broadcast(event LiveEvent) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subscribers {
+		select {
+		case ch <- event:
+		default:
+			delete(h.subscribers, ch)
+			close(ch)
+		}
+	}
+}
+
+// closeAll disconnects every subscriber and turns away new ones, so open
+// streams do not hold up the server shutting down.
+func (h *Hub) closeAll() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.stopped = true
+	for ch := range h.subscribers {
+		delete(h.subscribers, ch)
+		close(ch)
+	}
+}
+
+// prune deletes events too old to resume from until ctx is cancelled.
+func (h *Hub) prune(ctx context.Context) {
+	ticker := time.NewTicker(pruneInterval)
+	defer ticker.Stop()
+	for {
+		if _, err := h.queries.DeleteOldLiveEvents(ctx, retentionHours); err != nil && ctx.Err() == nil {
+			log.Printf("live: pruning: %v", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
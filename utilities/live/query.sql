@@ -0,0 +1,27 @@
+-- name: LockLiveEvents :exec
+-- Serialize recording so IDs are committed in order and a client resuming
+-- after an ID cannot miss one committed late
+SELECT pg_advisory_xact_lock(hashtext('live_events'));
+
+-- name: RecordLiveEvent :exec
+-- Record an event once and wake every listening server
+WITH recorded AS (
+    INSERT INTO live_events (outbox_event_id, event_type, payload)
+    VALUES ($1, $2, $3)
+    ON CONFLICT (outbox_event_id) DO NOTHING
+    RETURNING id
+)
+SELECT pg_notify('live_events', id::text) FROM recorded;
+
+-- name: ListLiveEventsAfter :many
+SELECT * FROM live_events
+WHERE id > $1
+ORDER BY id
+LIMIT $2;
+
+-- name: GetLatestLiveEventID :one
+SELECT COALESCE(MAX(id), 0)::bigint AS id FROM live_events;
+
+-- name: DeleteOldLiveEvents :execrows
+DELETE FROM live_events
+WHERE created_at < CURRENT_TIMESTAMP - make_interval(hours => @hours::int);
@@ -0,0 +1,13 @@
+package live
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesLive(r *gin.Engine, liveHandler *LiveHandler) {
+	admin := r.Group("live")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.GET("/events", liveHandler.StreamEvents)
+}
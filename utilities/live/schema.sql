@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS live_events(
+    id BIGSERIAL PRIMARY KEY,
+    outbox_event_id bigint not null UNIQUE,
+    event_type varchar(50) not null,
+    payload JSONB not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_live_events_created_at ON live_events(created_at);
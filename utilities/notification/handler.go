@@ -0,0 +1,123 @@
+package notification
+
+import (
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	defaultLogLimit = 100
+	maxLogLimit     = 1000
+)
+
+var notificationKinds = []string{
+	string(NotificationKindInvitation),
+	string(NotificationKindExpiryReminder),
+	string(NotificationKindIncompleteNudge),
+	string(NotificationKindCompletionConfirmation),
+	string(NotificationKindStaffDigest),
+}
+
+type NotificationHandler struct {
+	queries *Queries
+}
+
+func NewNotificationHandler(queries *Queries) *NotificationHandler {
+	return &NotificationHandler{
+		queries: queries,
+	}
+}
+
+func (h *NotificationHandler) GetPreferences(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	optedOut, err := h.queries.GetEmailOptOut(c, int32(userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve preferences"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"email_opt_out": optedOut})
+}
+
+func (h *NotificationHandler) SetPreferences(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req struct {
+		EmailOptOut *bool `json:"email_opt_out" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	err = h.queries.SetEmailOptOut(c, SetEmailOptOutParams{
+		UserID:      int32(userID),
+		EmailOptOut: *req.EmailOptOut,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save preferences"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"email_opt_out": *req.EmailOptOut})
+}
+
+func (h *NotificationHandler) ListNotificationLog(c *gin.Context) {
+	params := ListNotificationLogParams{RowLimit: defaultLogLimit}
+	if s := c.Query("user_id"); s != "" {
+		userID, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		params.UserID = pgtype.Int4{Int32: int32(userID), Valid: true}
+	}
+	if s := c.Query("kind"); s != "" {
+		if !slices.Contains(notificationKinds, s) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Kind must be one of %s", strings.Join(notificationKinds, ", "))})
+			return
+		}
+		params.Kind = NullNotificationKind{NotificationKind: NotificationKind(s), Valid: true}
+	}
+	if s := c.Query("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > maxLogLimit {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Limit must be between 1 and %d", maxLogLimit)})
+			return
+		}
+		params.RowLimit = int32(limit)
+	}
+
+	entries, err := h.queries.ListNotificationLog(c, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve notification log"})
+		return
+	}
+	if entries == nil {
+		entries = []NotificationLog{}
+	}
+	c.JSON(http.StatusOK, entries)
+}
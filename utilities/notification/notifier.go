@@ -0,0 +1,257 @@
+package notification
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"strings"
+	"time"
+
+	"backend/pkg/mailer"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// sweepInterval is how often the scheduled rules are checked.
+	sweepInterval = time.Minute
+	// maxAttempts bounds how often a failed message is retried.
+	maxAttempts = 3
+	// sendTimeout bounds a single delivery to the mail server.
+	sendTimeout = 30 * time.Second
+	// claimLease is how long a claimed message may stay pending before it
+	// is assumed lost and claimed again. It must outlast sendTimeout.
+	claimLease = 5 * time.Minute
+
+	defaultReminderDays    = 1
+	defaultNudgeAfterHours = 24
+)
+
+// Config controls when the scheduled messages go out.
+type Config struct {
+	// BaseURL is where the front end is served, for links in messages.
+	BaseURL string
+	// ReminderDays is how long before an invitation expires the candidate
+	// is reminded of it.
+	ReminderDays int
+	// NudgeAfterHours is how long a session can sit unfinished before the
+	// candidate is nudged.
+	NudgeAfterHours int
+}
+
+// Notifier sends the automated emails and records every one of them.
+type Notifier struct {
+	queries *Queries
+	mailer  mailer.Mailer
+	cfg     Config
+}
+
+func NewNotifier(queries *Queries, m mailer.Mailer, cfg Config) *Notifier {
+	if cfg.ReminderDays <= 0 {
+		cfg.ReminderDays = defaultReminderDays
+	}
+	if cfg.NudgeAfterHours <= 0 {
+		cfg.NudgeAfterHours = defaultNudgeAfterHours
+	}
+	cfg.BaseURL = strings.TrimRight(cfg.BaseURL, "/")
+	return &Notifier{
+		queries: queries,
+		mailer:  m,
+		cfg:     cfg,
+	}
+}
+
+// Run checks the scheduled rules until the context is cancelled. Every
+// message has a dedup key, so several servers can run it side by side.
+func (n *Notifier) Run(ctx context.Context) {
+	ticker := time.NewTicker(sweepInterval)
+	defer ticker.Stop()
+	for {
+		n.sweep(ctx)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func (n *Notifier) sweep(ctx context.Context) {
+	rules := []struct {
+		name string
+		run  func(context.Context) error
+	}{
+		{"expiry reminders", n.sendExpiryReminders},
+		{"incomplete session nudges", n.sendIncompleteNudges},
+		{"completion confirmations", n.sendCompletionConfirmations},
+		{"staff digests", n.sendStaffDigests},
+	}
+	for _, rule := range rules {
+		if err := rule.run(ctx); err != nil {
+			log.Printf("notification: %s: %v", rule.name, err)
+		}
+	}
+}
+
+// SendInvitation emails a candidate the link to their invitation. Only the
+// token's hash is stored, so callers issue a fresh token for each attempt
+// and the link in the latest email is the one that works.
+func (n *Notifier) SendInvitation(ctx context.Context, invitationID int32, userID pgtype.Int4, email, name, token string, expiresAt time.Time) error {
+	return n.deliver(ctx, NotificationKindInvitation, invitationDedupKey(invitationID), userID, email, templateData{
+		Name:      name,
+		Link:      n.cfg.BaseURL + "/invite/" + token,
+		ExpiresAt: expiresAt,
+	})
+}
+
+// InvitationSent reports whether an invitation's email has gone out, so a
+// retried send does not replace the link the candidate already has.
+func (n *Notifier) InvitationSent(ctx context.Context, invitationID int32) (bool, error) {
+	return n.queries.IsNotificationSent(ctx, invitationDedupKey(invitationID))
+}
+
+func invitationDedupKey(invitationID int32) string {
+	return fmt.Sprintf("invitation:%d", invitationID)
+}
+
+func (n *Notifier) sendExpiryReminders(ctx context.Context) error {
+	invitations, err := n.queries.ListDueExpiryReminders(ctx, int32(n.cfg.ReminderDays))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, invitation := range invitations {
+		errs = append(errs, n.deliver(ctx, NotificationKindExpiryReminder, fmt.Sprintf("expiry_reminder:%d", invitation.ID), invitation.UserID, invitation.Email, templateData{
+			Name:      invitation.Name,
+			ExpiresAt: invitation.ExpiresAt.Time,
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) sendIncompleteNudges(ctx context.Context) error {
+	sessions, err := n.queries.ListIncompleteSessions(ctx, int32(n.cfg.NudgeAfterHours))
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, session := range sessions {
+		errs = append(errs, n.deliver(ctx, NotificationKindIncompleteNudge, fmt.Sprintf("incomplete_nudge:%d", session.ID), pgtype.Int4{Int32: session.UserID, Valid: true}, session.Email, templateData{
+			Name:           session.Name,
+			Link:           n.cfg.BaseURL + "/dashboard",
+			AssessmentType: session.AssessmentType,
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+func (n *Notifier) sendCompletionConfirmations(ctx context.Context) error {
+	sessions, err := n.queries.ListUnconfirmedCompletions(ctx)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, session := range sessions {
+		errs = append(errs, n.deliver(ctx, NotificationKindCompletionConfirmation, fmt.Sprintf("completion_confirmation:%d", session.ID), pgtype.Int4{Int32: session.UserID, Valid: true}, session.Email, templateData{
+			Name:           session.Name,
+			AssessmentType: session.AssessmentType,
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+// sendStaffDigests sends each staff member at most one digest a day, listing
+// the candidates who completed assessments since their last one.
+func (n *Notifier) sendStaffDigests(ctx context.Context) error {
+	staff, err := n.queries.ListStaff(ctx)
+	if err != nil {
+		return err
+	}
+	now := time.Now().UTC()
+	var errs []error
+	for _, member := range staff {
+		userID := pgtype.Int4{Int32: member.ID, Valid: true}
+		since, err := n.queries.GetLastDigestSentAt(ctx, userID)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if !since.Valid {
+			since = pgtype.Timestamp{Time: now.Add(-24 * time.Hour), Valid: true}
+		}
+		completions, err := n.queries.ListCompletionsSince(ctx, since)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if len(completions) == 0 {
+			continue
+		}
+		errs = append(errs, n.deliver(ctx, NotificationKindStaffDigest, fmt.Sprintf("staff_digest:%d:%s", member.ID, now.Format(time.DateOnly)), userID, member.Email, templateData{
+			Name:        member.Name,
+			Link:        n.cfg.BaseURL + "/dashboard",
+			Completions: completions,
+		}))
+	}
+	return errors.Join(errs...)
+}
+
+// deliver sends one message unless its dedup key shows it has been handled
+// already. The log records the outcome but not the body, which can carry
+// invitation links.
+func (n *Notifier) deliver(ctx context.Context, kind NotificationKind, dedupKey string, userID pgtype.Int4, email string, data templateData) error {
+	subject, body, err := render(kind, data)
+	if err != nil {
+		return err
+	}
+
+	id, err := n.queries.ClaimNotification(ctx, ClaimNotificationParams{
+		Kind:         kind,
+		DedupKey:     dedupKey,
+		UserID:       userID,
+		Email:        email,
+		Subject:      subject,
+		MaxAttempts:  maxAttempts,
+		LeaseSeconds: int32(claimLease.Seconds()),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if userID.Valid {
+		optedOut, err := n.queries.GetEmailOptOut(ctx, userID.Int32)
+		if err != nil {
+			return n.finish(ctx, id, err)
+		}
+		if optedOut {
+			return n.queries.FinishNotification(ctx, FinishNotificationParams{
+				Status: NotificationStatusSkipped,
+				Error:  pgtype.Text{String: "recipient opted out", Valid: true},
+				ID:     id,
+			})
+		}
+	}
+
+	sendCtx, cancel := context.WithTimeout(ctx, sendTimeout)
+	defer cancel()
+	err = n.mailer.Send(sendCtx, mailer.Message{To: email, Subject: subject, Body: body})
+	return n.finish(ctx, id, err)
+}
+
+// finish records how a claimed message went, passing on the send error.
+func (n *Notifier) finish(ctx context.Context, id int32, sendErr error) error {
+	params := FinishNotificationParams{Status: NotificationStatusSent, ID: id}
+	if sendErr != nil {
+		params.Status = NotificationStatusFailed
+		params.Error = pgtype.Text{String: sendErr.Error(), Valid: true}
+	}
+	if err := n.queries.FinishNotification(ctx, params); err != nil {
+		return errors.Join(sendErr, err)
+	}
+	return sendErr
+}
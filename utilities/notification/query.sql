@@ -0,0 +1,117 @@
+-- name: ClaimNotification :one
+-- Record a message about to be sent. Nothing is returned when it has been
+-- sent already, is being sent elsewhere, or has failed too often. A message
+-- left pending past the lease was lost with its sender and is taken over.
+INSERT INTO notification_log (kind, dedup_key, user_id, email, subject)
+VALUES (@kind, @dedup_key, @user_id, @email, @subject)
+ON CONFLICT (dedup_key) DO UPDATE
+SET status = 'pending',
+    attempts = notification_log.attempts + 1,
+    error = NULL,
+    subject = EXCLUDED.subject,
+    claimed_at = CURRENT_TIMESTAMP
+WHERE notification_log.attempts < @max_attempts::int
+  AND (notification_log.status = 'failed'
+    OR (notification_log.status = 'pending'
+      AND notification_log.claimed_at < CURRENT_TIMESTAMP - make_interval(secs => @lease_seconds::int)))
+RETURNING id;
+
+-- name: FinishNotification :exec
+UPDATE notification_log
+SET status = @status,
+    error = @error,
+    sent_at = CASE WHEN @status = 'sent' THEN CURRENT_TIMESTAMP ELSE NULL END
+WHERE id = @id;
+
+-- name: GetEmailOptOut :one
+SELECT COALESCE((
+  SELECT email_opt_out FROM user_preferences WHERE user_id = $1
+), false)::boolean AS email_opt_out;
+
+-- name: SetEmailOptOut :exec
+INSERT INTO user_preferences (user_id, email_opt_out)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET email_opt_out = EXCLUDED.email_opt_out;
+
+-- name: ListDueExpiryReminders :many
+-- Unaccepted invitations expiring within the reminder window, skipping those
+-- sent so close to expiry that the invitation itself is the reminder
+SELECT i.id, i.email, i.name, i.user_id, i.expires_at
+FROM invitations i
+WHERE i.accepted_at IS NULL
+  AND i.expires_at > CURRENT_TIMESTAMP
+  AND i.expires_at <= CURRENT_TIMESTAMP + make_interval(days => @days::int)
+  AND i.expires_at - i.created_at > make_interval(days => @days::int)
+  AND NOT EXISTS (
+    SELECT 1 FROM notification_log n
+    WHERE n.dedup_key = 'expiry_reminder:' || i.id AND n.status <> 'failed'
+  )
+ORDER BY i.id;
+
+-- name: ListIncompleteSessions :many
+-- Sessions left unfinished for a while, unless the candidate has since
+-- completed the same assessment. Sessions older than a week are left alone.
+SELECT s.id, s.assessment_type, s.started_at, u.id AS user_id, u.name, u.email
+FROM user_assessment_sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.completed_at IS NULL
+  AND s.submitted_at IS NULL
+  AND s.started_at <= CURRENT_TIMESTAMP - make_interval(hours => @hours::int)
+  AND s.started_at > CURRENT_TIMESTAMP - INTERVAL '7 days'
+  AND NOT EXISTS (
+    SELECT 1 FROM user_assessment_sessions later
+    WHERE later.user_id = s.user_id
+      AND later.assessment_type = s.assessment_type
+      AND later.completed_at IS NOT NULL
+      AND later.started_at > s.started_at
+  )
+  AND NOT EXISTS (
+    SELECT 1 FROM notification_log n
+    WHERE n.dedup_key = 'incomplete_nudge:' || s.id AND n.status <> 'failed'
+  )
+ORDER BY s.id;
+
+-- name: ListUnconfirmedCompletions :many
+-- Sessions completed in the last day that have not been confirmed yet
+SELECT s.id, s.assessment_type, s.completed_at, u.id AS user_id, u.name, u.email
+FROM user_assessment_sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.completed_at > CURRENT_TIMESTAMP - INTERVAL '1 day'
+  AND NOT EXISTS (
+    SELECT 1 FROM notification_log n
+    WHERE n.dedup_key = 'completion_confirmation:' || s.id AND n.status <> 'failed'
+  )
+ORDER BY s.id;
+
+-- name: ListStaff :many
+SELECT u.id, u.name, u.email FROM users u
+WHERE u.role_id = (SELECT id FROM roles WHERE name = 'admin')
+ORDER BY u.id;
+
+-- name: GetLastDigestSentAt :one
+SELECT MAX(sent_at)::timestamp AS sent_at FROM notification_log
+WHERE kind = 'staff_digest' AND user_id = $1 AND status = 'sent';
+
+-- name: IsNotificationSent :one
+SELECT EXISTS (
+  SELECT 1 FROM notification_log
+  WHERE dedup_key = $1 AND status = 'sent'
+) AS sent;
+
+-- name: ListCompletionsSince :many
+-- Candidates who completed an assessment since the given time
+SELECT u.id AS user_id, u.name, u.email, s.assessment_type, s.completed_at
+FROM user_assessment_sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.completed_at > @since
+ORDER BY s.completed_at, s.id;
+
+-- name: ListNotificationLog :many
+-- Messages newest first, optionally narrowed to a recipient or kind
+SELECT id, kind, dedup_key, user_id, email, subject, status, attempts, error, created_at, sent_at, claimed_at
+FROM notification_log
+WHERE (sqlc.narg(user_id)::int IS NULL OR user_id = sqlc.narg(user_id)::int)
+  AND (sqlc.narg(kind)::notification_kind IS NULL OR kind = sqlc.narg(kind)::notification_kind)
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit;
@@ -0,0 +1,18 @@
+package notification
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesNotification(r *gin.Engine, notificationHandler *NotificationHandler) {
+	auth := r.Group("notifications")
+	auth.Use(middleware.AuthMiddleware())
+	auth.GET("/preferences", notificationHandler.GetPreferences)
+	auth.PUT("/preferences", notificationHandler.SetPreferences)
+
+	admin := r.Group("notifications")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.GET("/log", notificationHandler.ListNotificationLog)
+}
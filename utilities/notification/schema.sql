@@ -0,0 +1,69 @@
+CREATE TABLE IF NOT EXISTS roles(
+    id SERIAL PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    created_at timestamp default now()
+);
+
+CREATE TABLE IF NOT EXISTS users(
+    id SERIAL PRIMARY KEY,
+    role_id integer null,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password varchar(100) NOT NULL,
+    created_at timestamp default now(),
+    constraint fk_role foreign key (role_id) REFERENCES roles(id) on delete SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_preferences(
+    user_id int PRIMARY KEY,
+    locale varchar(35) null,
+    email_opt_out boolean not null DEFAULT false,
+    constraint fk_user_preferences_user foreign key (user_id) REFERENCES users(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_sessions(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    started_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    completed_at timestamp null,
+    blueprint_id int null,
+    quality_flags JSONB NOT NULL DEFAULT '[]'::jsonb,
+    submitted_at timestamp null
+);
+
+CREATE TABLE IF NOT EXISTS invitations(
+    id SERIAL PRIMARY KEY,
+    email varchar(100) not null,
+    name varchar(100) not null,
+    user_id int null,
+    assessment_types text[] not null,
+    token_hash varchar(64) not null UNIQUE,
+    invited_by int not null,
+    expires_at timestamp not null,
+    opened_at timestamp null,
+    accepted_at timestamp null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_invitation_user foreign key (user_id) REFERENCES users(id) on delete SET NULL,
+    constraint fk_invitation_inviter foreign key (invited_by) REFERENCES users(id)
+);
+
+CREATE TYPE notification_kind as ENUM ('invitation','expiry_reminder','incomplete_nudge','completion_confirmation','staff_digest');
+
+CREATE TYPE notification_status as ENUM ('pending','sent','failed','skipped');
+
+CREATE TABLE IF NOT EXISTS notification_log(
+    id SERIAL PRIMARY KEY,
+    kind notification_kind not null,
+    dedup_key varchar(255) not null UNIQUE,
+    user_id int null,
+    email varchar(100) not null,
+    subject text not null,
+    status notification_status not null DEFAULT 'pending',
+    attempts int not null DEFAULT 1,
+    error text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    sent_at timestamp null,
+    claimed_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    constraint fk_notification_user foreign key (user_id) REFERENCES users(id) on delete SET NULL
+);
@@ -0,0 +1,107 @@
+package notification
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// templateData is everything a message template can refer to. Each kind of
+// message uses the fields that make sense for it.
+type templateData struct {
+	Name           string
+	Link           string
+	ExpiresAt      time.Time
+	AssessmentType string
+	Completions    []ListCompletionsSinceRow
+}
+
+type messageTemplate struct {
+	subject *template.Template
+	body    *template.Template
+}
+
+var templateFuncs = template.FuncMap{
+	"date": func(t time.Time) string {
+		return t.UTC().Format("Monday 2 January 2006, 15:04 MST")
+	},
+	"title": func(s string) string {
+		if s == "" {
+			return s
+		}
+		return strings.ToUpper(s[:1]) + s[1:]
+	},
+}
+
+func mustTemplate(kind NotificationKind, subject, body string) messageTemplate {
+	return messageTemplate{
+		subject: template.Must(template.New(string(kind) + "_subject").Funcs(templateFuncs).Parse(subject)),
+		body:    template.Must(template.New(string(kind) + "_body").Funcs(templateFuncs).Parse(body)),
+	}
+}
+
+var templates = map[NotificationKind]messageTemplate{
+	NotificationKindInvitation: mustTemplate(NotificationKindInvitation,
+		"You have been invited to complete an assessment",
+		`Hello {{.Name}},
+
+You have been invited to complete an assessment. Follow the link below to get
+started:
+
+{{.Link}}
+
+The link can be used once and expires on {{date .ExpiresAt}}.
+`),
+	NotificationKindExpiryReminder: mustTemplate(NotificationKindExpiryReminder,
+		"Your assessment invitation expires soon",
+		`Hello {{.Name}},
+
+This is a reminder that your assessment invitation expires on
+{{date .ExpiresAt}}.
+
+Use the link in your invitation email to get started before then.
+`),
+	NotificationKindIncompleteNudge: mustTemplate(NotificationKindIncompleteNudge,
+		"Your {{.AssessmentType}} assessment is waiting for you",
+		`Hello {{.Name}},
+
+You started the {{.AssessmentType}} assessment but have not finished it yet.
+Your progress has been saved, so you can pick up where you left off:
+
+{{.Link}}
+`),
+	NotificationKindCompletionConfirmation: mustTemplate(NotificationKindCompletionConfirmation,
+		"We have received your {{.AssessmentType}} assessment",
+		`Hello {{.Name}},
+
+Thank you for completing the {{.AssessmentType}} assessment. Your answers have
+been received and there is nothing more you need to do for it.
+`),
+	NotificationKindStaffDigest: mustTemplate(NotificationKindStaffDigest,
+		"{{len .Completions}} new assessment completion{{if ne (len .Completions) 1}}s{{end}}",
+		`Hello {{.Name}},
+
+These assessments were completed since the last digest:
+{{range .Completions}}
+- {{.Name}} <{{.Email}}>: {{title .AssessmentType}}, {{date .CompletedAt.Time}}{{end}}
+
+Review the results at {{.Link}}
+`),
+}
+
+// render fills in the subject and body of a message.
+func render(kind NotificationKind, data templateData) (subject, body string, err error) {
+	tmpl, ok := templates[kind]
+	if !ok {
+		return "", "", fmt.Errorf("no template for %s notifications", kind)
+	}
+	var s, b strings.Builder
+	if err := tmpl.subject.Execute(&s, data); err != nil {
+		return "", "", err
+	}
+	if err := tmpl.body.Execute(&b, data); err != nil {
+		return "", "", err
+	}
+	return s.String(), b.String(), nil
+}
@@ -0,0 +1,136 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"log"
+	"sort"
+	"time"
+
+	"backend/pkg/backoff"
+	"backend/pkg/events"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// pollInterval is how often the outbox is checked for new events.
+	pollInterval = time.Second
+	// batchSize bounds how many events are claimed at once.
+	batchSize = 50
+	// consumeTimeout bounds how long the consumers get for one event.
+	consumeTimeout = 30 * time.Second
+	// leaseDuration is how long a claimed event is left alone before another
+	// dispatcher may take it. It must outlast a full batch of consumers.
+	leaseDuration = batchSize * consumeTimeout * 2
+	// baseRetryDelay and maxRetryDelay bound the backoff for events whose
+	// consumers failed.
+	baseRetryDelay = 5 * time.Second
+	maxRetryDelay  = time.Hour
+	// retentionDays is how long dispatched events are kept, for
+	// troubleshooting, before they are pruned.
+	retentionDays = 7
+	// pruneInterval is how often dispatched events are pruned.
+	pruneInterval = time.Hour
+	// maxErrorLength bounds how much of a failure is kept on the event.
+	maxErrorLength = 500
+)
+
+// Dispatcher hands committed outbox events to consumers. An event is marked
+// dispatched only once every consumer has accepted it; a crash before then
+// means it is dispatched again, so delivery is at least once.
+type Dispatcher struct {
+	queries   *Queries
+	consumers []events.Consumer
+}
+
+func NewDispatcher(queries *Queries, consumers ...events.Consumer) *Dispatcher {
+	return &Dispatcher{
+		queries:   queries,
+		consumers: consumers,
+	}
+}
+
+// Run dispatches events until the context is cancelled. Claimed events are
+// leased, so several servers can run it side by side without handing out the
+// same event twice at once.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	var lastPrune time.Time
+	for {
+		for {
+			n, err := d.dispatchBatch(ctx)
+			if err != nil {
+				log.Printf("outbox: dispatching: %v", err)
+			}
+			if err != nil || n < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+		if time.Since(lastPrune) >= pruneInterval {
+			if _, err := d.queries.DeleteDispatchedOutboxEvents(ctx, retentionDays); err != nil {
+				log.Printf("outbox: pruning: %v", err)
+			}
+			lastPrune = time.Now()
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// dispatchBatch hands one batch of events to the consumers and reports how
+// many it took. The claim is committed before any consumer runs, so no rows
+// stay locked while they work; the lease keeps other dispatchers away.
+func (d *Dispatcher) dispatchBatch(ctx context.Context) (int, error) {
+	rows, err := d.queries.ClaimOutboxEvents(ctx, ClaimOutboxEventsParams{
+		BatchSize:    batchSize,
+		LeaseSeconds: int32(leaseDuration.Seconds()),
+	})
+	if err != nil {
+		return 0, err
+	}
+	sort.Slice(rows, func(i, j int) bool { return rows[i].ID < rows[j].ID })
+	for _, row := range rows {
+		event := events.Event{
+			ID:        row.ID,
+			Type:      row.EventType,
+			DedupKey:  row.DedupKey,
+			Payload:   row.Payload,
+			CreatedAt: row.CreatedAt.Time,
+		}
+		if consumeErr := d.consume(ctx, event); consumeErr != nil {
+			log.Printf("outbox: event %d (%s): %v", row.ID, row.DedupKey, consumeErr)
+			msg := consumeErr.Error()
+			if len(msg) > maxErrorLength {
+				msg = msg[:maxErrorLength]
+			}
+			err = d.queries.MarkOutboxEventFailed(ctx, MarkOutboxEventFailedParams{
+				LastError:         pgtype.Text{String: msg, Valid: true},
+				RetryAfterSeconds: int32(backoff.Exponential(int(row.Attempts)+1, baseRetryDelay, maxRetryDelay).Seconds()),
+				ID:                row.ID,
+			})
+		} else {
+			err = d.queries.MarkOutboxEventDispatched(ctx, row.ID)
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	return len(rows), nil
+}
+
+// consume gives an event to every consumer, even when an earlier one fails.
+// Those that succeeded see it again on the retry and rely on its dedup key.
+func (d *Dispatcher) consume(ctx context.Context, event events.Event) error {
+	ctx, cancel := context.WithTimeout(ctx, consumeTimeout)
+	defer cancel()
+	var errs []error
+	for _, consumer := range d.consumers {
+		errs = append(errs, consumer.Consume(ctx, event))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,34 @@
+-- name: ClaimOutboxEvents :many
+-- Take a batch of events that are ready to go, pushing them out by the lease
+-- so that a dispatcher dying while consumers run leaves them to be retried.
+-- Events another dispatcher is claiming are skipped rather than waited for.
+WITH due AS (
+  SELECT id FROM outbox_events
+  WHERE dispatched_at IS NULL AND available_at <= CURRENT_TIMESTAMP
+  ORDER BY id
+  LIMIT @batch_size
+  FOR UPDATE SKIP LOCKED
+)
+UPDATE outbox_events e
+SET available_at = CURRENT_TIMESTAMP + make_interval(secs => @lease_seconds::int)
+FROM due
+WHERE e.id = due.id
+RETURNING e.*;
+
+-- name: MarkOutboxEventDispatched :exec
+UPDATE outbox_events
+SET dispatched_at = CURRENT_TIMESTAMP,
+    attempts = attempts + 1,
+    last_error = NULL
+WHERE id = $1;
+
+-- name: MarkOutboxEventFailed :exec
+UPDATE outbox_events
+SET attempts = attempts + 1,
+    last_error = @last_error,
+    available_at = CURRENT_TIMESTAMP + make_interval(secs => @retry_after_seconds::int)
+WHERE id = @id;
+
+-- name: DeleteDispatchedOutboxEvents :execrows
+DELETE FROM outbox_events
+WHERE dispatched_at < CURRENT_TIMESTAMP - make_interval(days => @days::int);
@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS outbox_events(
+    id BIGSERIAL PRIMARY KEY,
+    event_type varchar(50) not null,
+    dedup_key varchar(255) not null UNIQUE,
+    payload JSONB not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    available_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    attempts int not null DEFAULT 0,
+    last_error text null,
+    dispatched_at timestamp null
+);
+
+CREATE INDEX IF NOT EXISTS idx_outbox_events_pending ON outbox_events(available_at) WHERE dispatched_at IS NULL;
@@ -0,0 +1,208 @@
+package proctoring
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+type ProctoringHandler struct {
+	db      *pgxpool.Pool
+	queries *Queries
+}
+
+func NewProctoringHandler(db *pgxpool.Pool, queries *Queries) *ProctoringHandler {
+	return &ProctoringHandler{
+		db:      db,
+		queries: queries,
+	}
+}
+
+// TimelineEvent is a proctoring event as shown to admins.
+type TimelineEvent struct {
+	ID         int32               `json:"id"`
+	Type       ProctoringEventType `json:"type"`
+	OccurredAt time.Time           `json:"occurred_at"`
+	ReceivedAt time.Time           `json:"received_at"`
+	Details    json.RawMessage     `json:"details"`
+}
+
+func (h *ProctoringHandler) RecordEvents(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	var req struct {
+		Events []struct {
+			Type       ProctoringEventType `json:"type" binding:"required"`
+			OccurredAt *time.Time          `json:"occurred_at"`
+			Details    json.RawMessage     `json:"details"`
+		} `json:"events" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if len(req.Events) == 0 || len(req.Events) > maxEventsPerRequest {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Send between 1 and %d events at a time", maxEventsPerRequest)})
+		return
+	}
+	for i, event := range req.Events {
+		if _, ok := eventPenalties[event.Type]; !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Event %d: unknown type %q", i+1, event.Type)})
+			return
+		}
+		if len(event.Details) > maxDetailsSize {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Event %d: details are too large", i+1)})
+			return
+		}
+		if len(event.Details) > 0 {
+			var details map[string]interface{}
+			if err := json.Unmarshal(event.Details, &details); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Event %d: details must be a JSON object", i+1)})
+				return
+			}
+		}
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	session, err := qtx.LockSession(c, int32(sessionID))
+	if err != nil || session.UserID != int32(userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if session.CompletedAt.Valid || session.SubmittedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Session is no longer active"})
+		return
+	}
+
+	counts, err := qtx.CountSessionEvents(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count events"})
+		return
+	}
+	if counts.LastMinute+int64(len(req.Events)) > maxEventsPerMinute {
+		c.Header("Retry-After", "60")
+		c.JSON(http.StatusTooManyRequests, gin.H{"error": "Too many events for this session, try again later"})
+		return
+	}
+
+	// Events past the session limit are dropped rather than rejected so the
+	// client does not keep retrying them.
+	accepted := len(req.Events)
+	if room := maxEventsPerSession - int(counts.Total); accepted > room {
+		accepted = max(room, 0)
+	}
+
+	now := time.Now()
+	for _, event := range req.Events[:accepted] {
+		occurredAt := now
+		if event.OccurredAt != nil && event.OccurredAt.Before(now) {
+			occurredAt = *event.OccurredAt
+		}
+		if session.StartedAt.Valid && occurredAt.Before(session.StartedAt.Time) {
+			occurredAt = session.StartedAt.Time
+		}
+		details := []byte(event.Details)
+		if len(details) == 0 {
+			details = []byte("{}")
+		}
+
+		err := qtx.InsertEvent(c, InsertEventParams{
+			SessionID:  session.ID,
+			EventType:  event.Type,
+			OccurredAt: pgtype.Timestamp{Time: occurredAt, Valid: true},
+			Details:    details,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to record event: %v", err)})
+			return
+		}
+	}
+
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit events"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"accepted":      accepted,
+		"dropped":       len(req.Events) - accepted,
+		"limit_reached": int(counts.Total)+accepted >= maxEventsPerSession,
+	})
+}
+
+func (h *ProctoringHandler) GetSessionIntegrity(c *gin.Context) {
+	sessionID, err := strconv.ParseInt(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	session, err := h.queries.GetSessionOverview(c, int32(sessionID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve session"})
+		return
+	}
+
+	events, err := h.queries.ListSessionEvents(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve events"})
+		return
+	}
+
+	timeline := make([]TimelineEvent, 0, len(events))
+	for _, event := range events {
+		timeline = append(timeline, TimelineEvent{
+			ID:         event.ID,
+			Type:       event.EventType,
+			OccurredAt: event.OccurredAt.Time,
+			ReceivedAt: event.ReceivedAt.Time,
+			Details:    event.Details,
+		})
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":      session.ID,
+		"user_id":         session.UserID,
+		"name":            session.Name,
+		"email":           session.Email,
+		"assessment_type": session.AssessmentType,
+		"started_at":      session.StartedAt,
+		"completed_at":    session.CompletedAt,
+		"integrity":       summarize(events),
+		"timeline":        timeline,
+	})
+}
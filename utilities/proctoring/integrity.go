@@ -0,0 +1,62 @@
+package proctoring
+
+const (
+	// maxEventsPerSession bounds how many events one session can store.
+	maxEventsPerSession = 500
+	// maxEventsPerMinute bounds how fast a session can post events.
+	maxEventsPerMinute = 60
+	// maxEventsPerRequest bounds a single batch from the client.
+	maxEventsPerRequest = 20
+	// maxDetailsSize bounds the free-form details attached to an event.
+	maxDetailsSize = 1 << 10
+)
+
+// eventPenalties is how many points each event type takes off the integrity
+// score. Pasting into a timed test is the strongest signal; a blur can be a
+// notification stealing focus.
+var eventPenalties = map[ProctoringEventType]int{
+	ProctoringEventTypeTabHidden:      5,
+	ProctoringEventTypeWindowBlur:     3,
+	ProctoringEventTypeCopy:           10,
+	ProctoringEventTypePaste:          15,
+	ProctoringEventTypeFullscreenExit: 8,
+	ProctoringEventTypePageReload:     5,
+}
+
+// IntegritySummary condenses a session's events into a 0-100 score, where
+// 100 means nothing was reported.
+type IntegritySummary struct {
+	Score        int                         `json:"score"`
+	Level        string                      `json:"level"`
+	TotalEvents  int                         `json:"total_events"`
+	Counts       map[ProctoringEventType]int `json:"counts"`
+	LimitReached bool                        `json:"limit_reached"`
+}
+
+// summarize scores a session's events. Reaching the event limit is treated
+// as suspicious on its own, since later events were not recorded.
+func summarize(events []ProctoringEvent) IntegritySummary {
+	summary := IntegritySummary{
+		Score:        100,
+		TotalEvents:  len(events),
+		Counts:       make(map[ProctoringEventType]int),
+		LimitReached: len(events) >= maxEventsPerSession,
+	}
+	for _, event := range events {
+		summary.Counts[event.EventType]++
+		summary.Score -= eventPenalties[event.EventType]
+	}
+	if summary.Score < 0 || summary.LimitReached {
+		summary.Score = 0
+	}
+
+	switch {
+	case summary.Score >= 90:
+		summary.Level = "clean"
+	case summary.Score >= 60:
+		summary.Level = "review"
+	default:
+		summary.Level = "suspicious"
+	}
+	return summary
+}
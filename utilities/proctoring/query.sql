@@ -0,0 +1,27 @@
+-- name: LockSession :one
+-- Serializes event posts for a session so the limits are counted exactly.
+SELECT * FROM user_assessment_sessions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: CountSessionEvents :one
+SELECT
+    COUNT(*) AS total,
+    COUNT(*) FILTER (WHERE received_at > CURRENT_TIMESTAMP - interval '1 minute') AS last_minute
+FROM proctoring_events
+WHERE session_id = $1;
+
+-- name: InsertEvent :exec
+INSERT INTO proctoring_events (session_id, event_type, occurred_at, details)
+VALUES ($1, $2, $3, $4);
+
+-- name: ListSessionEvents :many
+SELECT * FROM proctoring_events
+WHERE session_id = $1
+ORDER BY occurred_at, id;
+
+-- name: GetSessionOverview :one
+SELECT s.id, s.user_id, u.name, u.email, s.assessment_type, s.started_at, s.completed_at
+FROM user_assessment_sessions s
+JOIN users u ON u.id = s.user_id
+WHERE s.id = $1;
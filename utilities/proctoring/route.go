@@ -0,0 +1,17 @@
+package proctoring
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesProctoring(r *gin.Engine, proctoringHandler *ProctoringHandler) {
+	auth := r.Group("proctoring")
+	auth.Use(middleware.AuthMiddleware())
+	auth.POST("/sessions/:session_id/events", proctoringHandler.RecordEvents)
+
+	admin := r.Group("proctoring")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.GET("/sessions/:session_id", proctoringHandler.GetSessionIntegrity)
+}
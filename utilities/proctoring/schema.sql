@@ -0,0 +1,32 @@
+CREATE TABLE IF NOT EXISTS users(
+    id SERIAL PRIMARY KEY,
+    role_id integer null,
+    name varchar(100) NOT NULL,
+    email varchar(100) NOT NULL,
+    password varchar(100) NOT NULL,
+    created_at timestamp default now(),
+    constraint fk_role foreign key (role_id) REFERENCES roles(id) on delete SET NULL
+);
+
+CREATE TABLE IF NOT EXISTS user_assessment_sessions(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    assessment_type varchar(50) not null,
+    started_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    completed_at timestamp null,
+    blueprint_id int null,
+    quality_flags JSONB NOT NULL DEFAULT '[]'::jsonb,
+    submitted_at timestamp null
+);
+
+CREATE TYPE proctoring_event_type as ENUM ('tab_hidden','window_blur','copy','paste','fullscreen_exit','page_reload');
+
+CREATE TABLE IF NOT EXISTS proctoring_events(
+    id SERIAL PRIMARY KEY,
+    session_id int not null,
+    event_type proctoring_event_type not null,
+    occurred_at timestamp not null,
+    received_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    details JSONB NOT NULL DEFAULT '{}'::jsonb,
+    constraint fk_proctoring_event_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE
+);
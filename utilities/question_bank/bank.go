@@ -0,0 +1,317 @@
+package question_bank
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+type bankQuestion struct {
+	question SelfAssessmentQuestion
+	mappings []SelfAssessmentMapping
+}
+
+// bank is a snapshot of the question bank as stored in the database.
+type bank struct {
+	categories     []SelfAssessmentCategory
+	categoryByName map[string]SelfAssessmentCategory
+	categoryByID   map[int32]SelfAssessmentCategory
+	questions      []*bankQuestion
+	questionByKey  map[string]*bankQuestion
+}
+
+func loadBank(ctx context.Context, q *Queries) (*bank, error) {
+	categories, err := q.ListCategories(ctx)
+	if err != nil {
+		return nil, err
+	}
+	questions, err := q.ListQuestions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := q.ListMappings(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	b := &bank{
+		categories:     categories,
+		categoryByName: make(map[string]SelfAssessmentCategory),
+		categoryByID:   make(map[int32]SelfAssessmentCategory),
+		questionByKey:  make(map[string]*bankQuestion),
+	}
+	for _, category := range categories {
+		b.categoryByName[category.Name.String] = category
+		b.categoryByID[category.ID] = category
+	}
+	byID := make(map[int32]*bankQuestion)
+	for _, question := range questions {
+		bq := &bankQuestion{question: question}
+		b.questions = append(b.questions, bq)
+		b.questionByKey[questionKey(string(question.Type), question.Question)] = bq
+		byID[question.ID] = bq
+	}
+	for _, mapping := range mappings {
+		if bq, ok := byID[mapping.QuestionID]; ok {
+			bq.mappings = append(bq.mappings, mapping)
+		}
+	}
+	return b, nil
+}
+
+func (b *bank) categoryNames() map[string]bool {
+	names := make(map[string]bool)
+	for name := range b.categoryByName {
+		names[name] = true
+	}
+	return names
+}
+
+// toBundle exports the bank in bundle form.
+func (b *bank) toBundle() (Bundle, error) {
+	bundle := Bundle{
+		Version:    bundleVersion,
+		Categories: []BundleCategory{},
+		Questions:  []BundleQuestion{},
+	}
+	for _, category := range b.categories {
+		bundle.Categories = append(bundle.Categories, BundleCategory{
+			Name:        category.Name.String,
+			Description: category.Description.String,
+		})
+	}
+	for _, bq := range b.questions {
+		question := BundleQuestion{
+			Type:          string(bq.question.Type),
+			Question:      bq.question.Question,
+			CorrectAnswer: bq.question.CorrectAnswer.String,
+			Mappings:      []BundleMapping{},
+		}
+		if bq.question.Format != QuestionFormatSingleChoice {
+			question.Format = string(bq.question.Format)
+		}
+		if err := json.Unmarshal(bq.question.Options, &question.Options); err != nil {
+			return bundle, fmt.Errorf("question %d has invalid options: %v", bq.question.ID, err)
+		}
+		for _, mapping := range bq.mappings {
+			question.Mappings = append(question.Mappings, BundleMapping{
+				AnswerValue: int(mapping.AnswerValue.Int32),
+				Category:    b.categoryByID[mapping.CategoryID].Name.String,
+				Points:      int(mapping.Points.Int32),
+			})
+		}
+		bundle.Questions = append(bundle.Questions, question)
+	}
+	return bundle, nil
+}
+
+type QuestionChange struct {
+	Type     string   `json:"type"`
+	Question string   `json:"question"`
+	Changes  []string `json:"changes"`
+}
+
+// BankDiff describes what importing a bundle would change. Questions are
+// matched on type and text, categories on name. Imports never delete, so
+// questions missing from the bundle are only counted.
+type BankDiff struct {
+	CategoriesCreated    []string         `json:"categories_created"`
+	CategoriesUpdated    []string         `json:"categories_updated"`
+	QuestionsCreated     []QuestionChange `json:"questions_created"`
+	QuestionsUpdated     []QuestionChange `json:"questions_updated"`
+	QuestionsUnchanged   int              `json:"questions_unchanged"`
+	QuestionsNotInBundle int              `json:"questions_not_in_bundle"`
+}
+
+func diffBundle(b *bank, bundle Bundle) (BankDiff, error) {
+	diff := BankDiff{
+		CategoriesCreated: []string{},
+		CategoriesUpdated: []string{},
+		QuestionsCreated:  []QuestionChange{},
+		QuestionsUpdated:  []QuestionChange{},
+	}
+
+	for _, category := range bundle.Categories {
+		existing, ok := b.categoryByName[category.Name]
+		if !ok {
+			diff.CategoriesCreated = append(diff.CategoriesCreated, category.Name)
+		} else if existing.Description.String != category.Description {
+			diff.CategoriesUpdated = append(diff.CategoriesUpdated, category.Name)
+		}
+	}
+
+	inBundle := make(map[string]bool)
+	for _, question := range bundle.Questions {
+		key := questionKey(question.Type, question.Question)
+		inBundle[key] = true
+		change := QuestionChange{Type: question.Type, Question: question.Question}
+
+		existing, ok := b.questionByKey[key]
+		if !ok {
+			diff.QuestionsCreated = append(diff.QuestionsCreated, change)
+			continue
+		}
+
+		options, err := canonicalJSON(existing.question.Options)
+		if err != nil {
+			return diff, err
+		}
+		incoming, err := json.Marshal(question.Options)
+		if err != nil {
+			return diff, err
+		}
+		if options != string(incoming) {
+			change.Changes = append(change.Changes, "options")
+		}
+		if existing.question.CorrectAnswer.String != question.CorrectAnswer {
+			change.Changes = append(change.Changes, "correct_answer")
+		}
+		if existing.question.Format != bundleFormat(question) {
+			change.Changes = append(change.Changes, "format")
+		}
+		if !sameMappings(b, existing.mappings, question.Mappings) {
+			change.Changes = append(change.Changes, "mappings")
+		}
+
+		if len(change.Changes) == 0 {
+			diff.QuestionsUnchanged++
+		} else {
+			diff.QuestionsUpdated = append(diff.QuestionsUpdated, change)
+		}
+	}
+
+	for key := range b.questionByKey {
+		if !inBundle[key] {
+			diff.QuestionsNotInBundle++
+		}
+	}
+	return diff, nil
+}
+
+// applyBundle writes the diff to the database through q, which is expected
+// to be bound to a transaction.
+func applyBundle(ctx context.Context, q *Queries, b *bank, bundle Bundle, diff BankDiff) error {
+	categoryIDs := make(map[string]int32)
+	for name, category := range b.categoryByName {
+		categoryIDs[name] = category.ID
+	}
+
+	changedCategories := make(map[string]bool)
+	for _, name := range append(diff.CategoriesCreated, diff.CategoriesUpdated...) {
+		changedCategories[name] = true
+	}
+	for _, category := range bundle.Categories {
+		if !changedCategories[category.Name] {
+			continue
+		}
+		saved, err := q.UpsertCategory(ctx, UpsertCategoryParams{
+			Name:        pgtype.Text{String: category.Name, Valid: true},
+			Description: pgtype.Text{String: category.Description, Valid: category.Description != ""},
+		})
+		if err != nil {
+			return fmt.Errorf("category %q: %v", category.Name, err)
+		}
+		categoryIDs[category.Name] = saved.ID
+	}
+
+	changedQuestions := make(map[string]bool)
+	for _, change := range append(diff.QuestionsCreated, diff.QuestionsUpdated...) {
+		changedQuestions[questionKey(change.Type, change.Question)] = true
+	}
+	for _, question := range bundle.Questions {
+		key := questionKey(question.Type, question.Question)
+		if !changedQuestions[key] {
+			continue
+		}
+
+		options, err := json.Marshal(question.Options)
+		if err != nil {
+			return err
+		}
+		correctAnswer := pgtype.Text{String: question.CorrectAnswer, Valid: question.CorrectAnswer != ""}
+
+		var questionID int32
+		if existing, ok := b.questionByKey[key]; ok {
+			questionID = existing.question.ID
+			if err := q.UpdateQuestion(ctx, UpdateQuestionParams{
+				ID:            questionID,
+				Options:       options,
+				CorrectAnswer: correctAnswer,
+				Format:        bundleFormat(question),
+			}); err != nil {
+				return fmt.Errorf("question %q: %v", question.Question, err)
+			}
+			if err := q.DeleteQuestionMappings(ctx, questionID); err != nil {
+				return fmt.Errorf("question %q: %v", question.Question, err)
+			}
+		} else {
+			saved, err := q.InsertQuestion(ctx, InsertQuestionParams{
+				Question:      question.Question,
+				Type:          QuestionType(question.Type),
+				Options:       options,
+				CorrectAnswer: correctAnswer,
+				Format:        bundleFormat(question),
+			})
+			if err != nil {
+				return fmt.Errorf("question %q: %v", question.Question, err)
+			}
+			questionID = saved.ID
+		}
+
+		for _, mapping := range question.Mappings {
+			if err := q.InsertMapping(ctx, InsertMappingParams{
+				QuestionID:  questionID,
+				AnswerValue: pgtype.Int4{Int32: int32(mapping.AnswerValue), Valid: true},
+				CategoryID:  categoryIDs[mapping.Category],
+				Points:      pgtype.Int4{Int32: int32(mapping.Points), Valid: true},
+			}); err != nil {
+				return fmt.Errorf("question %q: %v", question.Question, err)
+			}
+		}
+	}
+	return nil
+}
+
+// bundleFormat is the stored format of a bundle question.
+func bundleFormat(question BundleQuestion) QuestionFormat {
+	if question.Format == "" {
+		return QuestionFormatSingleChoice
+	}
+	return QuestionFormat(question.Format)
+}
+
+func sameMappings(b *bank, existing []SelfAssessmentMapping, incoming []BundleMapping) bool {
+	if len(existing) != len(incoming) {
+		return false
+	}
+	a := make([]string, 0, len(existing))
+	for _, m := range existing {
+		a = append(a, fmt.Sprintf("%d|%s|%d", m.AnswerValue.Int32, b.categoryByID[m.CategoryID].Name.String, m.Points.Int32))
+	}
+	c := make([]string, 0, len(incoming))
+	for _, m := range incoming {
+		c = append(c, fmt.Sprintf("%d|%s|%d", m.AnswerValue, m.Category, m.Points))
+	}
+	sort.Strings(a)
+	sort.Strings(c)
+	for i := range a {
+		if a[i] != c[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// canonicalJSON re-encodes stored JSON so it compares equal to a freshly
+// marshalled bundle value regardless of key order or spacing.
+func canonicalJSON(raw []byte) (string, error) {
+	var v interface{}
+	if err := json.Unmarshal(raw, &v); err != nil {
+		return "", err
+	}
+	out, err := json.Marshal(v)
+	return string(out), err
+}
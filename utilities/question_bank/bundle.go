@@ -0,0 +1,318 @@
+package question_bank
+
+import (
+	"backend/pkg/formats"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// bundleVersion is the only bundle layout this package reads and writes.
+const bundleVersion = 1
+
+// Bundle is a whole question bank in a portable form. Questions refer to
+// categories by name so bundles can move between databases.
+type Bundle struct {
+	Version    int              `json:"version" yaml:"version"`
+	Categories []BundleCategory `json:"categories" yaml:"categories"`
+	Questions  []BundleQuestion `json:"questions" yaml:"questions"`
+}
+
+type BundleCategory struct {
+	Name        string `json:"name" yaml:"name"`
+	Description string `json:"description" yaml:"description"`
+}
+
+// BundleQuestion leaves Format empty for single-choice questions, so bundles
+// written before question formats existed still read the same.
+type BundleQuestion struct {
+	Type          string                 `json:"type" yaml:"type"`
+	Format        string                 `json:"format,omitempty" yaml:"format,omitempty"`
+	Question      string                 `json:"question" yaml:"question"`
+	Options       map[string]interface{} `json:"options" yaml:"options"`
+	CorrectAnswer string                 `json:"correct_answer,omitempty" yaml:"correct_answer,omitempty"`
+	Mappings      []BundleMapping        `json:"mappings" yaml:"mappings"`
+}
+
+type BundleMapping struct {
+	AnswerValue int    `json:"answer_value" yaml:"answer_value"`
+	Category    string `json:"category" yaml:"category"`
+	Points      int    `json:"points" yaml:"points"`
+}
+
+// ValidationError points at the offending part of a bundle.
+type ValidationError struct {
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+var csvHeader = []string{"type", "question", "options", "correct_answer", "answer_value", "category", "category_description", "points", "format"}
+
+// legacyCSVColumns is the column count of CSV bundles written before the
+// format column was added.
+const legacyCSVColumns = 8
+
+func decodeBundle(format string, r io.Reader) (Bundle, error) {
+	var bundle Bundle
+	switch format {
+	case "json":
+		if err := json.NewDecoder(r).Decode(&bundle); err != nil {
+			return bundle, fmt.Errorf("invalid JSON bundle: %v", err)
+		}
+	case "yaml":
+		if err := yaml.NewDecoder(r).Decode(&bundle); err != nil {
+			return bundle, fmt.Errorf("invalid YAML bundle: %v", err)
+		}
+	case "csv":
+		return decodeCSVBundle(r)
+	default:
+		return bundle, errors.New("format must be json, yaml or csv")
+	}
+	return bundle, nil
+}
+
+func encodeBundle(format string, w io.Writer, bundle Bundle) error {
+	switch format {
+	case "json":
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(bundle)
+	case "yaml":
+		enc := yaml.NewEncoder(w)
+		defer enc.Close()
+		return enc.Encode(bundle)
+	case "csv":
+		return encodeCSVBundle(w, bundle)
+	default:
+		return errors.New("format must be json, yaml or csv")
+	}
+}
+
+// decodeCSVBundle reads one row per question mapping. Rows repeating the same
+// type and question text belong to the same question; rows without a question
+// only declare a category.
+func decodeCSVBundle(r io.Reader) (Bundle, error) {
+	bundle := Bundle{Version: bundleVersion}
+	reader := csv.NewReader(r)
+
+	// The header fixes the column count for every following row.
+	header, err := reader.Read()
+	if err != nil {
+		return bundle, fmt.Errorf("invalid CSV bundle: %v", err)
+	}
+	if strings.Join(header, ",") != strings.Join(csvHeader, ",") &&
+		strings.Join(header, ",") != strings.Join(csvHeader[:legacyCSVColumns], ",") {
+		return bundle, fmt.Errorf("invalid CSV header, expected %s", strings.Join(csvHeader, ","))
+	}
+
+	categorySeen := make(map[string]bool)
+	questionIndex := make(map[string]int)
+	line := 1
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return bundle, fmt.Errorf("invalid CSV bundle: %v", err)
+		}
+		questionType, text, options, correctAnswer := record[0], record[1], record[2], record[3]
+		answerValue, category, categoryDescription, points := record[4], record[5], record[6], record[7]
+		var format string
+		if len(record) > legacyCSVColumns {
+			format = record[8]
+		}
+
+		if category != "" && !categorySeen[category] {
+			categorySeen[category] = true
+			bundle.Categories = append(bundle.Categories, BundleCategory{Name: category, Description: categoryDescription})
+		}
+		if text == "" {
+			continue
+		}
+
+		key := questionKey(questionType, text)
+		idx, ok := questionIndex[key]
+		if !ok {
+			question := BundleQuestion{Type: questionType, Format: format, Question: text, CorrectAnswer: correctAnswer}
+			if options != "" {
+				if err := json.Unmarshal([]byte(options), &question.Options); err != nil {
+					return bundle, fmt.Errorf("line %d: options must be a JSON object: %v", line, err)
+				}
+			}
+			bundle.Questions = append(bundle.Questions, question)
+			idx = len(bundle.Questions) - 1
+			questionIndex[key] = idx
+		}
+
+		if answerValue == "" && category == "" {
+			continue
+		}
+		mapping := BundleMapping{Category: category}
+		if mapping.AnswerValue, err = strconv.Atoi(answerValue); err != nil {
+			return bundle, fmt.Errorf("line %d: answer_value must be a number", line)
+		}
+		if mapping.Points, err = strconv.Atoi(points); err != nil {
+			return bundle, fmt.Errorf("line %d: points must be a number", line)
+		}
+		bundle.Questions[idx].Mappings = append(bundle.Questions[idx].Mappings, mapping)
+	}
+	return bundle, nil
+}
+
+func encodeCSVBundle(w io.Writer, bundle Bundle) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(csvHeader); err != nil {
+		return err
+	}
+
+	descriptions := make(map[string]string)
+	for _, category := range bundle.Categories {
+		descriptions[category.Name] = category.Description
+	}
+
+	used := make(map[string]bool)
+	for _, question := range bundle.Questions {
+		options, err := json.Marshal(question.Options)
+		if err != nil {
+			return err
+		}
+		base := []string{question.Type, question.Question, string(options), question.CorrectAnswer}
+		if len(question.Mappings) == 0 {
+			if err := writer.Write(append(base, "", "", "", "", question.Format)); err != nil {
+				return err
+			}
+			continue
+		}
+		for _, mapping := range question.Mappings {
+			used[mapping.Category] = true
+			record := append(append([]string{}, base...),
+				strconv.Itoa(mapping.AnswerValue),
+				mapping.Category,
+				descriptions[mapping.Category],
+				strconv.Itoa(mapping.Points),
+				question.Format,
+			)
+			if err := writer.Write(record); err != nil {
+				return err
+			}
+		}
+	}
+
+	// Categories no question maps to still need a row of their own.
+	for _, category := range bundle.Categories {
+		if !used[category.Name] {
+			if err := writer.Write([]string{"", "", "", "", "", category.Name, category.Description, "", ""}); err != nil {
+				return err
+			}
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// validateBundle checks the bundle on its own and against the categories
+// already in the bank.
+func validateBundle(bundle Bundle, existingCategories map[string]bool) []ValidationError {
+	var errs []ValidationError
+	add := func(path, format string, args ...interface{}) {
+		errs = append(errs, ValidationError{Path: path, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if bundle.Version != bundleVersion {
+		add("version", "unsupported bundle version %d, expected %d", bundle.Version, bundleVersion)
+	}
+
+	known := make(map[string]bool)
+	for name := range existingCategories {
+		known[name] = true
+	}
+	declared := make(map[string]bool)
+	for i, category := range bundle.Categories {
+		path := fmt.Sprintf("categories[%d]", i)
+		if strings.TrimSpace(category.Name) == "" {
+			add(path+".name", "category name is required")
+			continue
+		}
+		if declared[category.Name] {
+			add(path+".name", "duplicate category %q", category.Name)
+		}
+		declared[category.Name] = true
+		known[category.Name] = true
+	}
+
+	seen := make(map[string]bool)
+	for i, question := range bundle.Questions {
+		path := fmt.Sprintf("questions[%d]", i)
+		switch QuestionType(question.Type) {
+		case QuestionTypeBehavioral, QuestionTypePersonality, QuestionTypeCognitive:
+		default:
+			add(path+".type", "invalid question type %q", question.Type)
+		}
+		if strings.TrimSpace(question.Question) == "" {
+			add(path+".question", "question text is required")
+		}
+		key := questionKey(question.Type, question.Question)
+		if seen[key] {
+			add(path+".question", "duplicate question %q", question.Question)
+		}
+		seen[key] = true
+
+		if len(question.Options) == 0 && question.Format != formats.OpenEnded {
+			add(path+".options", "at least one option is required")
+		} else if question.Format != "" {
+			if err := validateFormat(question); err != nil {
+				add(path+".format", "%v", err)
+			}
+		}
+		if question.CorrectAnswer != "" {
+			if _, ok := question.Options[question.CorrectAnswer]; !ok {
+				add(path+".correct_answer", "correct answer %q is not one of the options", question.CorrectAnswer)
+			}
+		}
+		if question.Format == formats.Situational && len(question.Mappings) == 0 {
+			add(path+".mappings", "situational questions need at least one mapping")
+		}
+		mapped := make(map[string]bool)
+		for j, mapping := range question.Mappings {
+			mappingPath := fmt.Sprintf("%s.mappings[%d]", path, j)
+			if _, ok := question.Options[strconv.Itoa(mapping.AnswerValue)]; !ok {
+				add(mappingPath+".answer_value", "answer value %d is not one of the options", mapping.AnswerValue)
+			}
+			if !known[mapping.Category] {
+				add(mappingPath+".category", "unknown category %q", mapping.Category)
+			}
+			pair := fmt.Sprintf("%d\x00%s", mapping.AnswerValue, mapping.Category)
+			if mapped[pair] {
+				add(mappingPath, "answer value %d already maps to category %q", mapping.AnswerValue, mapping.Category)
+			}
+			mapped[pair] = true
+		}
+	}
+	return errs
+}
+
+func questionKey(questionType, text string) string {
+	return questionType + "\x00" + strings.TrimSpace(text)
+}
+
+// validateFormat checks the options of a typed-format question.
+func validateFormat(question BundleQuestion) error {
+	encoded, err := json.Marshal(question.Options)
+	if err != nil {
+		return err
+	}
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(encoded, &options); err != nil {
+		return err
+	}
+	return formats.ValidateOptions(question.Format, options)
+}
@@ -0,0 +1,171 @@
+package question_bank
+
+import (
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// maxBundleSize bounds the size of an uploaded question bank or QTI package.
+const maxBundleSize = 10 << 20
+
+// defaultCognitivePoints is what a correct cognitive answer is worth in the seed data.
+const defaultCognitivePoints = 4
+
+var contentTypes = map[string]string{
+	"json": "application/json",
+	"yaml": "application/yaml",
+	"csv":  "text/csv; charset=utf-8",
+}
+
+type QuestionBankHandler struct {
+	db      *pgxpool.Pool
+	queries *Queries
+}
+
+func NewQuestionBankHandler(db *pgxpool.Pool, queries *Queries) *QuestionBankHandler {
+	return &QuestionBankHandler{
+		db:      db,
+		queries: queries,
+	}
+}
+
+func (h *QuestionBankHandler) ExportQuestionBank(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	contentType, ok := contentTypes[format]
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format must be json, yaml or csv"})
+		return
+	}
+
+	b, err := loadBank(c, h.queries)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question bank"})
+		return
+	}
+
+	bundle, err := b.toBundle()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("question-bank-%s.%s", time.Now().Format("20060102"), format)
+	c.Header("Content-Disposition", fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Header("Content-Type", contentType)
+	c.Status(http.StatusOK)
+	if err := encodeBundle(format, c.Writer, bundle); err != nil {
+		c.Error(err)
+	}
+}
+
+func (h *QuestionBankHandler) ImportQuestionBank(c *gin.Context) {
+	format := c.DefaultQuery("format", "json")
+	if _, ok := contentTypes[format]; !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Format must be json, yaml or csv"})
+		return
+	}
+	dryRun := c.Query("dry_run") == "true"
+
+	bundle, err := decodeBundle(format, http.MaxBytesReader(c.Writer, c.Request.Body, maxBundleSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.importBundle(c, bundle, dryRun, gin.H{})
+}
+
+func (h *QuestionBankHandler) ImportQTIPackage(c *gin.Context) {
+	dryRun := c.Query("dry_run") == "true"
+	opts := QTIOptions{
+		DefaultCategory: c.Query("default_category"),
+		Points:          defaultCognitivePoints,
+	}
+	if raw := c.Query("points"); raw != "" {
+		points, err := strconv.Atoi(raw)
+		if err != nil || points <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Points must be a positive number"})
+			return
+		}
+		opts.Points = points
+	}
+
+	data, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxBundleSize))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "QTI package is too large or unreadable"})
+		return
+	}
+
+	bundle, unsupported, err := parseQTIPackage(data, opts)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.importBundle(c, bundle, dryRun, gin.H{
+		"items_found":       len(bundle.Questions) + len(unsupported),
+		"items_supported":   len(bundle.Questions),
+		"unsupported_items": unsupported,
+	})
+}
+
+// importBundle validates the bundle, diffs it against the bank and, unless
+// this is a dry run, applies it. Everything happens inside one transaction so
+// the diff reflects exactly what gets committed. Extra report fields are
+// added to the response.
+func (h *QuestionBankHandler) importBundle(c *gin.Context, bundle Bundle, dryRun bool, report gin.H) {
+	respond := func(status int, fields gin.H) {
+		for k, v := range fields {
+			report[k] = v
+		}
+		report["dry_run"] = dryRun
+		c.JSON(status, report)
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	b, err := loadBank(c, qtx)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question bank"})
+		return
+	}
+
+	if errs := validateBundle(bundle, b.categoryNames()); len(errs) > 0 {
+		respond(http.StatusUnprocessableEntity, gin.H{"valid": false, "errors": errs})
+		return
+	}
+
+	diff, err := diffBundle(b, bundle)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if dryRun {
+		respond(http.StatusOK, gin.H{"valid": true, "diff": diff})
+		return
+	}
+
+	if err := applyBundle(c, qtx, b, bundle, diff); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to import question bank: %v", err)})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit question bank import"})
+		return
+	}
+
+	respond(http.StatusOK, gin.H{"valid": true, "committed": true, "diff": diff})
+}
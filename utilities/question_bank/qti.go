@@ -0,0 +1,382 @@
+package question_bank
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// maxQTIFileSize bounds every file read out of a QTI package, so a small
+// archive cannot expand into an unbounded amount of memory.
+const maxQTIFileSize = 2 << 20
+
+// UnsupportedItem is a QTI item that was left out of the import and why.
+type UnsupportedItem struct {
+	File        string `json:"file"`
+	Identifier  string `json:"identifier,omitempty"`
+	Interaction string `json:"interaction,omitempty"`
+	Reason      string `json:"reason"`
+}
+
+// QTIOptions controls how QTI items are mapped onto cognitive questions.
+type QTIOptions struct {
+	// DefaultCategory is used for items whose manifest metadata names no category.
+	DefaultCategory string
+	// Points awarded for the correct choice, matching the cognitive seed data.
+	Points int
+}
+
+type qtiManifest struct {
+	Resources []qtiResource `xml:"resources>resource"`
+}
+
+type qtiResource struct {
+	Identifier string   `xml:"identifier,attr"`
+	Type       string   `xml:"type,attr"`
+	Href       string   `xml:"href,attr"`
+	Taxons     []string `xml:"metadata>lom>classification>taxonPath>taxon>entry>string"`
+	Keywords   []string `xml:"metadata>lom>general>keyword>string"`
+}
+
+func (r qtiResource) category() string {
+	for _, values := range [][]string{r.Taxons, r.Keywords} {
+		for _, v := range values {
+			if v = strings.TrimSpace(v); v != "" {
+				return v
+			}
+		}
+	}
+	return ""
+}
+
+type qtiResponseDeclaration struct {
+	Identifier      string   `xml:"identifier,attr"`
+	Cardinality     string   `xml:"cardinality,attr"`
+	CorrectResponse []string `xml:"correctResponse>value"`
+}
+
+type qtiChoiceInteraction struct {
+	ResponseIdentifier string `xml:"responseIdentifier,attr"`
+	MaxChoices         string `xml:"maxChoices,attr"`
+	Prompt             struct {
+		Inner string `xml:",innerxml"`
+	} `xml:"prompt"`
+	Choices []struct {
+		Identifier string `xml:"identifier,attr"`
+		Inner      string `xml:",innerxml"`
+	} `xml:"simpleChoice"`
+}
+
+type qtiItem struct {
+	Identifier   string
+	Title        string
+	Stem         string
+	HasMedia     bool
+	Responses    []qtiResponseDeclaration
+	Choices      []qtiChoiceInteraction
+	Interactions []string
+}
+
+// parseQTIPackage turns the choice items of a QTI 2.1 zip package into a
+// bundle of cognitive questions. Items it cannot represent are returned as
+// unsupported rather than silently dropped.
+func parseQTIPackage(data []byte, opts QTIOptions) (Bundle, []UnsupportedItem, error) {
+	bundle := Bundle{Version: bundleVersion, Categories: []BundleCategory{}, Questions: []BundleQuestion{}}
+	unsupported := []UnsupportedItem{}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return bundle, nil, fmt.Errorf("invalid QTI package: %v", err)
+	}
+	files := make(map[string]*zip.File)
+	for _, f := range archive.File {
+		files[path.Clean(f.Name)] = f
+	}
+
+	resources, err := qtiItemResources(files)
+	if err != nil {
+		return bundle, nil, err
+	}
+
+	categories := make(map[string]bool)
+	for _, resource := range resources {
+		file, ok := files[resource.Href]
+		if !ok {
+			unsupported = append(unsupported, UnsupportedItem{File: resource.Href, Identifier: resource.Identifier, Reason: "file listed in manifest is missing"})
+			continue
+		}
+		raw, err := readZipFile(file)
+		if err != nil {
+			unsupported = append(unsupported, UnsupportedItem{File: resource.Href, Identifier: resource.Identifier, Reason: err.Error()})
+			continue
+		}
+		item, err := parseQTIItem(raw)
+		if err != nil {
+			unsupported = append(unsupported, UnsupportedItem{File: resource.Href, Identifier: resource.Identifier, Reason: err.Error()})
+			continue
+		}
+
+		category := resource.category()
+		if category == "" {
+			category = opts.DefaultCategory
+		}
+		question, problem := qtiQuestion(item, category, opts.Points)
+		if problem != nil {
+			problem.File = resource.Href
+			unsupported = append(unsupported, *problem)
+			continue
+		}
+
+		if !categories[category] {
+			categories[category] = true
+			bundle.Categories = append(bundle.Categories, BundleCategory{Name: category})
+		}
+		bundle.Questions = append(bundle.Questions, question)
+	}
+	return bundle, unsupported, nil
+}
+
+// qtiItemResources lists the item files to import, from the manifest when
+// there is one and otherwise from every XML file in the package.
+func qtiItemResources(files map[string]*zip.File) ([]qtiResource, error) {
+	if manifestFile, ok := files["imsmanifest.xml"]; ok {
+		raw, err := readZipFile(manifestFile)
+		if err != nil {
+			return nil, err
+		}
+		var manifest qtiManifest
+		if err := xml.Unmarshal(raw, &manifest); err != nil {
+			return nil, fmt.Errorf("invalid imsmanifest.xml: %v", err)
+		}
+		var resources []qtiResource
+		for _, resource := range manifest.Resources {
+			if strings.HasPrefix(resource.Type, "imsqti_item") {
+				resource.Href = path.Clean(resource.Href)
+				resources = append(resources, resource)
+			}
+		}
+		return resources, nil
+	}
+
+	var resources []qtiResource
+	for name := range files {
+		if strings.EqualFold(path.Ext(name), ".xml") {
+			resources = append(resources, qtiResource{Href: name})
+		}
+	}
+	sort.Slice(resources, func(i, j int) bool { return resources[i].Href < resources[j].Href })
+	if len(resources) == 0 {
+		return nil, errors.New("QTI package contains no items")
+	}
+	return resources, nil
+}
+
+func readZipFile(f *zip.File) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+	data, err := io.ReadAll(io.LimitReader(rc, maxQTIFileSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > maxQTIFileSize {
+		return nil, fmt.Errorf("%s is larger than %d bytes", f.Name, maxQTIFileSize)
+	}
+	return data, nil
+}
+
+// parseQTIItem walks an assessmentItem, collecting the stem text outside of
+// interactions, the response declarations and every interaction it finds.
+func parseQTIItem(data []byte) (qtiItem, error) {
+	var item qtiItem
+	var stem strings.Builder
+	foundItem := false
+	inBody := false
+
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	// Item bodies are XHTML and often use HTML entities such as &nbsp;.
+	decoder.Entity = xml.HTMLEntity
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return item, fmt.Errorf("invalid item XML: %v", err)
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			name := t.Name.Local
+			switch {
+			case name == "assessmentItem":
+				foundItem = true
+				item.Identifier = xmlAttr(t, "identifier")
+				item.Title = xmlAttr(t, "title")
+			case name == "responseDeclaration":
+				var response qtiResponseDeclaration
+				if err := decoder.DecodeElement(&response, &t); err != nil {
+					return item, fmt.Errorf("invalid responseDeclaration: %v", err)
+				}
+				item.Responses = append(item.Responses, response)
+			case name == "itemBody":
+				inBody = true
+			case inBody && name == "choiceInteraction":
+				var choice qtiChoiceInteraction
+				if err := decoder.DecodeElement(&choice, &t); err != nil {
+					return item, fmt.Errorf("invalid choiceInteraction: %v", err)
+				}
+				item.Choices = append(item.Choices, choice)
+				item.Interactions = append(item.Interactions, name)
+			case inBody && strings.HasSuffix(name, "Interaction"):
+				item.Interactions = append(item.Interactions, name)
+				if err := decoder.Skip(); err != nil {
+					return item, fmt.Errorf("invalid %s: %v", name, err)
+				}
+			case inBody && (name == "img" || name == "object"):
+				item.HasMedia = true
+			case inBody:
+				stem.WriteString(" ")
+			}
+		case xml.EndElement:
+			if t.Name.Local == "itemBody" {
+				inBody = false
+			} else if inBody {
+				stem.WriteString(" ")
+			}
+		case xml.CharData:
+			if inBody {
+				stem.Write(t)
+			}
+		}
+	}
+
+	if !foundItem {
+		return item, errors.New("not a QTI assessmentItem")
+	}
+	item.Stem = collapseSpace(stem.String())
+	return item, nil
+}
+
+// qtiQuestion maps a single-response choice item to a cognitive question
+// keyed like the seeded ones: options "1".."n", the correct option in
+// correct_answer and one scoring mapping for it.
+func qtiQuestion(item qtiItem, category string, points int) (BundleQuestion, *UnsupportedItem) {
+	problem := func(interaction, reason string) *UnsupportedItem {
+		return &UnsupportedItem{Identifier: item.Identifier, Interaction: interaction, Reason: reason}
+	}
+
+	if len(item.Interactions) == 0 {
+		return BundleQuestion{}, problem("", "item has no interaction")
+	}
+	for _, interaction := range item.Interactions {
+		if interaction != "choiceInteraction" {
+			return BundleQuestion{}, problem(interaction, "only choiceInteraction is supported")
+		}
+	}
+	if len(item.Interactions) > 1 {
+		return BundleQuestion{}, problem("choiceInteraction", "items with more than one interaction are not supported")
+	}
+	if item.HasMedia {
+		return BundleQuestion{}, problem("choiceInteraction", "items with images or embedded objects are not supported")
+	}
+
+	choice := item.Choices[0]
+	if choice.MaxChoices != "" && choice.MaxChoices != "1" {
+		return BundleQuestion{}, problem("choiceInteraction", "multiple response choices are not supported")
+	}
+
+	var response *qtiResponseDeclaration
+	for i := range item.Responses {
+		if item.Responses[i].Identifier == choice.ResponseIdentifier {
+			response = &item.Responses[i]
+		}
+	}
+	if response == nil {
+		return BundleQuestion{}, problem("choiceInteraction", "no responseDeclaration for "+choice.ResponseIdentifier)
+	}
+	if response.Cardinality != "single" || len(response.CorrectResponse) != 1 {
+		return BundleQuestion{}, problem("choiceInteraction", "response must have single cardinality and exactly one correct value")
+	}
+	if category == "" {
+		return BundleQuestion{}, problem("choiceInteraction", "no category in metadata and no default category given")
+	}
+
+	text := item.Stem
+	if prompt := collapseSpace(xmlText(choice.Prompt.Inner)); prompt != "" {
+		text = strings.TrimSpace(text + " " + prompt)
+	}
+	if text == "" {
+		text = item.Title
+	}
+	if text == "" {
+		return BundleQuestion{}, problem("choiceInteraction", "item has no question text")
+	}
+
+	question := BundleQuestion{
+		Type:     string(QuestionTypeCognitive),
+		Question: text,
+		Options:  make(map[string]interface{}),
+	}
+	correct := 0
+	for i, c := range choice.Choices {
+		if strings.Contains(c.Inner, "<img") || strings.Contains(c.Inner, "<object") {
+			return BundleQuestion{}, problem("choiceInteraction", "choices with images or embedded objects are not supported")
+		}
+		key := i + 1
+		question.Options[strconv.Itoa(key)] = collapseSpace(xmlText(c.Inner))
+		if c.Identifier == strings.TrimSpace(response.CorrectResponse[0]) {
+			correct = key
+		}
+	}
+	if correct == 0 {
+		return BundleQuestion{}, problem("choiceInteraction", "correct response does not match any choice")
+	}
+
+	question.CorrectAnswer = strconv.Itoa(correct)
+	question.Mappings = []BundleMapping{{AnswerValue: correct, Category: category, Points: points}}
+	return question, nil
+}
+
+func xmlAttr(el xml.StartElement, name string) string {
+	for _, attr := range el.Attr {
+		if attr.Name.Local == name {
+			return attr.Value
+		}
+	}
+	return ""
+}
+
+// xmlText returns the character data of an XHTML fragment, dropping markup.
+func xmlText(fragment string) string {
+	var text strings.Builder
+	decoder := xml.NewDecoder(strings.NewReader("<root>" + fragment + "</root>"))
+	decoder.Strict = false
+	decoder.Entity = xml.HTMLEntity
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			break
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement, xml.EndElement:
+			text.WriteString(" ")
+		}
+	}
+	return text.String()
+}
+
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
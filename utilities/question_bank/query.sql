@@ -0,0 +1,62 @@
+-- name: ListCategories :many
+SELECT * FROM self_assessment_categories
+ORDER BY id;
+
+-- name: ListQuestions :many
+SELECT * FROM self_assessment_questions
+ORDER BY type, id;
+
+-- name: ListMappings :many
+SELECT * FROM self_assessment_mappings
+ORDER BY question_id, answer_value, id;
+
+-- name: UpsertCategory :one
+INSERT INTO self_assessment_categories(
+    name,
+    description
+)VALUES(
+    $1,
+    $2
+)
+ON CONFLICT (name) DO UPDATE
+SET description = EXCLUDED.description
+RETURNING *;
+
+-- name: InsertQuestion :one
+INSERT INTO self_assessment_questions(
+    question,
+    type,
+    options,
+    correct_answer,
+    format,
+    created_at
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5,
+    CURRENT_TIMESTAMP
+) RETURNING *;
+
+-- name: UpdateQuestion :exec
+UPDATE self_assessment_questions
+SET options = $2, correct_answer = $3, format = $4
+WHERE id = $1;
+
+-- name: DeleteQuestionMappings :exec
+DELETE FROM self_assessment_mappings
+WHERE question_id = $1;
+
+-- name: InsertMapping :exec
+INSERT INTO self_assessment_mappings(
+    question_id,
+    answer_value,
+    category_id,
+    points
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4
+);
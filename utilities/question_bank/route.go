@@ -0,0 +1,15 @@
+package question_bank
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesQuestionBank(r *gin.Engine, questionBankHandler *QuestionBankHandler) {
+	admin := r.Group("question-bank")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.GET("/export", questionBankHandler.ExportQuestionBank)
+	admin.POST("/import", questionBankHandler.ImportQuestionBank)
+	admin.POST("/import/qti", questionBankHandler.ImportQTIPackage)
+}
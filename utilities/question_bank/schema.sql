@@ -0,0 +1,30 @@
+CREATE TABLE IF NOT EXISTS self_assessment_categories(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) UNIQUE,
+    description text
+);
+
+CREATE TYPE question_type as ENUM ('personality','cognitive','behavioral');
+
+CREATE TYPE question_format as ENUM ('single_choice','multi_select','ranking','numeric','free_text','open_ended','situational');
+
+CREATE TABLE IF NOT EXISTS self_assessment_questions (
+    id SERIAL PRIMARY KEY,
+    question text not null,
+    type question_type not null,
+    options JSONB NOT NULL DEFAULT '{}'::jsonb,
+    correct_answer VARCHAR(255) null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    format question_format NOT NULL DEFAULT 'single_choice'
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_mappings (
+    id SERIAL PRIMARY KEY,
+    question_id int not null, 
+    answer_value int,
+    category_id int not null,
+    points int,
+    constraint fk_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete SET NULL,
+    constraint fk_category foreign key (category_id) REFERENCES self_assessment_categories(id) on delete SET NULL,
+    constraint uq_mapping_option_category unique (question_id, answer_value, category_id)
+);
@@ -0,0 +1,80 @@
+package self_assessment
+
+import "math"
+
+const (
+	// defaultTargetSE and defaultMaxItems are the stopping rules used when
+	// the server is not configured with its own.
+	defaultTargetSE = 0.4
+	defaultMaxItems = 15
+
+	// The ability scale is integrated over this grid when estimating theta.
+	thetaMin  = -4.0
+	thetaMax  = 4.0
+	thetaStep = 0.05
+)
+
+// probability is the two-parameter logistic chance that a candidate of
+// ability theta answers an item with discrimination a and difficulty b correctly.
+func probability(theta, a, b float64) float64 {
+	return 1 / (1 + math.Exp(-a*(theta-b)))
+}
+
+// information is the Fisher information an item contributes at theta.
+func information(theta, a, b float64) float64 {
+	p := probability(theta, a, b)
+	return a * a * p * (1 - p)
+}
+
+// estimateTheta returns the expected a posteriori ability estimate and its
+// posterior standard deviation under a standard normal prior. Unlike maximum
+// likelihood this stays finite when every answer so far is right or wrong.
+func estimateTheta(responses []ListAdaptiveResponsesRow) (float64, float64) {
+	var thetas, logPosterior []float64
+	maxLog := math.Inf(-1)
+	for theta := thetaMin; theta <= thetaMax+thetaStep/2; theta += thetaStep {
+		logP := -theta * theta / 2
+		for _, r := range responses {
+			p := probability(theta, r.Discrimination, r.Difficulty)
+			if r.Correct {
+				logP += math.Log(p)
+			} else {
+				logP += math.Log(1 - p)
+			}
+		}
+		thetas = append(thetas, theta)
+		logPosterior = append(logPosterior, logP)
+		maxLog = math.Max(maxLog, logP)
+	}
+
+	var total, mean float64
+	weights := make([]float64, len(thetas))
+	for i, logP := range logPosterior {
+		weights[i] = math.Exp(logP - maxLog)
+		total += weights[i]
+		mean += thetas[i] * weights[i]
+	}
+	mean /= total
+
+	var variance float64
+	for i, theta := range thetas {
+		variance += (theta - mean) * (theta - mean) * weights[i]
+	}
+	return mean, math.Sqrt(variance / total)
+}
+
+// nextItem picks the remaining item that is most informative at theta.
+func nextItem(items []ListAdaptiveCandidateItemsRow, theta float64) (int32, bool) {
+	best, bestInfo := int32(0), -1.0
+	for _, item := range items {
+		if info := information(theta, item.Discrimination, item.Difficulty); info > bestInfo {
+			best, bestInfo = item.ID, info
+		}
+	}
+	return best, bestInfo >= 0
+}
+
+// adaptiveDone reports whether a session has met either stopping rule.
+func adaptiveDone(state AdaptiveSession, answered int, standardError float64) bool {
+	return answered >= int(state.MaxItems) || standardError <= state.TargetSe
+}
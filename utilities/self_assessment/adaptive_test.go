@@ -0,0 +1,81 @@
+package self_assessment
+
+import (
+	"math"
+	"testing"
+)
+
+func TestEstimateTheta(t *testing.T) {
+	item := func(difficulty float64, correct bool) ListAdaptiveResponsesRow {
+		return ListAdaptiveResponsesRow{Discrimination: 1.5, Difficulty: difficulty, Correct: correct}
+	}
+	tests := []struct {
+		name      string
+		responses []ListAdaptiveResponsesRow
+		// wantTheta is checked to within thetaTolerance; the other bounds
+		// describe where the estimate must fall.
+		wantTheta      float64
+		thetaTolerance float64
+		maxSE          float64
+	}{
+		{
+			name:           "no responses falls back to the prior",
+			responses:      nil,
+			wantTheta:      0,
+			thetaTolerance: 0.01,
+			maxSE:          1.01,
+		},
+		{
+			name:           "one right and one wrong at the same difficulty",
+			responses:      []ListAdaptiveResponsesRow{item(0, true), item(0, false)},
+			wantTheta:      0,
+			thetaTolerance: 0.01,
+			maxSE:          1,
+		},
+		{
+			name:           "all right stays finite",
+			responses:      []ListAdaptiveResponsesRow{item(0, true), item(1, true), item(2, true)},
+			wantTheta:      1.5,
+			thetaTolerance: 1,
+			maxSE:          1,
+		},
+		{
+			name:           "all wrong stays finite",
+			responses:      []ListAdaptiveResponsesRow{item(0, false), item(-1, false), item(-2, false)},
+			wantTheta:      -1.5,
+			thetaTolerance: 1,
+			maxSE:          1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			theta, se := estimateTheta(tt.responses)
+			if math.IsNaN(theta) || math.IsInf(theta, 0) || theta < thetaMin || theta > thetaMax {
+				t.Fatalf("theta = %v, want a finite value on the grid", theta)
+			}
+			if math.Abs(theta-tt.wantTheta) > tt.thetaTolerance {
+				t.Errorf("theta = %.3f, want %.3f ± %.2f", theta, tt.wantTheta, tt.thetaTolerance)
+			}
+			if se <= 0 || se > tt.maxSE {
+				t.Errorf("standard error = %.3f, want in (0, %.2f]", se, tt.maxSE)
+			}
+		})
+	}
+}
+
+func TestEstimateThetaNarrowsWithResponses(t *testing.T) {
+	var responses []ListAdaptiveResponsesRow
+	_, previous := estimateTheta(responses)
+	for i := 0; i < 10; i++ {
+		responses = append(responses, ListAdaptiveResponsesRow{
+			Discrimination: 1.2,
+			Difficulty:     0,
+			Correct:        i%2 == 0,
+		})
+		_, se := estimateTheta(responses)
+		if se >= previous {
+			t.Fatalf("after %d responses the standard error rose from %.3f to %.3f", i+1, previous, se)
+		}
+		previous = se
+	}
+}
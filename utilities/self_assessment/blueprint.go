@@ -0,0 +1,168 @@
+package self_assessment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// errItemBankExhausted is returned when a blueprint asks for more questions
+// than the bank can supply after exposure limits are applied.
+var errItemBankExhausted = errors.New("not enough eligible questions in the item bank")
+
+// SessionQuestion is a question as shown in one session. Options are keyed
+// "1".."n" in display order, which may differ from the stored order.
+type SessionQuestion struct {
+	ID       int32                      `json:"id"`
+	Question string                     `json:"question"`
+	Type     QuestionType               `json:"type"`
+	Format   QuestionFormat             `json:"format"`
+	Options  map[string]json.RawMessage `json:"options"`
+}
+
+// drawQuestions picks the questions for a new session. Each blueprint section
+// draws at random from the questions mapped to its category, then the whole
+// set, validity items included, is shuffled so sections are interleaved.
+// Without a blueprint every question of the type outside a trait model is
+// used, in random order.
+func drawQuestions(ctx context.Context, q *Queries, blueprint *AssessmentBlueprint, assessmentType string) ([]int32, error) {
+	if blueprint == nil {
+		return q.ListQuestionIDsByType(ctx, QuestionType(assessmentType))
+	}
+
+	sections, err := q.ListBlueprintSections(ctx, blueprint.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	drawn := []int32{}
+	for _, section := range sections {
+		ids, err := q.DrawSectionQuestions(ctx, DrawSectionQuestionsParams{
+			Type:          QuestionType(assessmentType),
+			CategoryID:    section.CategoryID,
+			ExcludeIds:    drawn,
+			MaxExposures:  blueprint.MaxItemExposures,
+			QuestionCount: section.QuestionCount,
+		})
+		if err != nil {
+			return nil, err
+		}
+		if len(ids) < int(section.QuestionCount) {
+			return nil, fmt.Errorf("%w: category %d needs %d, %d available",
+				errItemBankExhausted, section.CategoryID, section.QuestionCount, len(ids))
+		}
+		drawn = append(drawn, ids...)
+	}
+
+	// Validity items map to no category, so no section draws them; they are
+	// asked in every session of their type.
+	validity, err := q.ListValidityQuestionIDs(ctx, QuestionType(assessmentType))
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range validity {
+		if !slices.Contains(drawn, id) {
+			drawn = append(drawn, id)
+		}
+	}
+
+	rand.Shuffle(len(drawn), func(i, j int) { drawn[i], drawn[j] = drawn[j], drawn[i] })
+	return drawn, nil
+}
+
+// shufflesOptions reports whether an assessment type shuffles options when
+// no blueprint says otherwise. Behavioral and personality items are answered
+// on an ordered scale, so only cognitive items are shuffled.
+func shufflesOptions(assessmentType string) bool {
+	return assessmentType == "cognitive"
+}
+
+// optionOrder returns the stored option keys in the order they will be
+// displayed. An unshuffled question gets an empty order, meaning options are
+// shown as stored.
+func optionOrder(options []byte, shuffle bool) ([]string, error) {
+	if !shuffle {
+		return []string{}, nil
+	}
+	var parsed map[string]json.RawMessage
+	if err := json.Unmarshal(options, &parsed); err != nil {
+		return nil, err
+	}
+	keys := make([]string, 0, len(parsed))
+	for key := range parsed {
+		keys = append(keys, key)
+	}
+	sortOptionKeys(keys)
+	rand.Shuffle(len(keys), func(i, j int) { keys[i], keys[j] = keys[j], keys[i] })
+	return keys, nil
+}
+
+// presentQuestion re-keys a session question's options in display order.
+func presentQuestion(row ListSessionQuestionsRow) (SessionQuestion, error) {
+	question := SessionQuestion{ID: row.ID, Question: row.Question, Type: row.Type, Format: row.Format}
+	if !showsOptions(row.Format) {
+		question.Options = map[string]json.RawMessage{}
+		return question, nil
+	}
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(row.Options, &options); err != nil {
+		return question, err
+	}
+	var order []string
+	if err := json.Unmarshal(row.OptionOrder, &order); err != nil {
+		return question, err
+	}
+	if len(order) == 0 {
+		question.Options = options
+		return question, nil
+	}
+
+	question.Options = make(map[string]json.RawMessage, len(order))
+	for i, key := range order {
+		question.Options[strconv.Itoa(i+1)] = options[key]
+	}
+	return question, nil
+}
+
+// sessionOptionOrders indexes the display order of every question in a
+// session by question ID.
+func sessionOptionOrders(rows []ListSessionQuestionsRow) (map[int32][]string, error) {
+	orders := make(map[int32][]string, len(rows))
+	for _, row := range rows {
+		var order []string
+		if err := json.Unmarshal(row.OptionOrder, &order); err != nil {
+			return nil, err
+		}
+		orders[row.ID] = order
+	}
+	return orders, nil
+}
+
+// originalAnswer maps an option key as displayed back to the stored key.
+func originalAnswer(order []string, displayed string) (string, error) {
+	if len(order) == 0 {
+		return displayed, nil
+	}
+	position, err := strconv.Atoi(displayed)
+	if err != nil || position < 1 || position > len(order) {
+		return "", fmt.Errorf("answer %q is not one of the options", displayed)
+	}
+	return order[position-1], nil
+}
+
+// sortOptionKeys orders keys numerically where possible so "10" follows "9".
+func sortOptionKeys(keys []string) {
+	sort.Slice(keys, func(i, j int) bool {
+		a, errA := strconv.Atoi(keys[i])
+		b, errB := strconv.Atoi(keys[j])
+		if errA == nil && errB == nil {
+			return a < b
+		}
+		return keys[i] < keys[j]
+	})
+}
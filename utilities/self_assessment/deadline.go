@@ -0,0 +1,72 @@
+package self_assessment
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// deadlineGrace lets answers sent just before the deadline arrive after it.
+const deadlineGrace = time.Minute
+
+// AccommodationSettings are the accommodations a candidate sees on their own
+// session. The reason and approver are kept for staff.
+type AccommodationSettings struct {
+	TimeMultiplier     float64  `json:"time_multiplier"`
+	BreakMinutes       int32    `json:"break_minutes"`
+	AlternativeFormats []string `json:"alternative_formats"`
+}
+
+// sessionDeadline works out when a timed session must be submitted by: the
+// blueprint's time limit, stretched by the accommodation's time multiplier,
+// plus the accommodation's break allowance. Untimed sessions have none.
+func sessionDeadline(startedAt pgtype.Timestamp, timeLimitMinutes pgtype.Int4, accommodation *CandidateAccommodation) *time.Time {
+	if !startedAt.Valid || !timeLimitMinutes.Valid {
+		return nil
+	}
+	limit := time.Duration(timeLimitMinutes.Int32) * time.Minute
+	if accommodation != nil {
+		limit = time.Duration(float64(limit) * accommodation.TimeMultiplier).Round(time.Second)
+		limit += time.Duration(accommodation.BreakMinutes) * time.Minute
+	}
+	deadline := startedAt.Time.Add(limit)
+	return &deadline
+}
+
+// sessionTiming loads what a session's deadline depends on and works it out.
+// The accommodation is nil when the session runs under none.
+func (h *SelfAssessmentHandler) sessionTiming(ctx context.Context, session UserAssessmentSession) (*time.Time, *CandidateAccommodation, error) {
+	var accommodation *CandidateAccommodation
+	found, err := h.queries.GetSessionAccommodation(ctx, session.ID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil, err
+	}
+	if err == nil {
+		accommodation = &found
+	}
+
+	if !session.BlueprintID.Valid {
+		return nil, accommodation, nil
+	}
+	blueprint, err := h.queries.GetBlueprint(ctx, session.BlueprintID.Int32)
+	if err != nil {
+		return nil, nil, err
+	}
+	return sessionDeadline(session.StartedAt, blueprint.TimeLimitMinutes, accommodation), accommodation, nil
+}
+
+// accommodationSettings strips an accommodation down to what the candidate
+// sees.
+func accommodationSettings(accommodation *CandidateAccommodation) *AccommodationSettings {
+	if accommodation == nil {
+		return nil
+	}
+	return &AccommodationSettings{
+		TimeMultiplier:     accommodation.TimeMultiplier,
+		BreakMinutes:       accommodation.BreakMinutes,
+		AlternativeFormats: accommodation.AlternativeFormats,
+	}
+}
@@ -0,0 +1,253 @@
+package self_assessment
+
+import (
+	"backend/pkg/formats"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TypedResponse is a candidate's answer to a question in one of the typed
+// formats. Only the field matching the question's format is used.
+type TypedResponse struct {
+	Choices []string `json:"choices"`
+	Order   []string `json:"order"`
+	Value   *float64 `json:"value"`
+	Text    *string  `json:"text"`
+}
+
+// CategoryPoints is what a typed answer contributes to one category.
+type CategoryPoints struct {
+	CategoryID int32 `json:"category_id"`
+	Points     int32 `json:"points"`
+}
+
+// typedAnswer is a typed answer as stored in user_answers.answer_value. Scores
+// are worked out when the answer is saved so score calculation only sums them.
+type typedAnswer struct {
+	Format  QuestionFormat   `json:"format"`
+	Choices []string         `json:"choices,omitempty"`
+	Order   []string         `json:"order,omitempty"`
+	Value   *float64         `json:"value,omitempty"`
+	Text    *string          `json:"text,omitempty"`
+	Matched string           `json:"matched,omitempty"`
+	Scores  []CategoryPoints `json:"scores"`
+}
+
+// showsOptions reports whether a format's options are shown to candidates.
+// Numeric and free-text options hold the accepted answers instead, and
+// open-ended questions have none.
+func showsOptions(format QuestionFormat) bool {
+	return format != QuestionFormatNumeric && format != QuestionFormatFreeText && format != QuestionFormatOpenEnded
+}
+
+// originalKeys maps the option keys of a response from display order back to
+// the stored keys.
+func (r *TypedResponse) originalKeys(order []string) error {
+	for _, keys := range [][]string{r.Choices, r.Order} {
+		for i, displayed := range keys {
+			original, err := originalAnswer(order, displayed)
+			if err != nil {
+				return err
+			}
+			keys[i] = original
+		}
+	}
+	return nil
+}
+
+// scoreTypedAnswer checks a response against its question and works out the
+// points it earns in every category the question maps to.
+func scoreTypedAnswer(question SelfAssessmentQuestion, mappings []SelfAssessmentMapping, response TypedResponse) (typedAnswer, error) {
+	answer := typedAnswer{Format: question.Format}
+
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(question.Options, &options); err != nil {
+		return answer, fmt.Errorf("question has invalid options")
+	}
+	// Every mapped category gets a score, even when the answer earns nothing in it.
+	byOption := make(map[string][]SelfAssessmentMapping)
+	points := make(map[int32]int32)
+	for _, mapping := range mappings {
+		key := strconv.Itoa(int(mapping.AnswerValue.Int32))
+		byOption[key] = append(byOption[key], mapping)
+		points[mapping.CategoryID] = 0
+	}
+
+	switch question.Format {
+	case QuestionFormatMultiSelect:
+		if len(response.Choices) == 0 {
+			return answer, errors.New("choose at least one option")
+		}
+		seen := make(map[string]bool)
+		for _, choice := range response.Choices {
+			if _, ok := options[choice]; !ok {
+				return answer, fmt.Errorf("choice %q is not one of the options", choice)
+			}
+			if seen[choice] {
+				return answer, fmt.Errorf("choice %q is selected twice", choice)
+			}
+			seen[choice] = true
+			for _, mapping := range byOption[choice] {
+				points[mapping.CategoryID] += mapping.Points.Int32
+			}
+		}
+		// Wrong choices can cancel out right ones but not push a question
+		// below zero.
+		for category, total := range points {
+			if total < 0 {
+				points[category] = 0
+			}
+		}
+		answer.Choices = response.Choices
+
+	case QuestionFormatRanking:
+		if len(response.Order) != len(options) {
+			return answer, fmt.Errorf("rank all %d options", len(options))
+		}
+		seen := make(map[string]bool)
+		for position, key := range response.Order {
+			if _, ok := options[key]; !ok {
+				return answer, fmt.Errorf("option %q is not one of the options", key)
+			}
+			if seen[key] {
+				return answer, fmt.Errorf("option %q is ranked twice", key)
+			}
+			seen[key] = true
+			// The top option earns its points once per option ranked below it.
+			weight := int32(len(response.Order) - 1 - position)
+			for _, mapping := range byOption[key] {
+				points[mapping.CategoryID] += mapping.Points.Int32 * weight
+			}
+		}
+		answer.Order = response.Order
+
+	case QuestionFormatNumeric:
+		if response.Value == nil || math.IsNaN(*response.Value) || math.IsInf(*response.Value, 0) {
+			return answer, errors.New("a numeric value is required")
+		}
+		// The closest accepted range wins, so narrower ranges can give
+		// more credit than a wide fallback.
+		bestDistance := math.Inf(1)
+		for _, key := range sortedKeys(options) {
+			var r formats.NumericRange
+			if err := json.Unmarshal(options[key], &r); err != nil {
+				return answer, fmt.Errorf("question has invalid options")
+			}
+			distance := math.Abs(*response.Value - r.Value)
+			if distance <= r.Tolerance && distance < bestDistance {
+				answer.Matched, bestDistance = key, distance
+			}
+		}
+		answer.Value = response.Value
+
+	case QuestionFormatFreeText:
+		if response.Text == nil || strings.TrimSpace(*response.Text) == "" {
+			return answer, errors.New("an answer is required")
+		}
+		if utf8.RuneCountInString(*response.Text) > formats.MaxTextLength {
+			return answer, fmt.Errorf("answers are limited to %d characters", formats.MaxTextLength)
+		}
+		given := formats.NormalizeText(*response.Text)
+		for _, key := range sortedKeys(options) {
+			var accepted string
+			if err := json.Unmarshal(options[key], &accepted); err != nil {
+				return answer, fmt.Errorf("question has invalid options")
+			}
+			if formats.NormalizeText(accepted) == given {
+				answer.Matched = key
+				break
+			}
+		}
+		answer.Text = response.Text
+
+	case QuestionFormatSituational:
+		// One response to the scenario is chosen. Its points, negative ones
+		// included, count in full towards every category it maps to.
+		if len(response.Choices) != 1 {
+			return answer, errors.New("choose exactly one response")
+		}
+		if _, ok := options[response.Choices[0]]; !ok {
+			return answer, fmt.Errorf("choice %q is not one of the options", response.Choices[0])
+		}
+		answer.Choices = response.Choices
+		answer.Matched = response.Choices[0]
+
+	case QuestionFormatOpenEnded:
+		// Open-ended answers earn no points until they are graded.
+		if response.Text == nil || strings.TrimSpace(*response.Text) == "" {
+			return answer, errors.New("an answer is required")
+		}
+		if utf8.RuneCountInString(*response.Text) > formats.MaxEssayLength {
+			return answer, fmt.Errorf("answers are limited to %d characters", formats.MaxEssayLength)
+		}
+		answer.Text = response.Text
+
+	default:
+		return answer, fmt.Errorf("%s questions take an answer_value", question.Format)
+	}
+
+	if answer.Matched != "" {
+		for _, mapping := range byOption[answer.Matched] {
+			points[mapping.CategoryID] += mapping.Points.Int32
+		}
+	}
+
+	answer.Scores = make([]CategoryPoints, 0, len(points))
+	for category, total := range points {
+		answer.Scores = append(answer.Scores, CategoryPoints{CategoryID: category, Points: total})
+	}
+	sort.Slice(answer.Scores, func(i, j int) bool { return answer.Scores[i].CategoryID < answer.Scores[j].CategoryID })
+	return answer, nil
+}
+
+// MappingInput maps one answer option to a category. Points may be negative,
+// so a response can count against a category.
+type MappingInput struct {
+	AnswerValue int32 `json:"answer_value"`
+	CategoryID  int32 `json:"category_id" binding:"required"`
+	Points      int32 `json:"points"`
+}
+
+// validateMappings checks a question's mappings against its options. Each
+// option maps to a category at most once, and a situational question needs
+// at least one mapping to be scored at all.
+func validateMappings(question SelfAssessmentQuestion, mappings []MappingInput) error {
+	if question.Format == QuestionFormatOpenEnded {
+		return errors.New("open-ended questions are scored with a rubric")
+	}
+	if question.Format == QuestionFormatSituational && len(mappings) == 0 {
+		return errors.New("situational questions need at least one mapping")
+	}
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(question.Options, &options); err != nil {
+		return fmt.Errorf("question has invalid options")
+	}
+	type pair struct{ option, category int32 }
+	seen := make(map[pair]bool)
+	for i, mapping := range mappings {
+		if _, ok := options[strconv.Itoa(int(mapping.AnswerValue))]; !ok {
+			return fmt.Errorf("mapping %d: answer value %d is not one of the options", i+1, mapping.AnswerValue)
+		}
+		key := pair{mapping.AnswerValue, mapping.CategoryID}
+		if seen[key] {
+			return fmt.Errorf("mapping %d: option %d already maps to category %d", i+1, mapping.AnswerValue, mapping.CategoryID)
+		}
+		seen[key] = true
+	}
+	return nil
+}
+
+func sortedKeys(options map[string]json.RawMessage) []string {
+	keys := make([]string, 0, len(options))
+	for key := range options {
+		keys = append(keys, key)
+	}
+	sortOptionKeys(keys)
+	return keys
+}
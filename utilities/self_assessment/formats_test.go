@@ -0,0 +1,175 @@
+package self_assessment
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestScoreTypedAnswer(t *testing.T) {
+	mapping := func(option, category, points int32) SelfAssessmentMapping {
+		return SelfAssessmentMapping{
+			AnswerValue: pgtype.Int4{Int32: option, Valid: true},
+			CategoryID:  category,
+			Points:      pgtype.Int4{Int32: points, Valid: true},
+		}
+	}
+	value := func(v float64) *float64 { return &v }
+	text := func(s string) *string { return &s }
+
+	tests := []struct {
+		name     string
+		format   QuestionFormat
+		options  string
+		mappings []SelfAssessmentMapping
+		response TypedResponse
+		want     []CategoryPoints
+		matched  string
+		wantErr  bool
+	}{
+		{
+			name:     "multi select sums the chosen options",
+			format:   QuestionFormatMultiSelect,
+			options:  `{"1":"a","2":"b","3":"c"}`,
+			mappings: []SelfAssessmentMapping{mapping(1, 10, 2), mapping(2, 10, 3), mapping(3, 20, 4)},
+			response: TypedResponse{Choices: []string{"1", "2"}},
+			want:     []CategoryPoints{{CategoryID: 10, Points: 5}, {CategoryID: 20, Points: 0}},
+		},
+		{
+			name:     "multi select never goes below zero",
+			format:   QuestionFormatMultiSelect,
+			options:  `{"1":"a","2":"b"}`,
+			mappings: []SelfAssessmentMapping{mapping(1, 10, 1), mapping(2, 10, -3)},
+			response: TypedResponse{Choices: []string{"1", "2"}},
+			want:     []CategoryPoints{{CategoryID: 10, Points: 0}},
+		},
+		{
+			name:     "multi select rejects a repeated choice",
+			format:   QuestionFormatMultiSelect,
+			options:  `{"1":"a","2":"b"}`,
+			response: TypedResponse{Choices: []string{"1", "1"}},
+			wantErr:  true,
+		},
+		{
+			name:     "multi select rejects an unknown choice",
+			format:   QuestionFormatMultiSelect,
+			options:  `{"1":"a"}`,
+			response: TypedResponse{Choices: []string{"9"}},
+			wantErr:  true,
+		},
+		{
+			name:     "ranking weights points by position",
+			format:   QuestionFormatRanking,
+			options:  `{"1":"a","2":"b","3":"c"}`,
+			mappings: []SelfAssessmentMapping{mapping(1, 10, 1), mapping(3, 10, 2)},
+			response: TypedResponse{Order: []string{"3", "1", "2"}},
+			want:     []CategoryPoints{{CategoryID: 10, Points: 2*2 + 1*1}},
+		},
+		{
+			name:     "ranking must rank every option",
+			format:   QuestionFormatRanking,
+			options:  `{"1":"a","2":"b","3":"c"}`,
+			response: TypedResponse{Order: []string{"3", "1"}},
+			wantErr:  true,
+		},
+		{
+			name:     "numeric picks the closest accepted range",
+			format:   QuestionFormatNumeric,
+			options:  `{"1":{"value":10,"tolerance":5},"2":{"value":12,"tolerance":0.5}}`,
+			mappings: []SelfAssessmentMapping{mapping(1, 10, 1), mapping(2, 10, 3)},
+			response: TypedResponse{Value: value(12.2)},
+			want:     []CategoryPoints{{CategoryID: 10, Points: 3}},
+			matched:  "2",
+		},
+		{
+			name:     "numeric outside every range earns nothing",
+			format:   QuestionFormatNumeric,
+			options:  `{"1":{"value":10,"tolerance":1}}`,
+			mappings: []SelfAssessmentMapping{mapping(1, 10, 1)},
+			response: TypedResponse{Value: value(20)},
+			want:     []CategoryPoints{{CategoryID: 10, Points: 0}},
+		},
+		{
+			name:     "numeric needs a value",
+			format:   QuestionFormatNumeric,
+			options:  `{"1":{"value":10,"tolerance":1}}`,
+			response: TypedResponse{},
+			wantErr:  true,
+		},
+		{
+			name:     "free text matches ignoring case and spacing",
+			format:   QuestionFormatFreeText,
+			options:  `{"1":"New York","2":"Boston"}`,
+			mappings: []SelfAssessmentMapping{mapping(1, 10, 2)},
+			response: TypedResponse{Text: text("  new   YORK ")},
+			want:     []CategoryPoints{{CategoryID: 10, Points: 2}},
+			matched:  "1",
+		},
+		{
+			name:     "free text needs an answer",
+			format:   QuestionFormatFreeText,
+			options:  `{"1":"x"}`,
+			response: TypedResponse{Text: text("   ")},
+			wantErr:  true,
+		},
+		{
+			name:     "situational counts negative points",
+			format:   QuestionFormatSituational,
+			options:  `{"1":"a","2":"b"}`,
+			mappings: []SelfAssessmentMapping{mapping(1, 10, 2), mapping(2, 10, -1)},
+			response: TypedResponse{Choices: []string{"2"}},
+			want:     []CategoryPoints{{CategoryID: 10, Points: -1}},
+			matched:  "2",
+		},
+		{
+			name:     "situational takes exactly one choice",
+			format:   QuestionFormatSituational,
+			options:  `{"1":"a","2":"b"}`,
+			response: TypedResponse{Choices: []string{"1", "2"}},
+			wantErr:  true,
+		},
+		{
+			name:     "open ended earns nothing until graded",
+			format:   QuestionFormatOpenEnded,
+			options:  `{}`,
+			response: TypedResponse{Text: text("An essay")},
+			want:     []CategoryPoints{},
+		},
+		{
+			name:     "single choice is not a typed format",
+			format:   QuestionFormatSingleChoice,
+			options:  `{"1":"a"}`,
+			response: TypedResponse{Choices: []string{"1"}},
+			wantErr:  true,
+		},
+		{
+			name:     "invalid options",
+			format:   QuestionFormatMultiSelect,
+			options:  `[]`,
+			response: TypedResponse{Choices: []string{"1"}},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			question := SelfAssessmentQuestion{Format: tt.format, Options: []byte(tt.options)}
+			answer, err := scoreTypedAnswer(question, tt.mappings, tt.response)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("scoreTypedAnswer = %+v, want an error", answer)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("scoreTypedAnswer: %v", err)
+			}
+			if !reflect.DeepEqual(answer.Scores, tt.want) {
+				t.Errorf("scores = %+v, want %+v", answer.Scores, tt.want)
+			}
+			if answer.Matched != tt.matched {
+				t.Errorf("matched = %q, want %q", answer.Matched, tt.matched)
+			}
+		})
+	}
+}
@@ -0,0 +1,289 @@
+package self_assessment
+
+import (
+	"backend/pkg/events"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// maxCriterionPoints bounds a single rubric criterion.
+const maxCriterionPoints = 100
+
+// RubricCriterion is one aspect an open-ended answer is graded on. Its points
+// count towards CategoryID.
+type RubricCriterion struct {
+	Key         string `json:"key"`
+	Description string `json:"description"`
+	MaxPoints   int32  `json:"max_points"`
+	CategoryID  int32  `json:"category_id"`
+}
+
+// Grade is one grader's marking of an item as shown to staff.
+type Grade struct {
+	GraderID    int32            `json:"grader_id"`
+	Resolver    bool             `json:"resolver"`
+	Scores      map[string]int32 `json:"scores,omitempty"`
+	Total       *int32           `json:"total,omitempty"`
+	Comment     string           `json:"comment,omitempty"`
+	AssignedAt  pgtype.Timestamp `json:"assigned_at"`
+	SubmittedAt pgtype.Timestamp `json:"submitted_at"`
+}
+
+// validateCriteria checks a rubric before it is stored.
+func validateCriteria(criteria []RubricCriterion) error {
+	if len(criteria) == 0 {
+		return errors.New("a rubric needs at least one criterion")
+	}
+	seen := make(map[string]bool)
+	for _, criterion := range criteria {
+		if criterion.Key == "" {
+			return errors.New("every criterion needs a key")
+		}
+		if seen[criterion.Key] {
+			return fmt.Errorf("criterion %q is listed twice", criterion.Key)
+		}
+		seen[criterion.Key] = true
+		if criterion.MaxPoints <= 0 || criterion.MaxPoints > maxCriterionPoints {
+			return fmt.Errorf("criterion %q must be worth between 1 and %d points", criterion.Key, maxCriterionPoints)
+		}
+		if criterion.CategoryID <= 0 {
+			return fmt.Errorf("criterion %q needs a category_id", criterion.Key)
+		}
+	}
+	return nil
+}
+
+// validateGrade checks that a grade scores every criterion of the rubric
+// within its range and nothing else.
+func validateGrade(criteria []RubricCriterion, scores map[string]int32) error {
+	for _, criterion := range criteria {
+		points, ok := scores[criterion.Key]
+		if !ok {
+			return fmt.Errorf("criterion %q is not scored", criterion.Key)
+		}
+		if points < 0 || points > criterion.MaxPoints {
+			return fmt.Errorf("criterion %q must be scored between 0 and %d", criterion.Key, criterion.MaxPoints)
+		}
+	}
+	if len(scores) != len(criteria) {
+		known := make(map[string]bool, len(criteria))
+		for _, criterion := range criteria {
+			known[criterion.Key] = true
+		}
+		for key := range scores {
+			if !known[key] {
+				return fmt.Errorf("criterion %q is not part of the rubric", key)
+			}
+		}
+	}
+	return nil
+}
+
+func gradeTotal(scores map[string]int32) int32 {
+	var total int32
+	for _, points := range scores {
+		total += points
+	}
+	return total
+}
+
+// resolveItem decides where an item stands once a grade is submitted. A
+// resolver's grade is final. Otherwise the item waits for its required
+// grades; two grades within maxDisagreement of each other are averaged per
+// criterion, and grades further apart send the item to a resolver.
+func resolveItem(item GradingItem, maxDisagreement int32, assignments []GradingAssignment) (GradingStatus, map[string]int32, error) {
+	var grades []map[string]int32
+	for _, assignment := range assignments {
+		if !assignment.SubmittedAt.Valid {
+			continue
+		}
+		var scores map[string]int32
+		if err := json.Unmarshal(assignment.CriterionScores, &scores); err != nil {
+			return "", nil, err
+		}
+		if assignment.Resolver {
+			return GradingStatusGraded, scores, nil
+		}
+		grades = append(grades, scores)
+	}
+
+	if len(grades) < int(item.RequiredGrades) {
+		return item.Status, nil, nil
+	}
+	if len(grades) == 1 {
+		return GradingStatusGraded, grades[0], nil
+	}
+
+	first, second := grades[0], grades[1]
+	difference := gradeTotal(first) - gradeTotal(second)
+	if difference < 0 {
+		difference = -difference
+	}
+	if difference > maxDisagreement {
+		return GradingStatusDisputed, nil, nil
+	}
+	final := make(map[string]int32, len(first))
+	for key, points := range first {
+		final[key] = int32(math.Round(float64(points+second[key]) / 2))
+	}
+	return GradingStatusGraded, final, nil
+}
+
+// categoryPoints totals final criterion scores per category.
+func categoryPoints(criteria []RubricCriterion, final map[string]int32) []CategoryPoints {
+	totals := make(map[int32]int32)
+	for _, criterion := range criteria {
+		totals[criterion.CategoryID] += final[criterion.Key]
+	}
+	points := make([]CategoryPoints, 0, len(totals))
+	for category, total := range totals {
+		points = append(points, CategoryPoints{CategoryID: category, Points: total})
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].CategoryID < points[j].CategoryID })
+	return points
+}
+
+// presentGrades turns assignments into grades for a viewer. Until a grader
+// has submitted their own grade they cannot see anyone else's, so the
+// second mark stays independent of the first.
+func presentGrades(assignments []GradingAssignment, viewerID int32) ([]Grade, error) {
+	blind := false
+	for _, assignment := range assignments {
+		if assignment.GraderID == viewerID && !assignment.SubmittedAt.Valid {
+			blind = true
+		}
+	}
+
+	grades := make([]Grade, 0, len(assignments))
+	for _, assignment := range assignments {
+		grade := Grade{
+			GraderID:    assignment.GraderID,
+			Resolver:    assignment.Resolver,
+			AssignedAt:  assignment.AssignedAt,
+			SubmittedAt: assignment.SubmittedAt,
+		}
+		if assignment.SubmittedAt.Valid && !blind {
+			if err := json.Unmarshal(assignment.CriterionScores, &grade.Scores); err != nil {
+				return nil, err
+			}
+			total := gradeTotal(grade.Scores)
+			grade.Total = &total
+			grade.Comment = assignment.Comment.String
+		}
+		grades = append(grades, grade)
+	}
+	return grades, nil
+}
+
+// calculateScores works out a session's category and validity scores from
+// its answers.
+func calculateScores(ctx context.Context, q *Queries, sessionID int32, assessmentType string) error {
+	session := pgtype.Int4{Int32: sessionID, Valid: true}
+	var err error
+	switch assessmentType {
+	case "behavioral":
+		err = q.CalculateBehavioralScores(ctx, session)
+	case "personality":
+		err = q.CalculatePersonalityScores(ctx, session)
+	case "cognitive":
+		err = q.CalculateCognitiveScores(ctx, session)
+	default:
+		err = fmt.Errorf("unknown assessment type %q", assessmentType)
+	}
+	if err != nil {
+		return err
+	}
+	return q.ScoreValidityScales(ctx, sessionID)
+}
+
+// finalizeSession scores a submitted session, marks it complete and records
+// the scores and the completion in the outbox.
+func finalizeSession(ctx context.Context, q *Queries, sessionID, userID int32, assessmentType string) error {
+	if err := calculateScores(ctx, q, sessionID, assessmentType); err != nil {
+		return err
+	}
+	if err := enqueueScored(ctx, q, sessionID, userID, assessmentType); err != nil {
+		return err
+	}
+	if err := q.CompleteAssessmentSession(ctx, sessionID); err != nil {
+		return err
+	}
+	return enqueueCompleted(ctx, q, sessionID, userID, assessmentType)
+}
+
+// enqueueCompleted records an assessment.completed event. Run it in the
+// transaction that completes the session, so the event is published exactly
+// when the completion sticks.
+func enqueueCompleted(ctx context.Context, q *Queries, sessionID, userID int32, assessmentType string) error {
+	payload, err := json.Marshal(events.AssessmentCompletedData{
+		SessionID:      sessionID,
+		UserID:         userID,
+		AssessmentType: assessmentType,
+		CompletedAt:    time.Now().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	return q.EnqueueOutboxEvent(ctx, EnqueueOutboxEventParams{
+		EventType: events.AssessmentCompleted,
+		DedupKey:  events.DedupKey(events.AssessmentCompleted, sessionID),
+		Payload:   payload,
+	})
+}
+
+// enqueueScored records a scores.calculated event carrying the session's
+// category scores. Run it in the transaction that calculated them.
+func enqueueScored(ctx context.Context, q *Queries, sessionID, userID int32, assessmentType string) error {
+	rows, err := q.GetSessionScores(ctx, pgtype.Int4{Int32: sessionID, Valid: true})
+	if err != nil {
+		return err
+	}
+	scores := make([]events.CategoryScore, 0, len(rows))
+	for _, row := range rows {
+		scores = append(scores, events.CategoryScore{
+			CategoryID:   row.CategoryID.Int32,
+			CategoryName: row.CategoryName.String,
+			Score:        row.Score.Int32,
+		})
+	}
+	payload, err := json.Marshal(events.ScoresCalculatedData{
+		SessionID:      sessionID,
+		UserID:         userID,
+		AssessmentType: assessmentType,
+		Scores:         scores,
+	})
+	if err != nil {
+		return err
+	}
+	return q.EnqueueOutboxEvent(ctx, EnqueueOutboxEventParams{
+		EventType: events.ScoresCalculated,
+		DedupKey:  events.DedupKey(events.ScoresCalculated, sessionID),
+		Payload:   payload,
+	})
+}
+
+// enqueueStarted records a session.started event. Run it in the transaction
+// that creates the session.
+func enqueueStarted(ctx context.Context, q *Queries, session UserAssessmentSession) error {
+	payload, err := json.Marshal(events.SessionStartedData{
+		SessionID:      session.ID,
+		UserID:         session.UserID,
+		AssessmentType: session.AssessmentType,
+		StartedAt:      session.StartedAt.Time,
+	})
+	if err != nil {
+		return err
+	}
+	return q.EnqueueOutboxEvent(ctx, EnqueueOutboxEventParams{
+		EventType: events.SessionStarted,
+		DedupKey:  events.DedupKey(events.SessionStarted, session.ID),
+		Payload:   payload,
+	})
+}
@@ -0,0 +1,104 @@
+package self_assessment
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+func TestValidateGrade(t *testing.T) {
+	criteria := []RubricCriterion{
+		{Key: "clarity", MaxPoints: 5, CategoryID: 1},
+		{Key: "depth", MaxPoints: 10, CategoryID: 2},
+	}
+	tests := []struct {
+		name    string
+		scores  map[string]int32
+		wantErr bool
+	}{
+		{name: "every criterion in range", scores: map[string]int32{"clarity": 5, "depth": 0}},
+		{name: "missing criterion", scores: map[string]int32{"clarity": 3}, wantErr: true},
+		{name: "above the maximum", scores: map[string]int32{"clarity": 6, "depth": 1}, wantErr: true},
+		{name: "negative", scores: map[string]int32{"clarity": -1, "depth": 1}, wantErr: true},
+		{name: "unknown criterion", scores: map[string]int32{"clarity": 1, "depth": 1, "style": 1}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateGrade(criteria, tt.scores)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("validateGrade = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestResolveItem(t *testing.T) {
+	submitted := pgtype.Timestamp{Time: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), Valid: true}
+	grade := func(scores string, resolver bool) GradingAssignment {
+		return GradingAssignment{CriterionScores: []byte(scores), Resolver: resolver, SubmittedAt: submitted}
+	}
+	pending := GradingAssignment{}
+
+	tests := []struct {
+		name        string
+		required    int32
+		assignments []GradingAssignment
+		wantStatus  GradingStatus
+		wantScores  map[string]int32
+	}{
+		{
+			name:        "waits for the second grade",
+			required:    2,
+			assignments: []GradingAssignment{grade(`{"a":3}`, false), pending},
+			wantStatus:  GradingStatusPending,
+		},
+		{
+			name:        "a single required grade is final",
+			required:    1,
+			assignments: []GradingAssignment{grade(`{"a":3}`, false)},
+			wantStatus:  GradingStatusGraded,
+			wantScores:  map[string]int32{"a": 3},
+		},
+		{
+			name:        "close grades are averaged per criterion",
+			required:    2,
+			assignments: []GradingAssignment{grade(`{"a":3,"b":4}`, false), grade(`{"a":4,"b":4}`, false)},
+			wantStatus:  GradingStatusGraded,
+			wantScores:  map[string]int32{"a": 4, "b": 4},
+		},
+		{
+			name:        "grades too far apart are disputed",
+			required:    2,
+			assignments: []GradingAssignment{grade(`{"a":1,"b":1}`, false), grade(`{"a":5,"b":5}`, false)},
+			wantStatus:  GradingStatusDisputed,
+		},
+		{
+			name:     "a resolver's grade is final",
+			required: 2,
+			assignments: []GradingAssignment{
+				grade(`{"a":1,"b":1}`, false),
+				grade(`{"a":5,"b":5}`, false),
+				grade(`{"a":2,"b":3}`, true),
+			},
+			wantStatus: GradingStatusGraded,
+			wantScores: map[string]int32{"a": 2, "b": 3},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			item := GradingItem{RequiredGrades: tt.required, Status: GradingStatusPending}
+			status, scores, err := resolveItem(item, 3, tt.assignments)
+			if err != nil {
+				t.Fatalf("resolveItem: %v", err)
+			}
+			if status != tt.wantStatus {
+				t.Errorf("status = %s, want %s", status, tt.wantStatus)
+			}
+			if !reflect.DeepEqual(scores, tt.wantScores) {
+				t.Errorf("scores = %v, want %v", scores, tt.wantScores)
+			}
+		})
+	}
+}
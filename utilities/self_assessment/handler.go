@@ -1,22 +1,48 @@
 package self_assessment
 
 import (
+	"backend/pkg/filters"
+	"backend/pkg/formats"
+	"backend/pkg/i18n"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// Config sets how assessments are run. It comes from the server's
+// configuration, never from candidates.
+type Config struct {
+	// AdaptiveTargetSE and AdaptiveMaxItems end an adaptive session once
+	// the ability estimate is precise enough or enough items were asked.
+	AdaptiveTargetSE float64
+	AdaptiveMaxItems int32
+}
+
 type SelfAssessmentHandler struct {
+	db      *pgxpool.Pool
 	queries *Queries
+	cfg     Config
 }
 
-func NewSelfAssessmentHandler(queries *Queries) *SelfAssessmentHandler {
+func NewSelfAssessmentHandler(db *pgxpool.Pool, queries *Queries, cfg Config) *SelfAssessmentHandler {
+	if cfg.AdaptiveTargetSE <= 0 || cfg.AdaptiveTargetSE >= 1 {
+		cfg.AdaptiveTargetSE = defaultTargetSE
+	}
+	if cfg.AdaptiveMaxItems <= 0 {
+		cfg.AdaptiveMaxItems = defaultMaxItems
+	}
 	return &SelfAssessmentHandler{
+		db:      db,
 		queries: queries,
+		cfg:     cfg,
 	}
 }
 
@@ -26,6 +52,7 @@ func (h *SelfAssessmentHandler) InsertQuestion(c *gin.Context) {
 		Type          string          `json:"type"`
 		Options       json.RawMessage `json:"options"`
 		CorrectAnswer string          `json:"correct_answer"`
+		Format        string          `json:"format"`
 	}
 
 	if err := c.ShouldBindJSON(&req); err != nil {
@@ -33,6 +60,25 @@ func (h *SelfAssessmentHandler) InsertQuestion(c *gin.Context) {
 		return
 	}
 
+	if req.Format == "" {
+		req.Format = formats.SingleChoice
+	}
+	// Open-ended questions are graded against a rubric and need no options.
+	if req.Format == formats.OpenEnded && len(req.Options) == 0 {
+		req.Options = json.RawMessage(`{}`)
+	}
+	if req.Format != formats.SingleChoice {
+		var options map[string]json.RawMessage
+		if err := json.Unmarshal(req.Options, &options); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Options must be a JSON object"})
+			return
+		}
+		if err := formats.ValidateOptions(req.Format, options); err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
 	optionsBytes, err := json.Marshal(req.Options) 
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process options"})
@@ -47,6 +93,7 @@ func (h *SelfAssessmentHandler) InsertQuestion(c *gin.Context) {
 			String: req.CorrectAnswer,
 			Valid:  req.CorrectAnswer != "",
 		},
+		Format: QuestionFormat(req.Format),
 	}
 
 	question, err := h.queries.InsertQuestion(c, params)
@@ -72,81 +119,294 @@ func (h *SelfAssessmentHandler) InsertCategory(c *gin.Context) {
 	c.JSON(http.StatusOK, category)
 }
 
-func (h *SelfAssessmentHandler) GetSelfAssessmentBehavioral(c *gin.Context) {
-	questions, err := h.queries.GetAssessmentQuestionBehavioral(c)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get questions"})
+func (h *SelfAssessmentHandler) GetUserAssessmentStatus(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
 	}
 
-	c.JSON(http.StatusOK, questions)
-}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
 
-func (h *SelfAssessmentHandler) GetSelfAssessmentPersonality(c *gin.Context) {
-	questions, err := h.queries.GetAssessmentQuestionPersonality(c)
+	completedAssessments, err := h.queries.GetUserCompletedAssessments(c, int32(userID))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get questions"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch assessment status"})
 		return
 	}
 
-	c.JSON(http.StatusOK, questions)
+	assessmentStatus := map[string]bool{
+		"behavioral":  false,
+		"cognitive":   false,
+		"personality": false,
+	}
+
+	for _, assessment := range completedAssessments {
+		assessmentStatus[assessment.AssessmentType] = true
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"assessments_completed": assessmentStatus,
+	})
 }
 
-func (h *SelfAssessmentHandler) GetSelfAssessmentCognitive(c *gin.Context) {
-	questions, err := h.queries.GetAssessmentQuestionCognitive(c)
+func (h *SelfAssessmentHandler) GetCandidateScores(c *gin.Context) {
+	filter, err := filters.ParseCandidateFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	candidates, err := h.queries.ListCandidateScores(c, ListCandidateScoresParams{
+		Search:         filter.Search,
+		AssessmentType: filter.AssessmentType,
+		CompletedFrom:  filter.CompletedFrom,
+		CompletedTo:    filter.CompletedTo,
+	})
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to get questions"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch candidate scores"})
 		return
 	}
 
-	c.JSON(http.StatusOK, questions)
+	c.JSON(http.StatusOK, candidates)
 }
 
-func (h *SelfAssessmentHandler) GetUserAssessmentStatus(c *gin.Context) {
+func (h *SelfAssessmentHandler) StartAssessment(c *gin.Context) {
+	assessmentType := c.Param("type")
+	if assessmentType != "behavioral" && assessmentType != "personality" && assessmentType != "cognitive" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assessment type"})
+		return
+	}
+
 	userIDStr, exists := c.Get("userID")
 	if !exists {
 		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
 		return
 	}
-
 	userID, err := strconv.Atoi(userIDStr.(string))
 	if err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
 		return
 	}
 
-	completedAssessments, err := h.queries.GetUserCompletedAssessments(c, int32(userID))
+	// Resume an unfinished session rather than drawing a fresh set, so
+	// reloading the page cannot be used to shop for easier questions.
+	open, err := h.queries.GetOpenAssessmentSession(c, GetOpenAssessmentSessionParams{
+		UserID:         int32(userID),
+		AssessmentType: assessmentType,
+	})
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up open session"})
+		return
+	}
+	if err == nil {
+		rows, err := h.queries.ListSessionQuestions(c, open.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session questions"})
+			return
+		}
+		if len(rows) > 0 {
+			h.respondSession(c, open, rows)
+			return
+		}
+	}
+
+	// Staff decide the blueprint: the newest one for the type. Letting the
+	// candidate pick would let them choose a shorter or untimed form.
+	var blueprint *AssessmentBlueprint
+	found, err := h.queries.GetLatestBlueprint(c, assessmentType)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load blueprint"})
+		return
+	}
+	if err == nil {
+		blueprint = &found
+	}
+
+	tx, err := h.db.Begin(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch assessment status"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
 		return
 	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
 
-	assessmentStatus := map[string]bool{
-		"behavioral":  false,
-		"cognitive":   false,
-		"personality": false,
+	ids, err := drawQuestions(c, qtx, blueprint, assessmentType)
+	if errors.Is(err, errItemBankExhausted) {
+		c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to draw questions"})
+		return
 	}
 
-	for _, assessment := range completedAssessments {
-		assessmentStatus[assessment.AssessmentType] = true
+	params := CreateAssessmentSessionParams{
+		UserID:         int32(userID),
+		AssessmentType: assessmentType,
+	}
+	// A blueprint can opt its items in or out of shuffling; scale items are
+	// otherwise shown in their stored order.
+	shuffle := shufflesOptions(assessmentType)
+	if blueprint != nil {
+		params.BlueprintID = pgtype.Int4{Int32: blueprint.ID, Valid: true}
+		shuffle = blueprint.ShuffleOptions
+	}
+	session, err := qtx.CreateAssessmentSession(c, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session"})
+		return
+	}
+	err = qtx.LinkCandidateAccommodation(c, LinkCandidateAccommodationParams{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply accommodations"})
+		return
+	}
+	if err := enqueueStarted(c, qtx, session); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record session start"})
+		return
+	}
+
+	questions, err := qtx.GetQuestionsByIDs(c, ids)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load questions"})
+		return
+	}
+	byID := make(map[int32]SelfAssessmentQuestion, len(questions))
+	for _, question := range questions {
+		byID[question.ID] = question
+	}
+
+	for position, id := range ids {
+		order, err := optionOrder(byID[id].Options, shuffle && showsOptions(byID[id].Format))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Question %d has invalid options", id)})
+			return
+		}
+		orderBytes, err := json.Marshal(order)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process options"})
+			return
+		}
+		err = qtx.InsertSessionQuestion(c, InsertSessionQuestionParams{
+			SessionID:   session.ID,
+			QuestionID:  id,
+			Position:    int32(position),
+			OptionOrder: orderBytes,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session questions"})
+			return
+		}
+	}
+
+	rows, err := qtx.ListSessionQuestions(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session questions"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit session"})
+		return
+	}
+
+	h.respondSession(c, session, rows)
+}
+
+// respondSession writes a started session with its questions in display order.
+func (h *SelfAssessmentHandler) respondSession(c *gin.Context, session UserAssessmentSession, rows []ListSessionQuestionsRow) {
+	rows, err := h.localizeSessionQuestions(c, rows)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to translate questions"})
+		return
+	}
+
+	questions := make([]SessionQuestion, 0, len(rows))
+	for _, row := range rows {
+		question, err := presentQuestion(row)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Question %d has invalid options", row.ID)})
+			return
+		}
+		questions = append(questions, question)
+	}
+
+	deadline, accommodation, err := h.sessionTiming(c, session)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session deadline"})
+		return
 	}
 
+	var blueprintID *int32
+	if session.BlueprintID.Valid {
+		blueprintID = &session.BlueprintID.Int32
+	}
 	c.JSON(http.StatusOK, gin.H{
-		"assessments_completed": assessmentStatus,
+		"session_id":      session.ID,
+		"assessment_type": session.AssessmentType,
+		"blueprint_id":    blueprintID,
+		"deadline":        deadline,
+		"accommodation":   accommodationSettings(accommodation),
+		"questions":       questions,
 	})
 }
 
-func (h *SelfAssessmentHandler) GetCandidateScores(c *gin.Context) {
-	candidates, err := h.queries.ListCandidateScores(c)
+func (h *SelfAssessmentHandler) RecordAnswerTime(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch candidate scores"})
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+	sessionID, err := strconv.ParseInt(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+	questionID, err := strconv.ParseInt(c.Param("question_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
 		return
 	}
 
-	c.JSON(http.StatusOK, candidates)
+	marked, err := h.queries.MarkSessionQuestionAnswered(c, MarkSessionQuestionAnsweredParams{
+		SessionID:  int32(sessionID),
+		QuestionID: int32(questionID),
+		UserID:     int32(userID),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record answer time"})
+		return
+	}
+	if marked == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question is not part of an open session"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Answer time recorded"})
 }
 
 func (h *SelfAssessmentHandler) SubmitAssessment(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
 	assessmentType := c.Param("type")
 	if assessmentType == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "Assessment type is required"})
@@ -160,10 +420,11 @@ func (h *SelfAssessmentHandler) SubmitAssessment(c *gin.Context) {
 	}
 
 	var req struct {
-		UserID  int32 `json:"user_id" binding:"required"`
-		Answers []struct {
-			QuestionID  int32  `json:"question_id" binding:"required"`
-			AnswerValue string `json:"answer_value" binding:"required"`
+		SessionID int32 `json:"session_id" binding:"required"`
+		Answers   []struct {
+			QuestionID  int32          `json:"question_id" binding:"required"`
+			AnswerValue string         `json:"answer_value"`
+			Response    *TypedResponse `json:"response"`
 		} `json:"answers" binding:"required"`
 	}
 
@@ -172,16 +433,160 @@ func (h *SelfAssessmentHandler) SubmitAssessment(c *gin.Context) {
 		return
 	}
 
-	session, err := h.queries.CreateAssessmentSession(c, CreateAssessmentSessionParams{
-		UserID:         req.UserID,
-		AssessmentType: assessmentType,
-	})
+	// Answers, scores and the completion event are saved together or not
+	// at all.
+	tx, err := h.db.Begin(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session"})
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	// Answers always go to a session started with StartAssessment, so its
+	// questions, deadline and accommodations apply. Answers refer to options
+	// as displayed, so map them back to the stored option keys before
+	// scoring. The lock makes a second submission wait, then see the session
+	// submitted.
+	session, err := qtx.LockAssessmentSession(c, req.SessionID)
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if session.UserID != int32(userID) || session.AssessmentType != assessmentType {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Session does not match this assessment"})
+		return
+	}
+	if session.CompletedAt.Valid || session.SubmittedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Session has already been submitted"})
+		return
+	}
+	if _, err := qtx.GetAdaptiveSession(c, session.ID); err == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Adaptive sessions are answered one item at a time"})
+		return
+	}
+	deadline, _, err := h.sessionTiming(c, session)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session deadline"})
+		return
+	}
+	if deadline != nil && time.Now().After(deadline.Add(deadlineGrace)) {
+		c.JSON(http.StatusConflict, gin.H{"error": "The time limit for this session has passed"})
+		return
+	}
+
+	rows, err := qtx.ListSessionQuestions(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session questions"})
 		return
 	}
+	orders, err := sessionOptionOrders(rows)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session questions"})
+		return
+	}
+	for i, answer := range req.Answers {
+		order, ok := orders[answer.QuestionID]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %d is not part of this session", answer.QuestionID)})
+			return
+		}
+		if answer.Response != nil {
+			if err := answer.Response.originalKeys(order); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %d: %v", answer.QuestionID, err)})
+				return
+			}
+			continue
+		}
+		original, err := originalAnswer(order, answer.AnswerValue)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %d: %v", answer.QuestionID, err)})
+			return
+		}
+		req.Answers[i].AnswerValue = original
+	}
 	sessionID := session.ID
 
+	// Answer times were stamped by the server as the candidate went along.
+	answerTimes, err := qtx.ListSessionAnswerTimes(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load answer times"})
+		return
+	}
+	answeredAt := make(map[int32]pgtype.Timestamp, len(answerTimes))
+	for _, t := range answerTimes {
+		answeredAt[t.QuestionID] = t.AnsweredAt
+	}
+
+	questionIDs := make([]int32, 0, len(req.Answers))
+	for _, answer := range req.Answers {
+		questionIDs = append(questionIDs, answer.QuestionID)
+	}
+	questions, err := qtx.GetQuestionsByIDs(c, questionIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load questions"})
+		return
+	}
+	mappings, err := qtx.ListMappingsByQuestionIDs(c, questionIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question mappings"})
+		return
+	}
+	questionByID := make(map[int32]SelfAssessmentQuestion, len(questions))
+	for _, question := range questions {
+		questionByID[question.ID] = question
+	}
+	mappingsByQuestion := make(map[int32][]SelfAssessmentMapping)
+	for _, mapping := range mappings {
+		mappingsByQuestion[mapping.QuestionID] = append(mappingsByQuestion[mapping.QuestionID], mapping)
+	}
+
+	// Typed formats are validated and scored here; single-choice answers
+	// keep the answer_value path below.
+	singleChoice := req.Answers[:0]
+	for _, answer := range req.Answers {
+		question, ok := questionByID[answer.QuestionID]
+		if !ok {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %d not found", answer.QuestionID)})
+			return
+		}
+		if question.Format == QuestionFormatSingleChoice {
+			if answer.AnswerValue == "" {
+				c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %d: answer_value is required", answer.QuestionID)})
+				return
+			}
+			singleChoice = append(singleChoice, answer)
+			continue
+		}
+		if answer.Response == nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %d: a %s question needs a response", answer.QuestionID, question.Format)})
+			return
+		}
+
+		typed, err := scoreTypedAnswer(question, mappingsByQuestion[question.ID], *answer.Response)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %d: %v", answer.QuestionID, err)})
+			return
+		}
+		answerBytes, err := json.Marshal(typed)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process answer"})
+			return
+		}
+		err = qtx.InsertUserAnswer(c, InsertUserAnswerParams{
+			UserID:      pgtype.Int4{Int32: int32(userID), Valid: true},
+			SessionID:   pgtype.Int4{Int32: sessionID, Valid: true},
+			QuestionID:  pgtype.Int4{Int32: answer.QuestionID, Valid: true},
+			AnswerValue: answerBytes,
+			AnsweredAt:  answeredAt[answer.QuestionID],
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to insert answer: %v", err)})
+			return
+		}
+	}
+	req.Answers = singleChoice
+
 	if assessmentType == "cognitive" {
 		for _, answer := range req.Answers {
 			answerInt, err := strconv.Atoi(answer.AnswerValue)
@@ -201,11 +606,12 @@ func (h *SelfAssessmentHandler) SubmitAssessment(c *gin.Context) {
 				return
 			}
 
-			err = h.queries.InsertUserAnswer(c, InsertUserAnswerParams{
-				UserID:      pgtype.Int4{Int32: req.UserID, Valid: true},
+			err = qtx.InsertUserAnswer(c, InsertUserAnswerParams{
+				UserID:      pgtype.Int4{Int32: int32(userID), Valid: true},
 				SessionID:   pgtype.Int4{Int32: sessionID, Valid: true},
 				QuestionID:  pgtype.Int4{Int32: answer.QuestionID, Valid: true},
 				AnswerValue: answerBytes,
+				AnsweredAt:  answeredAt[answer.QuestionID],
 			})
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to insert answer: %v", err)})
@@ -240,11 +646,12 @@ func (h *SelfAssessmentHandler) SubmitAssessment(c *gin.Context) {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process answer"})
 				return
 			}
-			err = h.queries.InsertUserAnswer(c, InsertUserAnswerParams{
-				UserID:      pgtype.Int4{Int32: req.UserID, Valid: true},
+			err = qtx.InsertUserAnswer(c, InsertUserAnswerParams{
+				UserID:      pgtype.Int4{Int32: int32(userID), Valid: true},
 				SessionID:   pgtype.Int4{Int32: sessionID, Valid: true},
 				QuestionID:  pgtype.Int4{Int32: answer.QuestionID, Valid: true},
 				AnswerValue: answerBytes,
+				AnsweredAt:  answeredAt[answer.QuestionID],
 			})
 			if err != nil {
 				c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to insert answer: %v", err)})
@@ -252,28 +659,46 @@ func (h *SelfAssessmentHandler) SubmitAssessment(c *gin.Context) {
 			}
 		}
 	}
-	var calcErr error
-	switch assessmentType {
-	case "behavioral":
-		calcErr = h.queries.CalculateBehavioralScores(c, pgtype.Int4{Int32: sessionID, Valid: true})
-	case "personality":
-		calcErr = h.queries.CalculatePersonalityScores(c, pgtype.Int4{Int32: sessionID, Valid: true})
-	case "cognitive":
-		calcErr = h.queries.CalculateCognitiveScores(c, pgtype.Int4{Int32: sessionID, Valid: true})
+	pending, err := qtx.CreateGradingItems(c, pgtype.Int4{Int32: sessionID, Valid: true})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue answers for grading"})
+		return
 	}
 
-	if calcErr != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to calculate scores: %v", calcErr)})
+	if _, err := recordQuality(c, qtx, session); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to check response quality: %v", err)})
 		return
 	}
 
-	err = h.queries.CompleteAssessmentSession(c, sessionID)
-	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to complete session: %v", err)})
+	if err := qtx.MarkSessionSubmitted(c, sessionID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit session"})
+		return
+	}
+
+	// Sessions with open-ended answers are scored once the last one is graded.
+	if pending > 0 {
+		if err := tx.Commit(c); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit answers"})
+			return
+		}
+		c.JSON(http.StatusAccepted, gin.H{
+			"message":         fmt.Sprintf("%s assessment submitted, awaiting grading", assessmentType),
+			"session_id":      sessionID,
+			"pending_grading": pending,
+		})
+		return
+	}
+
+	if err := finalizeSession(c, qtx, sessionID, int32(userID), assessmentType); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to calculate scores: %v", err)})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit answers"})
 		return
 	}
 
-	scores, err := h.queries.GetSessionScores(c, pgtype.Int4{Int32: sessionID, Valid: true})
+	scores, err := h.sessionScores(c, sessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve scores"})
 		return
@@ -296,15 +721,28 @@ func (h *SelfAssessmentHandler) GetSessionScores(c *gin.Context) {
 		return
 	}
 
-	scores, err := h.queries.GetSessionScores(c, pgtype.Int4{Int32: req.SessionID, Valid: true})
+	scores, err := h.sessionScores(c, req.SessionID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve scores"})
 		return
 	}
+	facets, err := h.queries.ListTraitFacets(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve trait models"})
+		return
+	}
+	validity, err := h.queries.ListSessionValidityScores(c, req.SessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve validity scores"})
+		return
+	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"session_id": req.SessionID,
-		"scores":     scores,
+		"session_id":             req.SessionID,
+		"scores":                 scores,
+		"traits":                 traitProfile(facets, scores),
+		"validity":               validity,
+		"interpret_with_caution": interpretWithCaution(validity),
 	})
 }
 
@@ -329,16 +767,1655 @@ func (h *SelfAssessmentHandler) GetCandidateAssessmentDetails(c *gin.Context) {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve assessment details"})
 		return
 	}
+	locale, err := h.requestLocale(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve locale"})
+		return
+	}
+	descriptions, err := h.categoryDescriptions(c, locale)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve category translations"})
+		return
+	}
+	for i, result := range results {
+		if description, ok := descriptions[result.CategoryID.Int32]; ok {
+			results[i].CategoryDescription = pgtype.Text{String: description, Valid: true}
+		}
+	}
 
 	var latestSessionID int32
+	qualityFlags := []QualityFlag{}
+	validity := []ListSessionValidityScoresRow{}
+	accommodated := false
 	if len(results) > 0 {
 		latestSessionID = results[0].SessionID.Int32
+		session, err := h.queries.GetAssessmentSession(c, latestSessionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve assessment details"})
+			return
+		}
+		if err := json.Unmarshal(session.QualityFlags, &qualityFlags); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to read quality flags"})
+			return
+		}
+		validity, err = h.queries.ListSessionValidityScores(c, latestSessionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve validity scores"})
+			return
+		}
+		// Reports only say that accommodations applied; the details stay
+		// with the staff who granted them.
+		accommodated, err = h.queries.SessionHasAccommodation(c, latestSessionID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve accommodations"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"user_id":                req.UserID,
+		"assessment_type":        req.AssessmentType,
+		"session_id":             latestSessionID,
+		"results":                results,
+		"quality_flags":          qualityFlags,
+		"validity":               validity,
+		"interpret_with_caution": interpretWithCaution(validity),
+		"accommodated":           accommodated,
+	})
+}
+
+func (h *SelfAssessmentHandler) CreateBlueprint(c *gin.Context) {
+	var req struct {
+		Name             string `json:"name" binding:"required"`
+		AssessmentType   string `json:"assessment_type" binding:"required"`
+		ShuffleOptions   *bool  `json:"shuffle_options"`
+		MaxItemExposures *int32 `json:"max_item_exposures"`
+		TimeLimitMinutes *int32 `json:"time_limit_minutes"`
+		Sections         []struct {
+			CategoryID    int32 `json:"category_id" binding:"required"`
+			QuestionCount int32 `json:"question_count" binding:"required"`
+		} `json:"sections" binding:"required"`
+	}
+
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.AssessmentType != "behavioral" && req.AssessmentType != "personality" && req.AssessmentType != "cognitive" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid assessment type"})
+		return
+	}
+	if len(req.Sections) == 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "At least one section is required"})
+		return
+	}
+	if req.MaxItemExposures != nil && *req.MaxItemExposures <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Max item exposures must be positive"})
+		return
+	}
+	if req.TimeLimitMinutes != nil && *req.TimeLimitMinutes <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Time limit must be positive"})
+		return
+	}
+
+	seen := make(map[int32]bool)
+	for _, section := range req.Sections {
+		if section.QuestionCount <= 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Question count must be positive"})
+			return
+		}
+		if seen[section.CategoryID] {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Category %d appears more than once", section.CategoryID)})
+			return
+		}
+		seen[section.CategoryID] = true
+
+		available, err := h.queries.CountCategoryQuestions(c, CountCategoryQuestionsParams{
+			Type:       QuestionType(req.AssessmentType),
+			CategoryID: section.CategoryID,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to count questions"})
+			return
+		}
+		if available < int64(section.QuestionCount) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf(
+				"Category %d has only %d %s questions, %d requested",
+				section.CategoryID, available, req.AssessmentType, section.QuestionCount)})
+			return
+		}
+	}
+
+	params := CreateBlueprintParams{
+		Name:           req.Name,
+		AssessmentType: req.AssessmentType,
+		ShuffleOptions: shufflesOptions(req.AssessmentType),
+	}
+	if req.ShuffleOptions != nil {
+		params.ShuffleOptions = *req.ShuffleOptions
+	}
+	if req.MaxItemExposures != nil {
+		params.MaxItemExposures = pgtype.Int4{Int32: *req.MaxItemExposures, Valid: true}
+	}
+	if req.TimeLimitMinutes != nil {
+		params.TimeLimitMinutes = pgtype.Int4{Int32: *req.TimeLimitMinutes, Valid: true}
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	blueprint, err := qtx.CreateBlueprint(c, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create blueprint"})
+		return
+	}
+
+	sections := []AssessmentBlueprintSection{}
+	for _, s := range req.Sections {
+		section, err := qtx.InsertBlueprintSection(c, InsertBlueprintSectionParams{
+			BlueprintID:   blueprint.ID,
+			CategoryID:    s.CategoryID,
+			QuestionCount: s.QuestionCount,
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save blueprint section"})
+			return
+		}
+		sections = append(sections, section)
+	}
+
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit blueprint"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"blueprint": blueprint,
+		"sections":  sections,
+	})
+}
+
+func (h *SelfAssessmentHandler) ListBlueprints(c *gin.Context) {
+	blueprints, err := h.queries.ListBlueprints(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch blueprints"})
+		return
+	}
+
+	c.JSON(http.StatusOK, blueprints)
+}
+
+func (h *SelfAssessmentHandler) GetBlueprint(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid blueprint ID"})
+		return
+	}
+
+	blueprint, err := h.queries.GetBlueprint(c, int32(id))
+	if err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Blueprint not found"})
+		return
+	}
+
+	sections, err := h.queries.ListBlueprintSections(c, blueprint.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to fetch blueprint sections"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"blueprint": blueprint,
+		"sections":  sections,
+	})
+}
+
+func (h *SelfAssessmentHandler) StartAdaptiveAssessment(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	params := CreateAdaptiveSessionParams{
+		TargetSe: h.cfg.AdaptiveTargetSE,
+		MaxItems: h.cfg.AdaptiveMaxItems,
+	}
+
+	open, err := h.queries.GetOpenAdaptiveSession(c, int32(userID))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to look up open session"})
+		return
+	}
+	if err == nil {
+		h.respondAdaptiveItem(c, open.ID)
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	session, err := qtx.CreateAssessmentSession(c, CreateAssessmentSessionParams{
+		UserID:         int32(userID),
+		AssessmentType: "cognitive",
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create session"})
+		return
+	}
+	params.SessionID = session.ID
+	if _, err := qtx.CreateAdaptiveSession(c, params); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create adaptive session"})
+		return
+	}
+	err = qtx.LinkCandidateAccommodation(c, LinkCandidateAccommodationParams{
+		SessionID: session.ID,
+		UserID:    session.UserID,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to apply accommodations"})
+		return
+	}
+	if err := enqueueStarted(c, qtx, session); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record session start"})
+		return
+	}
+
+	items, err := qtx.ListAdaptiveCandidateItems(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load items"})
+		return
+	}
+	first, ok := nextItem(items, 0)
+	if !ok {
+		c.JSON(http.StatusConflict, gin.H{"error": "No cognitive items are available"})
+		return
+	}
+	err = qtx.InsertSessionQuestion(c, InsertSessionQuestionParams{
+		SessionID:   session.ID,
+		QuestionID:  first,
+		Position:    0,
+		OptionOrder: []byte("[]"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session question"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit session"})
+		return
+	}
+
+	h.respondAdaptiveItem(c, session.ID)
+}
+
+func (h *SelfAssessmentHandler) AnswerAdaptiveItem(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	sessionID, err := strconv.ParseInt(c.Param("session_id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid session ID"})
+		return
+	}
+
+	var req struct {
+		QuestionID  int32  `json:"question_id" binding:"required"`
+		AnswerValue string `json:"answer_value" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	selected, err := strconv.Atoi(req.AnswerValue)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Invalid answer value format: %v", err)})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	// The lock makes a concurrent answer to the same item wait, then see it
+	// answered or the session submitted.
+	session, err := qtx.LockAssessmentSession(c, int32(sessionID))
+	if err != nil || session.UserID != int32(userID) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Session not found"})
+		return
+	}
+	if session.CompletedAt.Valid || session.SubmittedAt.Valid {
+		c.JSON(http.StatusConflict, gin.H{"error": "Session has already been submitted"})
+		return
+	}
+	state, err := qtx.GetAdaptiveSession(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Session is not adaptive"})
+		return
+	}
+	deadline, _, err := h.sessionTiming(c, session)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session deadline"})
+		return
+	}
+	if deadline != nil && time.Now().After(deadline.Add(deadlineGrace)) {
+		c.JSON(http.StatusConflict, gin.H{"error": "The time limit for this session has passed"})
+		return
+	}
+
+	served, err := qtx.ListSessionQuestions(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session questions"})
+		return
+	}
+	sessionParam := pgtype.Int4{Int32: session.ID, Valid: true}
+	responses, err := qtx.ListAdaptiveResponses(c, sessionParam)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load answers"})
+		return
+	}
+	// Items are served one at a time, so the only unanswered one is the last.
+	if len(served) <= len(responses) || served[len(served)-1].ID != req.QuestionID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Question %d is not the current item", req.QuestionID)})
+		return
+	}
+
+	answerBytes, err := json.Marshal(map[string]interface{}{
+		"selected": selected,
+		"points":   0,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process answer"})
+		return
+	}
+	err = qtx.InsertUserAnswer(c, InsertUserAnswerParams{
+		UserID:      pgtype.Int4{Int32: session.UserID, Valid: true},
+		SessionID:   sessionParam,
+		QuestionID:  pgtype.Int4{Int32: req.QuestionID, Valid: true},
+		AnswerValue: answerBytes,
+		AnsweredAt:  pgtype.Timestamp{Time: time.Now(), Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to insert answer: %v", err)})
+		return
+	}
+
+	responses, err = qtx.ListAdaptiveResponses(c, sessionParam)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load answers"})
+		return
+	}
+	theta, standardError := estimateTheta(responses)
+	err = qtx.UpdateAdaptiveEstimate(c, UpdateAdaptiveEstimateParams{
+		SessionID:     session.ID,
+		Theta:         theta,
+		StandardError: standardError,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update ability estimate"})
+		return
+	}
+
+	items, err := qtx.ListAdaptiveCandidateItems(c, session.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load items"})
+		return
+	}
+	next, ok := nextItem(items, theta)
+
+	if !ok || adaptiveDone(state, len(responses), standardError) {
+		if err := qtx.CalculateCognitiveScores(c, sessionParam); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to calculate scores: %v", err)})
+			return
+		}
+		err = qtx.SetSessionTheta(c, SetSessionThetaParams{
+			SessionID: sessionParam,
+			Theta:     pgtype.Float8{Float64: theta, Valid: true},
+			ThetaSe:   pgtype.Float8{Float64: standardError, Valid: true},
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store ability estimate"})
+			return
+		}
+		if err := enqueueScored(c, qtx, session.ID, session.UserID, session.AssessmentType); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record scores"})
+			return
+		}
+		if _, err := recordQuality(c, qtx, session); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to check response quality: %v", err)})
+			return
+		}
+		if err := qtx.MarkSessionSubmitted(c, session.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to submit session"})
+			return
+		}
+		if err := qtx.CompleteAssessmentSession(c, session.ID); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to complete session: %v", err)})
+			return
+		}
+		if err := enqueueCompleted(c, qtx, session.ID, session.UserID, session.AssessmentType); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to record completion"})
+			return
+		}
+		if err := tx.Commit(c); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit answer"})
+			return
+		}
+
+		scores, err := h.sessionScores(c, session.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve scores"})
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"message":        "cognitive assessment completed successfully",
+			"session_id":     session.ID,
+			"completed":      true,
+			"items_answered": len(responses),
+			"theta":          theta,
+			"standard_error": standardError,
+			"scores":         scores,
+		})
+		return
+	}
+
+	err = qtx.InsertSessionQuestion(c, InsertSessionQuestionParams{
+		SessionID:   session.ID,
+		QuestionID:  next,
+		Position:    int32(len(served)),
+		OptionOrder: []byte("[]"),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save session question"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to commit answer"})
+		return
+	}
+
+	h.respondAdaptiveItem(c, session.ID)
+}
+
+// respondAdaptiveItem writes the item an adaptive session is waiting on.
+func (h *SelfAssessmentHandler) respondAdaptiveItem(c *gin.Context, sessionID int32) {
+	state, err := h.queries.GetAdaptiveSession(c, sessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load adaptive session"})
+		return
+	}
+	served, err := h.queries.ListSessionQuestions(c, sessionID)
+	if err != nil || len(served) == 0 {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session questions"})
+		return
+	}
+	current, err := h.localizeSessionQuestions(c, served[len(served)-1:])
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to translate questions"})
+		return
+	}
+	question, err := presentQuestion(current[0])
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session questions"})
+		return
+	}
+	var accommodation *CandidateAccommodation
+	found, err := h.queries.GetSessionAccommodation(c, sessionID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load accommodations"})
+		return
+	}
+	if err == nil {
+		accommodation = &found
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"session_id":     sessionID,
+		"completed":      false,
+		"items_answered": len(served) - 1,
+		"max_items":      state.MaxItems,
+		"accommodation":  accommodationSettings(accommodation),
+		"question":       question,
+	})
+}
+
+func (h *SelfAssessmentHandler) SetItemParameters(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
+		return
+	}
+
+	var req struct {
+		Discrimination float64 `json:"discrimination" binding:"required"`
+		Difficulty     float64 `json:"difficulty"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Discrimination <= 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Discrimination must be positive"})
+		return
+	}
+	if req.Difficulty < thetaMin || req.Difficulty > thetaMax {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Difficulty must be between %v and %v", thetaMin, thetaMax)})
+		return
+	}
+
+	questions, err := h.queries.GetQuestionsByIDs(c, []int32{int32(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question"})
+		return
+	}
+	if len(questions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		return
+	}
+
+	parameters, err := h.queries.UpsertItemParameters(c, UpsertItemParametersParams{
+		QuestionID:     int32(id),
+		Discrimination: req.Discrimination,
+		Difficulty:     req.Difficulty,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save item parameters"})
+		return
+	}
+
+	c.JSON(http.StatusOK, parameters)
+}
+
+func (h *SelfAssessmentHandler) CreateItemPair(c *gin.Context) {
+	var req struct {
+		QuestionID       int32 `json:"question_id" binding:"required"`
+		PairedQuestionID int32 `json:"paired_question_id" binding:"required"`
+		Reversed         bool  `json:"reversed"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.QuestionID == req.PairedQuestionID {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "A question cannot be paired with itself"})
+		return
+	}
+
+	questions, err := h.queries.GetQuestionsByIDs(c, []int32{req.QuestionID, req.PairedQuestionID})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load questions"})
+		return
+	}
+	if len(questions) != 2 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		return
+	}
+	if questions[0].Type != questions[1].Type {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Paired questions must belong to the same assessment type"})
+		return
+	}
+
+	pair, err := h.queries.CreateItemPair(c, CreateItemPairParams{
+		QuestionID:       req.QuestionID,
+		PairedQuestionID: req.PairedQuestionID,
+		Reversed:         req.Reversed,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create item pair"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, pair)
+}
+
+func (h *SelfAssessmentHandler) ListItemPairs(c *gin.Context) {
+	pairs, err := h.queries.ListItemPairs(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve item pairs"})
+		return
+	}
+	c.JSON(http.StatusOK, pairs)
+}
+
+func (h *SelfAssessmentHandler) SetRubric(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
+		return
+	}
+
+	var req struct {
+		Criteria        []RubricCriterion `json:"criteria" binding:"required"`
+		DoubleMarked    bool              `json:"double_marked"`
+		MaxDisagreement int32             `json:"max_disagreement"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := validateCriteria(req.Criteria); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.MaxDisagreement < 0 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Max disagreement cannot be negative"})
+		return
+	}
+
+	questions, err := h.queries.GetQuestionsByIDs(c, []int32{int32(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question"})
+		return
+	}
+	if len(questions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		return
+	}
+	if questions[0].Format != QuestionFormatOpenEnded {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rubrics apply to open-ended questions only"})
+		return
+	}
+
+	categoryIDs := make([]int32, 0, len(req.Criteria))
+	seen := make(map[int32]bool)
+	for _, criterion := range req.Criteria {
+		if !seen[criterion.CategoryID] {
+			seen[criterion.CategoryID] = true
+			categoryIDs = append(categoryIDs, criterion.CategoryID)
+		}
+	}
+	found, err := h.queries.CountCategoriesByIDs(c, categoryIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load categories"})
+		return
+	}
+	if found != int64(len(categoryIDs)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Rubric refers to an unknown category"})
+		return
+	}
+
+	criteria, err := json.Marshal(req.Criteria)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process rubric"})
+		return
+	}
+	rubric, err := h.queries.UpsertRubric(c, UpsertRubricParams{
+		QuestionID:      int32(id),
+		Criteria:        criteria,
+		DoubleMarked:    req.DoubleMarked,
+		MaxDisagreement: req.MaxDisagreement,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save rubric"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"question_id":      rubric.QuestionID,
+		"criteria":         req.Criteria,
+		"double_marked":    rubric.DoubleMarked,
+		"max_disagreement": rubric.MaxDisagreement,
+		"updated_at":       rubric.UpdatedAt,
+	})
+}
+
+func (h *SelfAssessmentHandler) SetMappings(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
+		return
+	}
+
+	var req struct {
+		Mappings []MappingInput `json:"mappings" binding:"required,dive"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	questions, err := h.queries.GetQuestionsByIDs(c, []int32{int32(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question"})
+		return
+	}
+	if len(questions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		return
+	}
+	if err := validateMappings(questions[0], req.Mappings); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	categoryIDs := make([]int32, 0, len(req.Mappings))
+	seen := make(map[int32]bool)
+	for _, mapping := range req.Mappings {
+		if !seen[mapping.CategoryID] {
+			seen[mapping.CategoryID] = true
+			categoryIDs = append(categoryIDs, mapping.CategoryID)
+		}
+	}
+	found, err := h.queries.CountCategoriesByIDs(c, categoryIDs)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load categories"})
+		return
+	}
+	if found != int64(len(categoryIDs)) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Mappings refer to an unknown category"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	if err := qtx.DeleteQuestionMappings(c, int32(id)); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replace mappings"})
+		return
+	}
+	saved := make([]SelfAssessmentMapping, 0, len(req.Mappings))
+	for _, mapping := range req.Mappings {
+		created, err := qtx.InsertMapping(c, InsertMappingParams{
+			QuestionID:  int32(id),
+			AnswerValue: pgtype.Int4{Int32: mapping.AnswerValue, Valid: true},
+			CategoryID:  mapping.CategoryID,
+			Points:      pgtype.Int4{Int32: mapping.Points, Valid: true},
+		})
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save mappings"})
+			return
+		}
+		saved = append(saved, created)
+	}
+	// Completed sessions that answered the question are scored again in the
+	// background, so results stay in line with the new mappings.
+	rescoring, err := qtx.EnqueueRescoreJobs(c, EnqueueRescoreJobsParams{
+		Kind:       RescoreJobKind,
+		QuestionID: int32(id),
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to queue score recalculation"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save mappings"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"question_id":       id,
+		"mappings":          saved,
+		"sessions_to_rescore": rescoring,
+	})
+}
+
+func (h *SelfAssessmentHandler) ListTraitModels(c *gin.Context) {
+	models, err := h.queries.ListTraitModels(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve trait models"})
+		return
+	}
+	facets, err := h.queries.ListTraitFacets(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve trait models"})
+		return
+	}
+
+	type facetView struct {
+		ID         int32  `json:"id"`
+		Code       string `json:"code"`
+		CategoryID int32  `json:"category_id"`
+		Name       string `json:"name"`
+		ItemCount  int64  `json:"item_count"`
+	}
+	type traitView struct {
+		CategoryID int32       `json:"category_id"`
+		Name       string      `json:"name"`
+		Facets     []facetView `json:"facets"`
+	}
+	type modelView struct {
+		ID     int32       `json:"id"`
+		Name   string      `json:"name"`
+		Source string      `json:"source"`
+		Traits []traitView `json:"traits"`
+	}
+
+	views := make([]modelView, 0, len(models))
+	for _, model := range models {
+		view := modelView{ID: model.ID, Name: model.Name, Source: model.Source.String, Traits: []traitView{}}
+		index := make(map[int32]int)
+		for _, facet := range facets {
+			if facet.ModelID != model.ID {
+				continue
+			}
+			i, ok := index[facet.TraitCategoryID]
+			if !ok {
+				i = len(view.Traits)
+				index[facet.TraitCategoryID] = i
+				view.Traits = append(view.Traits, traitView{CategoryID: facet.TraitCategoryID, Name: facet.TraitName.String})
+			}
+			view.Traits[i].Facets = append(view.Traits[i].Facets, facetView{
+				ID:         facet.ID,
+				Code:       facet.Code,
+				CategoryID: facet.FacetCategoryID,
+				Name:       facet.FacetName.String,
+				ItemCount:  facet.ItemCount,
+			})
+		}
+		views = append(views, view)
+	}
+	c.JSON(http.StatusOK, views)
+}
+
+func (h *SelfAssessmentHandler) SetTraitItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
+		return
+	}
+
+	var req struct {
+		FacetID      int32 `json:"facet_id" binding:"required"`
+		ReverseKeyed bool  `json:"reverse_keyed"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	questions, err := h.queries.GetQuestionsByIDs(c, []int32{int32(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question"})
+		return
+	}
+	if len(questions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		return
+	}
+	question := questions[0]
+	if question.Type != QuestionTypePersonality || question.Format != QuestionFormatSingleChoice {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Trait items must be single-choice personality questions"})
+		return
+	}
+	points, err := keyedPoints(question.Options, req.ReverseKeyed)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	facet, err := h.queries.GetTraitFacet(c, req.FacetID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Facet not found"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	// The item scores on its facet and on the facet's trait, so the mappings
+	// are rebuilt from the keying.
+	if err := qtx.DeleteQuestionMappings(c, question.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replace mappings"})
+		return
+	}
+	for option, earned := range points {
+		for _, categoryID := range []int32{facet.FacetCategoryID, facet.TraitCategoryID} {
+			_, err := qtx.InsertMapping(c, InsertMappingParams{
+				QuestionID:  question.ID,
+				AnswerValue: pgtype.Int4{Int32: option, Valid: true},
+				CategoryID:  categoryID,
+				Points:      pgtype.Int4{Int32: earned, Valid: true},
+			})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save mappings"})
+				return
+			}
+		}
+	}
+	item, err := qtx.UpsertTraitItem(c, UpsertTraitItemParams{
+		QuestionID:   question.ID,
+		FacetID:      facet.ID,
+		ReverseKeyed: req.ReverseKeyed,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save trait item"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save trait item"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"question_id":   item.QuestionID,
+		"facet_id":      item.FacetID,
+		"code":          facet.Code,
+		"reverse_keyed": item.ReverseKeyed,
+		"points":        points,
+	})
+}
+
+func (h *SelfAssessmentHandler) CreateValidityScale(c *gin.Context) {
+	var req struct {
+		Name           string            `json:"name" binding:"required"`
+		Kind           ValidityScaleKind `json:"kind" binding:"required"`
+		AssessmentType string            `json:"assessment_type" binding:"required"`
+		Threshold      int32             `json:"threshold" binding:"required"`
+		Description    string            `json:"description"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Kind != ValidityScaleKindLie && req.Kind != ValidityScaleKindInfrequency {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Kind must be lie or infrequency"})
+		return
+	}
+	if req.AssessmentType != "behavioral" && req.AssessmentType != "personality" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validity scales apply to behavioral or personality assessments"})
+		return
+	}
+	if req.Threshold < 1 {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Threshold must be at least 1"})
+		return
+	}
+
+	scale, err := h.queries.CreateValidityScale(c, CreateValidityScaleParams{
+		Name:           req.Name,
+		Kind:           req.Kind,
+		AssessmentType: req.AssessmentType,
+		Threshold:      req.Threshold,
+		Description:    pgtype.Text{String: req.Description, Valid: req.Description != ""},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create validity scale"})
+		return
+	}
+	c.JSON(http.StatusCreated, scale)
+}
+
+func (h *SelfAssessmentHandler) ListValidityScales(c *gin.Context) {
+	scales, err := h.queries.ListValidityScales(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve validity scales"})
+		return
+	}
+	c.JSON(http.StatusOK, scales)
+}
+
+func (h *SelfAssessmentHandler) SetValidityItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
+		return
+	}
+
+	var req struct {
+		ScaleID      int32    `json:"scale_id" binding:"required"`
+		KeyedAnswers []string `json:"keyed_answers" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	questions, err := h.queries.GetQuestionsByIDs(c, []int32{int32(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question"})
+		return
+	}
+	if len(questions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		return
+	}
+	question := questions[0]
+	scale, err := h.queries.GetValidityScale(c, req.ScaleID)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Validity scale not found"})
+		return
+	}
+	if string(question.Type) != scale.AssessmentType {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Question and scale are for different assessment types"})
+		return
+	}
+	if err := validateKeyedAnswers(question, req.KeyedAnswers); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// Validity items are scored apart from categories, so a mapped question
+	// would count twice.
+	mapped, err := h.queries.CountQuestionMappings(c, question.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question mappings"})
+		return
+	}
+	if mapped > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "Question maps to categories; remove its mappings first"})
+		return
+	}
+
+	item, err := h.queries.UpsertValidityItem(c, UpsertValidityItemParams{
+		QuestionID:   question.ID,
+		ScaleID:      scale.ID,
+		KeyedAnswers: req.KeyedAnswers,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save validity item"})
+		return
+	}
+	c.JSON(http.StatusOK, item)
+}
+
+func (h *SelfAssessmentHandler) ListLocales(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	supported, err := h.queries.ListTranslationLocales(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve locales"})
+		return
+	}
+	saved, err := h.queries.GetUserLocale(c, int32(userID))
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve locale preference"})
+		return
+	}
+	locale, err := h.requestLocale(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to resolve locale"})
+		return
+	}
+
+	var preferred *string
+	if saved.Valid {
+		preferred = &saved.String
+	}
+	c.JSON(http.StatusOK, gin.H{
+		"locales":   append([]string{i18n.Default}, supported...),
+		"preferred": preferred,
+		"locale":    locale,
+	})
+}
+
+func (h *SelfAssessmentHandler) SetPreferredLocale(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req struct {
+		Locale string `json:"locale"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	// An empty locale clears the preference, falling back to Accept-Language.
+	var locale pgtype.Text
+	if req.Locale != "" {
+		canonical, err := i18n.Canonical(req.Locale)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+		locale = pgtype.Text{String: canonical, Valid: true}
+	}
+	err = h.queries.SetUserLocale(c, SetUserLocaleParams{
+		UserID: int32(userID),
+		Locale: locale,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save locale preference"})
+		return
+	}
+
+	var preferred *string
+	if locale.Valid {
+		preferred = &locale.String
+	}
+	c.JSON(http.StatusOK, gin.H{"preferred": preferred})
+}
+
+func (h *SelfAssessmentHandler) GetQuestionTranslations(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
+		return
+	}
+
+	translations, err := h.queries.ListTranslationsForQuestion(c, int32(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve translations"})
+		return
+	}
+	c.JSON(http.StatusOK, translations)
+}
+
+func (h *SelfAssessmentHandler) SetQuestionTranslation(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid question ID"})
+		return
+	}
+	locale, err := i18n.Canonical(c.Param("locale"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if locale == i18n.Default {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The default locale is edited on the question itself"})
+		return
+	}
+
+	var req struct {
+		Question string            `json:"question" binding:"required"`
+		Options  map[string]string `json:"options"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	questions, err := h.queries.GetQuestionsByIDs(c, []int32{int32(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load question"})
+		return
+	}
+	if len(questions) == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Question not found"})
+		return
+	}
+	if err := validateTranslation(questions[0], req.Question, req.Options); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Options == nil {
+		req.Options = map[string]string{}
+	}
+	optionsBytes, err := json.Marshal(req.Options)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process options"})
+		return
+	}
+
+	translation, err := h.queries.UpsertQuestionTranslation(c, UpsertQuestionTranslationParams{
+		QuestionID: int32(id),
+		Locale:     locale,
+		Question:   req.Question,
+		Options:    optionsBytes,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save translation"})
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
+
+func (h *SelfAssessmentHandler) SetCategoryTranslation(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid category ID"})
+		return
+	}
+	locale, err := i18n.Canonical(c.Param("locale"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if locale == i18n.Default {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "The default locale is edited on the category itself"})
+		return
+	}
+
+	var req struct {
+		Description string `json:"description" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	found, err := h.queries.CountCategoriesByIDs(c, []int32{int32(id)})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load category"})
+		return
+	}
+	if found == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Category not found"})
+		return
+	}
+
+	translation, err := h.queries.UpsertCategoryTranslation(c, UpsertCategoryTranslationParams{
+		CategoryID:  int32(id),
+		Locale:      locale,
+		Description: req.Description,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save translation"})
+		return
+	}
+	c.JSON(http.StatusOK, translation)
+}
+
+func (h *SelfAssessmentHandler) GetGradingQueue(c *gin.Context) {
+	var params ListGradingQueueParams
+	if status := c.Query("status"); status != "" {
+		switch GradingStatus(status) {
+		case GradingStatusPending, GradingStatusDisputed:
+			params.Status = NullGradingStatus{GradingStatus: GradingStatus(status), Valid: true}
+		default:
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Status must be pending or disputed"})
+			return
+		}
+	}
+	if c.Query("mine") == "true" {
+		userIDStr, exists := c.Get("userID")
+		if !exists {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+			return
+		}
+		userID, err := strconv.Atoi(userIDStr.(string))
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+			return
+		}
+		params.GraderID = pgtype.Int4{Int32: int32(userID), Valid: true}
+	}
+
+	items, err := h.queries.ListGradingQueue(c, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve grading queue"})
+		return
+	}
+	c.JSON(http.StatusOK, items)
+}
+
+func (h *SelfAssessmentHandler) GetGradingItem(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	item, err := h.queries.GetGradingItem(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Grading item not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grading item"})
+		return
+	}
+
+	criteria := []RubricCriterion{}
+	rubric, err := h.queries.GetRubric(c, item.QuestionID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load rubric"})
+		return
+	}
+	if err == nil {
+		if err := json.Unmarshal(rubric.Criteria, &criteria); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load rubric"})
+			return
+		}
+	}
+
+	assignments, err := h.queries.ListGradingAssignments(c, item.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grades"})
+		return
+	}
+	grades, err := presentGrades(assignments, int32(userID))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grades"})
+		return
+	}
+
+	var final map[string]int32
+	if item.FinalScores != nil {
+		if err := json.Unmarshal(item.FinalScores, &final); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grading item"})
+			return
+		}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"id":              item.ID,
+		"session_id":      item.SessionID,
+		"question_id":     item.QuestionID,
+		"question":        item.Question,
+		"assessment_type": item.AssessmentType,
+		"answer":          item.AnswerText,
+		"status":          item.Status,
+		"required_grades": item.RequiredGrades,
+		"criteria":        criteria,
+		"grades":          grades,
+		"final_scores":    final,
+		"created_at":      item.CreatedAt,
+		"graded_at":       item.GradedAt,
+	})
+}
+
+func (h *SelfAssessmentHandler) AssignGrader(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+	var req struct {
+		GraderID int32 `json:"grader_id" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := h.queries.GetStaffUser(c, req.GraderID); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Graders must be staff members"})
+			return
+		}
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grader"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	item, err := qtx.LockGradingItem(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Grading item not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grading item"})
+		return
+	}
+	if item.Status == GradingStatusGraded {
+		c.JSON(http.StatusConflict, gin.H{"error": "Item has already been graded"})
+		return
+	}
+
+	assignments, err := qtx.ListGradingAssignments(c, item.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grades"})
+		return
+	}
+	var markers, resolvers int32
+	for _, assignment := range assignments {
+		if assignment.GraderID == req.GraderID {
+			c.JSON(http.StatusConflict, gin.H{"error": "Grader is already assigned to this item"})
+			return
+		}
+		if assignment.Resolver {
+			resolvers++
+		} else {
+			markers++
+		}
+	}
+
+	// Disputed items get a resolver, whose grade settles the item.
+	resolver := item.Status == GradingStatusDisputed
+	if resolver && resolvers > 0 {
+		c.JSON(http.StatusConflict, gin.H{"error": "A resolver is already assigned to this item"})
+		return
+	}
+	if !resolver && markers >= item.RequiredGrades {
+		c.JSON(http.StatusConflict, gin.H{"error": fmt.Sprintf("Item already has %d grader(s)", item.RequiredGrades)})
+		return
+	}
+
+	assignment, err := qtx.CreateGradingAssignment(c, CreateGradingAssignmentParams{
+		ItemID:   item.ID,
+		GraderID: req.GraderID,
+		Resolver: resolver,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign grader"})
+		return
+	}
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to assign grader"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"item_id":     assignment.ItemID,
+		"grader_id":   assignment.GraderID,
+		"resolver":    assignment.Resolver,
+		"assigned_at": assignment.AssignedAt,
+	})
+}
+
+func (h *SelfAssessmentHandler) SubmitGrade(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid item ID"})
+		return
+	}
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req struct {
+		Scores  map[string]int32 `json:"scores" binding:"required"`
+		Comment string           `json:"comment"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	details, err := h.queries.GetGradingItem(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Grading item not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grading item"})
+		return
+	}
+	rubric, err := h.queries.GetRubric(c, details.QuestionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusConflict, gin.H{"error": "Question has no rubric yet"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load rubric"})
+		return
+	}
+	var criteria []RubricCriterion
+	if err := json.Unmarshal(rubric.Criteria, &criteria); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load rubric"})
+		return
+	}
+	if err := validateGrade(criteria, req.Scores); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	scores, err := json.Marshal(req.Scores)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process grade"})
+		return
+	}
+
+	tx, err := h.db.Begin(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to start transaction"})
+		return
+	}
+	defer tx.Rollback(c)
+	qtx := h.queries.WithTx(tx)
+
+	// The session is locked before the item so the last two grades of a
+	// session cannot both see the other item as open and skip scoring.
+	session, err := qtx.LockAssessmentSession(c, details.SessionID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load session"})
+		return
+	}
+	item, err := qtx.LockGradingItem(c, details.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grading item"})
+		return
+	}
+	if item.Status == GradingStatusGraded {
+		c.JSON(http.StatusConflict, gin.H{"error": "Item has already been graded"})
+		return
+	}
+
+	_, err = qtx.SubmitGrade(c, SubmitGradeParams{
+		ItemID:          item.ID,
+		GraderID:        int32(userID),
+		CriterionScores: scores,
+		Comment:         pgtype.Text{String: req.Comment, Valid: req.Comment != ""},
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusForbidden, gin.H{"error": "You have no open assignment for this item"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save grade"})
+		return
+	}
+
+	assignments, err := qtx.ListGradingAssignments(c, item.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grades"})
+		return
+	}
+	status, final, err := resolveItem(item, rubric.MaxDisagreement, assignments)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load grades"})
+		return
+	}
+
+	finalized := false
+	switch status {
+	case GradingStatusGraded:
+		points, err := json.Marshal(categoryPoints(criteria, final))
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process grade"})
+			return
+		}
+		finalScores, err := json.Marshal(final)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to process grade"})
+			return
+		}
+		if err := qtx.SetAnswerScores(c, SetAnswerScoresParams{Scores: points, ID: item.AnswerID}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to store answer scores"})
+			return
+		}
+		if err := qtx.CompleteGradingItem(c, CompleteGradingItemParams{ID: item.ID, FinalScores: finalScores}); err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to complete grading item"})
+			return
+		}
+
+		open, err := qtx.CountUngradedItems(c, session.ID)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to check grading progress"})
+			return
+		}
+		if open == 0 && !session.CompletedAt.Valid {
+			if err := finalizeSession(c, qtx, session.ID, session.UserID, session.AssessmentType); err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": fmt.Sprintf("Failed to calculate scores: %v", err)})
+				return
+			}
+			finalized = true
+		}
+	case GradingStatusDisputed:
+		if item.Status != GradingStatusDisputed {
+			err := qtx.SetGradingItemStatus(c, SetGradingItemStatusParams{ID: item.ID, Status: status})
+			if err != nil {
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update grading item"})
+				return
+			}
+		}
+	}
+
+	if err := tx.Commit(c); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to save grade"})
+		return
 	}
 
 	c.JSON(http.StatusOK, gin.H{
-		"user_id":         req.UserID,
-		"assessment_type": req.AssessmentType,
-		"session_id":      latestSessionID,
-		"results":         results,
+		"item_id":           item.ID,
+		"status":            status,
+		"final_scores":      final,
+		"session_completed": finalized,
 	})
 }
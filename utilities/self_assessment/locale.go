@@ -0,0 +1,201 @@
+package self_assessment
+
+import (
+	"backend/pkg/i18n"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// requestLocale works out the locale to answer a request in: the user's
+// saved preference first, then the Accept-Language header, matched against
+// the locales that have translations. The choice is echoed in the
+// Content-Language header.
+func (h *SelfAssessmentHandler) requestLocale(c *gin.Context) (string, error) {
+	var preferred []string
+	if userIDStr, exists := c.Get("userID"); exists {
+		if userID, err := strconv.Atoi(userIDStr.(string)); err == nil {
+			saved, err := h.queries.GetUserLocale(c, int32(userID))
+			if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+				return "", err
+			}
+			if saved.Valid {
+				preferred = append(preferred, saved.String)
+			}
+		}
+	}
+	preferred = append(preferred, i18n.ParseAcceptLanguage(c.GetHeader("Accept-Language"))...)
+
+	supported, err := h.queries.ListTranslationLocales(c)
+	if err != nil {
+		return "", err
+	}
+	locale := i18n.Match(preferred, append(supported, i18n.Default))
+	c.Header("Content-Language", locale)
+	return locale, nil
+}
+
+// questionTranslations loads a locale's translations of the given questions,
+// keyed by question ID. The default locale has none.
+func (h *SelfAssessmentHandler) questionTranslations(ctx context.Context, locale string, ids []int32) (map[int32]QuestionTranslation, error) {
+	translations := make(map[int32]QuestionTranslation)
+	if locale == i18n.Default || len(ids) == 0 {
+		return translations, nil
+	}
+	rows, err := h.queries.ListQuestionTranslations(ctx, ListQuestionTranslationsParams{
+		Locale:      locale,
+		QuestionIds: ids,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		translations[row.QuestionID] = row
+	}
+	return translations, nil
+}
+
+// categoryDescriptions loads a locale's category descriptions, keyed by
+// category ID.
+func (h *SelfAssessmentHandler) categoryDescriptions(ctx context.Context, locale string) (map[int32]string, error) {
+	descriptions := make(map[int32]string)
+	if locale == i18n.Default {
+		return descriptions, nil
+	}
+	rows, err := h.queries.ListCategoryTranslations(ctx, locale)
+	if err != nil {
+		return nil, err
+	}
+	for _, row := range rows {
+		descriptions[row.CategoryID] = row.Description
+	}
+	return descriptions, nil
+}
+
+// localizeSessionQuestions overlays the request locale on the questions of a
+// session before they are put in display order.
+func (h *SelfAssessmentHandler) localizeSessionQuestions(c *gin.Context, rows []ListSessionQuestionsRow) ([]ListSessionQuestionsRow, error) {
+	locale, err := h.requestLocale(c)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]int32, len(rows))
+	for i, row := range rows {
+		ids[i] = row.ID
+	}
+	translations, err := h.questionTranslations(c, locale, ids)
+	if err != nil {
+		return nil, err
+	}
+	for i, row := range rows {
+		translation, ok := translations[row.ID]
+		if !ok {
+			continue
+		}
+		rows[i].Question, rows[i].Options, err = translateQuestion(row.Format, row.Options, translation)
+		if err != nil {
+			return nil, fmt.Errorf("question %d: %w", row.ID, err)
+		}
+	}
+	return rows, nil
+}
+
+// translateQuestion returns a question's text and options in a translation.
+// Only option labels change: keys, points and everything else that scoring
+// relies on stay as stored. Labels the translation lacks stay in English.
+func translateQuestion(format QuestionFormat, options []byte, translation QuestionTranslation) (string, []byte, error) {
+	var labels map[string]string
+	if err := json.Unmarshal(translation.Options, &labels); err != nil {
+		return "", nil, errors.New("translation has invalid option labels")
+	}
+	if len(labels) == 0 || !showsOptions(format) {
+		return translation.Question, options, nil
+	}
+
+	var parsed map[string]json.RawMessage
+	if err := json.Unmarshal(options, &parsed); err != nil {
+		return "", nil, err
+	}
+	for key, label := range labels {
+		raw, ok := parsed[key]
+		if !ok {
+			continue
+		}
+		labelBytes, err := json.Marshal(label)
+		if err != nil {
+			return "", nil, err
+		}
+		// Scored options are objects with the label in "text"; the
+		// others are the label itself.
+		var option map[string]json.RawMessage
+		if err := json.Unmarshal(raw, &option); err == nil && option != nil {
+			option["text"] = labelBytes
+			if labelBytes, err = json.Marshal(option); err != nil {
+				return "", nil, err
+			}
+		}
+		parsed[key] = labelBytes
+	}
+	translated, err := json.Marshal(parsed)
+	if err != nil {
+		return "", nil, err
+	}
+	return translation.Question, translated, nil
+}
+
+// validateTranslation checks a translation against the question it
+// translates: option labels may only be given for options candidates see,
+// and only under keys the question has.
+func validateTranslation(question SelfAssessmentQuestion, text string, labels map[string]string) error {
+	if text == "" {
+		return errors.New("translated question text is required")
+	}
+	if len(labels) == 0 {
+		return nil
+	}
+	if !showsOptions(question.Format) {
+		return fmt.Errorf("%s questions have no option labels to translate", question.Format)
+	}
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(question.Options, &options); err != nil {
+		return errors.New("question has invalid options")
+	}
+	for key, label := range labels {
+		if _, ok := options[key]; !ok {
+			return fmt.Errorf("option %q is not one of the question's options", key)
+		}
+		if label == "" {
+			return fmt.Errorf("option %q needs a label", key)
+		}
+	}
+	return nil
+}
+
+// sessionScores loads a session's category scores with descriptions in the
+// request locale.
+func (h *SelfAssessmentHandler) sessionScores(c *gin.Context, sessionID int32) ([]GetSessionScoresRow, error) {
+	scores, err := h.queries.GetSessionScores(c, pgtype.Int4{Int32: sessionID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	locale, err := h.requestLocale(c)
+	if err != nil {
+		return nil, err
+	}
+	descriptions, err := h.categoryDescriptions(c, locale)
+	if err != nil {
+		return nil, err
+	}
+	for i, score := range scores {
+		if description, ok := descriptions[score.CategoryID.Int32]; ok {
+			scores[i].CategoryDescription = pgtype.Text{String: description, Valid: true}
+		}
+	}
+	return scores, nil
+}
@@ -11,6 +11,140 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type BackgroundJobStatus string
+
+const (
+	BackgroundJobStatusQueued    BackgroundJobStatus = "queued"
+	BackgroundJobStatusRunning   BackgroundJobStatus = "running"
+	BackgroundJobStatusSucceeded BackgroundJobStatus = "succeeded"
+	BackgroundJobStatusFailed    BackgroundJobStatus = "failed"
+)
+
+func (e *BackgroundJobStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = BackgroundJobStatus(s)
+	case string:
+		*e = BackgroundJobStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for BackgroundJobStatus: %T", src)
+	}
+	return nil
+}
+
+type NullBackgroundJobStatus struct {
+	BackgroundJobStatus BackgroundJobStatus
+	Valid               bool // Valid is true if BackgroundJobStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullBackgroundJobStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.BackgroundJobStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.BackgroundJobStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullBackgroundJobStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.BackgroundJobStatus), nil
+}
+
+type GradingStatus string
+
+const (
+	GradingStatusPending  GradingStatus = "pending"
+	GradingStatusDisputed GradingStatus = "disputed"
+	GradingStatusGraded   GradingStatus = "graded"
+)
+
+func (e *GradingStatus) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = GradingStatus(s)
+	case string:
+		*e = GradingStatus(s)
+	default:
+		return fmt.Errorf("unsupported scan type for GradingStatus: %T", src)
+	}
+	return nil
+}
+
+type NullGradingStatus struct {
+	GradingStatus GradingStatus
+	Valid         bool // Valid is true if GradingStatus is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullGradingStatus) Scan(value interface{}) error {
+	if value == nil {
+		ns.GradingStatus, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.GradingStatus.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullGradingStatus) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.GradingStatus), nil
+}
+
+type QuestionFormat string
+
+const (
+	QuestionFormatSingleChoice QuestionFormat = "single_choice"
+	QuestionFormatMultiSelect  QuestionFormat = "multi_select"
+	QuestionFormatRanking      QuestionFormat = "ranking"
+	QuestionFormatNumeric      QuestionFormat = "numeric"
+	QuestionFormatFreeText     QuestionFormat = "free_text"
+	QuestionFormatOpenEnded    QuestionFormat = "open_ended"
+	QuestionFormatSituational  QuestionFormat = "situational"
+)
+
+func (e *QuestionFormat) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = QuestionFormat(s)
+	case string:
+		*e = QuestionFormat(s)
+	default:
+		return fmt.Errorf("unsupported scan type for QuestionFormat: %T", src)
+	}
+	return nil
+}
+
+type NullQuestionFormat struct {
+	QuestionFormat QuestionFormat
+	Valid          bool // Valid is true if QuestionFormat is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullQuestionFormat) Scan(value interface{}) error {
+	if value == nil {
+		ns.QuestionFormat, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.QuestionFormat.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullQuestionFormat) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.QuestionFormat), nil
+}
+
 type QuestionType string
 
 const (
@@ -54,12 +188,185 @@ func (ns NullQuestionType) Value() (driver.Value, error) {
 	return string(ns.QuestionType), nil
 }
 
+type ValidityScaleKind string
+
+const (
+	ValidityScaleKindLie         ValidityScaleKind = "lie"
+	ValidityScaleKindInfrequency ValidityScaleKind = "infrequency"
+)
+
+func (e *ValidityScaleKind) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = ValidityScaleKind(s)
+	case string:
+		*e = ValidityScaleKind(s)
+	default:
+		return fmt.Errorf("unsupported scan type for ValidityScaleKind: %T", src)
+	}
+	return nil
+}
+
+type NullValidityScaleKind struct {
+	ValidityScaleKind ValidityScaleKind
+	Valid             bool // Valid is true if ValidityScaleKind is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullValidityScaleKind) Scan(value interface{}) error {
+	if value == nil {
+		ns.ValidityScaleKind, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.ValidityScaleKind.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullValidityScaleKind) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.ValidityScaleKind), nil
+}
+
+type AdaptiveSession struct {
+	SessionID     int32
+	TargetSe      float64
+	MaxItems      int32
+	Theta         float64
+	StandardError float64
+}
+
+type AssessmentBlueprint struct {
+	ID               int32
+	Name             string
+	AssessmentType   string
+	ShuffleOptions   bool
+	MaxItemExposures pgtype.Int4
+	CreatedAt        pgtype.Timestamp
+	TimeLimitMinutes pgtype.Int4
+}
+
+type AssessmentBlueprintSection struct {
+	ID            int32
+	BlueprintID   int32
+	CategoryID    int32
+	QuestionCount int32
+}
+
+type BackgroundJob struct {
+	ID          int64
+	Kind        string
+	Payload     []byte
+	Status      BackgroundJobStatus
+	Attempts    int32
+	MaxAttempts int32
+	RunAt       pgtype.Timestamp
+	LockedUntil pgtype.Timestamp
+	LastError   pgtype.Text
+	CreatedAt   pgtype.Timestamp
+	StartedAt   pgtype.Timestamp
+	FinishedAt  pgtype.Timestamp
+}
+
+type CandidateAccommodation struct {
+	ID                 int32
+	UserID             int32
+	SessionID          pgtype.Int4
+	TimeMultiplier     float64
+	BreakMinutes       int32
+	AlternativeFormats []string
+	Reason             string
+	ApprovedBy         int32
+	CreatedAt          pgtype.Timestamp
+	RevokedAt          pgtype.Timestamp
+	RevokedBy          pgtype.Int4
+}
+
+type CandidateListing struct {
+	UserID             pgtype.Int4
+	SessionID          pgtype.Int4
+	TopBehavioralTrait pgtype.Text
+	TopBehavioralScore pgtype.Int4
+}
+
+type CategoryTranslation struct {
+	CategoryID  int32
+	Locale      string
+	Description string
+	UpdatedAt   pgtype.Timestamp
+}
+
+type GradingAssignment struct {
+	ID              int32
+	ItemID          int32
+	GraderID        int32
+	Resolver        bool
+	CriterionScores []byte
+	Comment         pgtype.Text
+	AssignedAt      pgtype.Timestamp
+	SubmittedAt     pgtype.Timestamp
+}
+
+type GradingItem struct {
+	ID             int32
+	AnswerID       int32
+	SessionID      int32
+	QuestionID     int32
+	RequiredGrades int32
+	Status         GradingStatus
+	FinalScores    []byte
+	CreatedAt      pgtype.Timestamp
+	GradedAt       pgtype.Timestamp
+}
+
+type OutboxEvent struct {
+	ID           int64
+	EventType    string
+	DedupKey     string
+	Payload      []byte
+	CreatedAt    pgtype.Timestamp
+	AvailableAt  pgtype.Timestamp
+	Attempts     int32
+	LastError    pgtype.Text
+	DispatchedAt pgtype.Timestamp
+}
+
+type QuestionTranslation struct {
+	QuestionID int32
+	Locale     string
+	Question   string
+	Options    []byte
+	UpdatedAt  pgtype.Timestamp
+}
+
+type Role struct {
+	ID        int32
+	Name      string
+	CreatedAt pgtype.Timestamp
+}
+
 type SelfAssessmentCategory struct {
 	ID          int32
 	Name        pgtype.Text
 	Description pgtype.Text
 }
 
+type SelfAssessmentItemParameter struct {
+	QuestionID     int32
+	Discrimination float64
+	Difficulty     float64
+	UpdatedAt      pgtype.Timestamp
+}
+
+type SelfAssessmentItemPair struct {
+	ID               int32
+	QuestionID       int32
+	PairedQuestionID int32
+	Reversed         bool
+}
+
 type SelfAssessmentMapping struct {
 	ID          int32
 	QuestionID  int32
@@ -75,6 +382,50 @@ type SelfAssessmentQuestion struct {
 	Options       []byte
 	CorrectAnswer pgtype.Text
 	CreatedAt     pgtype.Timestamp
+	Format        QuestionFormat
+}
+
+type SelfAssessmentRubric struct {
+	QuestionID      int32
+	Criteria        []byte
+	DoubleMarked    bool
+	MaxDisagreement int32
+	UpdatedAt       pgtype.Timestamp
+}
+
+type SessionAccommodation struct {
+	SessionID       int32
+	AccommodationID int32
+}
+
+type SessionValidityScore struct {
+	SessionID int32
+	ScaleID   int32
+	Score     int32
+	ItemCount int32
+	Flagged   bool
+}
+
+type TraitFacet struct {
+	ID              int32
+	ModelID         int32
+	Code            string
+	TraitCategoryID int32
+	FacetCategoryID int32
+	Position        int32
+}
+
+type TraitItem struct {
+	QuestionID   int32
+	FacetID      int32
+	ReverseKeyed bool
+}
+
+type TraitModel struct {
+	ID        int32
+	Name      string
+	Source    pgtype.Text
+	CreatedAt pgtype.Timestamp
 }
 
 type User struct {
@@ -92,6 +443,7 @@ type UserAnswer struct {
 	SessionID   pgtype.Int4
 	QuestionID  pgtype.Int4
 	AnswerValue []byte
+	AnsweredAt  pgtype.Timestamp
 }
 
 type UserAssessmentScore struct {
@@ -100,6 +452,8 @@ type UserAssessmentScore struct {
 	SessionID  pgtype.Int4
 	CategoryID pgtype.Int4
 	Score      pgtype.Int4
+	Theta      pgtype.Float8
+	ThetaSe    pgtype.Float8
 }
 
 type UserAssessmentSession struct {
@@ -108,4 +462,37 @@ type UserAssessmentSession struct {
 	AssessmentType string
 	StartedAt      pgtype.Timestamp
 	CompletedAt    pgtype.Timestamp
+	BlueprintID    pgtype.Int4
+	QualityFlags   []byte
+	SubmittedAt    pgtype.Timestamp
+}
+
+type UserPreference struct {
+	UserID      int32
+	Locale      pgtype.Text
+	EmailOptOut bool
+}
+
+type UserSessionQuestion struct {
+	SessionID   int32
+	QuestionID  int32
+	Position    int32
+	OptionOrder []byte
+	AnsweredAt  pgtype.Timestamp
+}
+
+type ValidityItem struct {
+	QuestionID   int32
+	ScaleID      int32
+	KeyedAnswers []string
+}
+
+type ValidityScale struct {
+	ID             int32
+	Name           string
+	Kind           ValidityScaleKind
+	AssessmentType string
+	Threshold      int32
+	Description    pgtype.Text
+	CreatedAt      pgtype.Timestamp
 }
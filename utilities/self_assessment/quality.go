@@ -0,0 +1,215 @@
+package self_assessment
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// straightLineMinItems is the fewest answers needed to judge straight-lining.
+	straightLineMinItems = 8
+	// straightLineShare is the share of answers on one option that counts as
+	// straight-lining.
+	straightLineShare = 0.8
+	// longStringRun is the run of identical consecutive answers that is flagged.
+	longStringRun = 8
+	// pairDifferenceLimit is how far apart, on a 0-1 scale, the answers to two
+	// paired items may be before they count as inconsistent.
+	pairDifferenceLimit = 0.5
+
+	likertMinSeconds    = 2.0
+	cognitiveMinSeconds = 5.0
+)
+
+// Response-quality flags.
+const (
+	flagStraightLining    = "straight_lining"
+	flagLongString        = "long_string"
+	flagFastCompletion    = "fast_completion"
+	flagInconsistentPairs = "inconsistent_pairs"
+)
+
+// QualityFlag is a response pattern that suggests careless or dishonest answering.
+type QualityFlag struct {
+	Flag   string `json:"flag"`
+	Detail string `json:"detail"`
+}
+
+// analyzeQuality looks for careless response patterns in a scored session.
+// Answers are only accepted on a started session, so completion speed is
+// measured from startedAt.
+func analyzeQuality(answers []ListQualityAnswersRow, pairs []SelfAssessmentItemPair, startedAt *time.Time, completedAt time.Time) []QualityFlag {
+	flags := []QualityFlag{}
+	if len(answers) == 0 {
+		return flags
+	}
+
+	counts := make(map[string]int)
+	longest, run := 0, 0
+	for i, answer := range answers {
+		counts[answer.Selected]++
+		if i > 0 && answer.Selected == answers[i-1].Selected {
+			run++
+		} else {
+			run = 1
+		}
+		if run > longest {
+			longest = run
+		}
+	}
+
+	if len(answers) >= straightLineMinItems {
+		top, topCount := "", 0
+		for selected, count := range counts {
+			if count > topCount || (count == topCount && selected < top) {
+				top, topCount = selected, count
+			}
+		}
+		if share := float64(topCount) / float64(len(answers)); share >= straightLineShare {
+			flags = append(flags, QualityFlag{
+				Flag:   flagStraightLining,
+				Detail: fmt.Sprintf("%d of %d answers chose option %s", topCount, len(answers), top),
+			})
+		}
+	}
+	if longest >= longStringRun {
+		flags = append(flags, QualityFlag{
+			Flag:   flagLongString,
+			Detail: fmt.Sprintf("%d identical answers in a row", longest),
+		})
+	}
+
+	if detail, fast := fastCompletion(answers, startedAt, completedAt); fast {
+		flags = append(flags, QualityFlag{Flag: flagFastCompletion, Detail: detail})
+	}
+
+	if inconsistent, answered := inconsistentPairs(answers, pairs); answered > 0 && inconsistent*2 >= answered {
+		flags = append(flags, QualityFlag{
+			Flag:   flagInconsistentPairs,
+			Detail: fmt.Sprintf("%d of %d paired items answered inconsistently", inconsistent, answered),
+		})
+	}
+	return flags
+}
+
+// fastCompletion compares time per item with what reading the item takes.
+// The average over the whole session is used when the start is known, and
+// the median gap between answers when answers carry timestamps.
+func fastCompletion(answers []ListQualityAnswersRow, startedAt *time.Time, completedAt time.Time) (string, bool) {
+	minSeconds := likertMinSeconds
+	if answers[0].Type == QuestionTypeCognitive {
+		minSeconds = cognitiveMinSeconds
+	}
+
+	if startedAt != nil {
+		average := completedAt.Sub(*startedAt).Seconds() / float64(len(answers))
+		if average < minSeconds {
+			return fmt.Sprintf("%.1fs per item on average, expected at least %.0fs", average, minSeconds), true
+		}
+	}
+
+	var times []time.Time
+	for _, answer := range answers {
+		if answer.AnsweredAt.Valid {
+			times = append(times, answer.AnsweredAt.Time)
+		}
+	}
+	if len(times) < 2 {
+		return "", false
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	gaps := make([]float64, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		gaps = append(gaps, times[i].Sub(times[i-1]).Seconds())
+	}
+	sort.Float64s(gaps)
+	median := gaps[len(gaps)/2]
+	if len(gaps)%2 == 0 {
+		median = (gaps[len(gaps)/2-1] + gaps[len(gaps)/2]) / 2
+	}
+	if median < minSeconds {
+		return fmt.Sprintf("median %.1fs between answers, expected at least %.0fs", median, minSeconds), true
+	}
+	return "", false
+}
+
+// inconsistentPairs counts the answered item pairs whose answers sit too far
+// apart on the option scale.
+func inconsistentPairs(answers []ListQualityAnswersRow, pairs []SelfAssessmentItemPair) (int, int) {
+	positions := make(map[int32]float64)
+	for _, answer := range answers {
+		if position, ok := scalePosition(answer.Options, answer.Selected); ok {
+			positions[answer.QuestionID.Int32] = position
+		}
+	}
+
+	var inconsistent, answered int
+	for _, pair := range pairs {
+		a, okA := positions[pair.QuestionID]
+		b, okB := positions[pair.PairedQuestionID]
+		if !okA || !okB {
+			continue
+		}
+		if pair.Reversed {
+			b = 1 - b
+		}
+		answered++
+		if a-b > pairDifferenceLimit || b-a > pairDifferenceLimit {
+			inconsistent++
+		}
+	}
+	return inconsistent, answered
+}
+
+// scalePosition places a selected option on a 0-1 scale by its rank among
+// the question's option keys.
+func scalePosition(options []byte, selected string) (float64, bool) {
+	var parsed map[string]json.RawMessage
+	if err := json.Unmarshal(options, &parsed); err != nil || len(parsed) < 2 {
+		return 0, false
+	}
+	keys := make([]string, 0, len(parsed))
+	for key := range parsed {
+		keys = append(keys, key)
+	}
+	sortOptionKeys(keys)
+	for i, key := range keys {
+		if key == selected {
+			return float64(i) / float64(len(keys)-1), true
+		}
+	}
+	return 0, false
+}
+
+// recordQuality analyzes a scored session and stores the flags on it.
+func recordQuality(ctx context.Context, q *Queries, session UserAssessmentSession) ([]QualityFlag, error) {
+	answers, err := q.ListQualityAnswers(ctx, pgtype.Int4{Int32: session.ID, Valid: true})
+	if err != nil {
+		return nil, err
+	}
+	pairs, err := q.ListItemPairs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var startedAt *time.Time
+	if session.StartedAt.Valid {
+		startedAt = &session.StartedAt.Time
+	}
+	flags := analyzeQuality(answers, pairs, startedAt, time.Now())
+
+	encoded, err := json.Marshal(flags)
+	if err != nil {
+		return nil, err
+	}
+	err = q.UpdateSessionQualityFlags(ctx, UpdateSessionQualityFlagsParams{
+		ID:           session.ID,
+		QualityFlags: encoded,
+	})
+	return flags, err
+}
@@ -13,15 +13,21 @@ INSERT INTO self_assessment_questions(
     type,
     options,
     correct_answer,
+    format,
     created_at
 )VALUES(
     $1,
     $2,
     $3,
     $4,
+    $5,
     CURRENT_TIMESTAMP
 ) RETURNING *;
 
+-- name: DeleteQuestionMappings :exec
+DELETE FROM self_assessment_mappings
+WHERE question_id = $1;
+
 -- name: InsertMapping :one
 INSERT INTO self_assessment_mappings(
     question_id,
@@ -38,77 +44,76 @@ INSERT INTO self_assessment_mappings(
 -- name: CreateAssessmentSession :one
 INSERT INTO user_assessment_sessions(
     user_id,
-    assessment_type
+    assessment_type,
+    blueprint_id
 )VALUES(
-    $1, $2
+    $1, $2, $3
 )RETURNING *;
 
+-- name: GetAssessmentSession :one
+SELECT * FROM user_assessment_sessions
+WHERE id = $1 LIMIT 1;
+
+-- name: GetOpenAssessmentSession :one
+-- The latest unfinished fixed-form session a user started for an assessment type
+SELECT * FROM user_assessment_sessions s
+WHERE s.user_id = $1 AND s.assessment_type = $2 AND s.completed_at IS NULL AND s.submitted_at IS NULL AND
+ NOT EXISTS (SELECT 1 FROM adaptive_sessions a WHERE a.session_id = s.id)
+ORDER BY s.id DESC
+LIMIT 1;
+
 -- name: InsertUserAnswer :exec
 INSERT INTO user_answers (
-    user_id, session_id, question_id, answer_value
+    user_id, session_id, question_id, answer_value, answered_at
 ) VALUES (
-    $1, $2, $3, $4
+    $1, $2, $3, $4, $5
 ) RETURNING *;
 
--- name: GetAssessmentQuestionBehavioral :many
-SELECT * from self_assessment_questions
-where type = 'behavioral';
-
--- name: GetAssessmentQuestionPersonality :many
-SELECT * from self_assessment_questions
-where type = 'personality';
-
--- name: GetAssessmentQuestionCognitive :many
-SELECT * from self_assessment_questions
-where type = 'cognitive';
-
 -- name: GetUserCompletedAssessments :many
 SELECT assessment_type, completed_at 
 FROM user_assessment_sessions
 WHERE user_id = $1 AND completed_at IS NOT NULL;
 
 -- name: ListCandidateScores :many
-WITH user_category_scores AS (
-SELECT
- uas.user_id,
- uas.session_id,
- uas.category_id,
- uas.score,
- sac.name AS category_name
-FROM
- user_assessment_scores uas
-JOIN
- self_assessment_categories sac ON uas.category_id = sac.id
-JOIN
- user_assessment_sessions sessions ON uas.session_id = sessions.id
-WHERE
- sessions.assessment_type = 'behavioral'
-),
-candidate_behavioral_scores AS (
-SELECT
- user_id,
- session_id,
- category_id,
- category_name,
- score,
- RANK() OVER (PARTITION BY user_id ORDER BY score DESC) as score_rank
-FROM
- user_category_scores
-)
+-- Top behavioral trait per candidate, narrowed by the optional listing filters
 SELECT
  user_id,
  session_id,
- category_name AS top_behavioral_trait,
- score AS top_behavioral_score,
+ top_behavioral_trait,
+ top_behavioral_score,
 CASE
-WHEN COUNT(category_name) OVER (PARTITION BY user_id) > 0
+WHEN COUNT(top_behavioral_trait) OVER (PARTITION BY user_id) > 0
 THEN 'In Progress'
  ELSE 'Not Started'
-END AS assessment_status
+END AS assessment_status,
+ ARRAY(
+   SELECT DISTINCT f->>'flag'
+   FROM user_assessment_sessions s, jsonb_array_elements(s.quality_flags) f
+   WHERE s.user_id = cl.user_id
+   ORDER BY 1
+ )::text[] AS quality_flags
 FROM
- candidate_behavioral_scores
+ candidate_listing cl
 WHERE
- score_rank = 1
+ (sqlc.narg(search)::text IS NULL OR EXISTS (
+   SELECT 1 FROM users u
+   WHERE u.id = cl.user_id AND
+   (u.name ILIKE '%' || sqlc.narg(search) || '%' OR u.email ILIKE '%' || sqlc.narg(search) || '%')
+ )) AND
+ (sqlc.narg(assessment_type)::text IS NULL OR EXISTS (
+   SELECT 1 FROM user_assessment_sessions s
+   WHERE s.user_id = cl.user_id AND
+   s.assessment_type = sqlc.narg(assessment_type) AND
+   s.completed_at IS NOT NULL
+ )) AND
+ (sqlc.narg(completed_from)::timestamp IS NULL OR EXISTS (
+   SELECT 1 FROM user_assessment_sessions s
+   WHERE s.user_id = cl.user_id AND s.completed_at >= sqlc.narg(completed_from)
+ )) AND
+ (sqlc.narg(completed_to)::timestamp IS NULL OR EXISTS (
+   SELECT 1 FROM user_assessment_sessions s
+   WHERE s.user_id = cl.user_id AND s.completed_at < sqlc.narg(completed_to)
+ ))
 ORDER BY
  user_id;
 
@@ -134,7 +139,21 @@ WITH answers AS (
     uas.id = ua.session_id
   WHERE 
     ua.session_id = $1 AND
-    uas.assessment_type = 'behavioral'
+    uas.assessment_type = 'behavioral' AND
+    ua.answer_value->>'format' IS NULL
+),
+typed_answers AS (
+  -- Typed formats are scored when answered and carry their category points
+  SELECT
+    (s->>'category_id')::int as category_id,
+    (s->>'points')::int as points
+  FROM user_answers ua
+  JOIN user_assessment_sessions uas ON uas.id = ua.session_id
+  CROSS JOIN LATERAL jsonb_array_elements(ua.answer_value->'scores') s
+  WHERE
+    ua.session_id = $1 AND
+    uas.assessment_type = 'behavioral' AND
+    ua.answer_value->>'format' IS NOT NULL
 )
 INSERT INTO user_assessment_scores (
   user_id,
@@ -147,7 +166,11 @@ SELECT
   $1,
   a.category_id,
   SUM(a.points)
-FROM answers a
+FROM (
+  SELECT category_id, points FROM answers
+  UNION ALL
+  SELECT category_id, points FROM typed_answers
+) a
 GROUP BY a.category_id;
 
 -- name: CalculatePersonalityScores :exec
@@ -156,7 +179,8 @@ WITH answers AS (
   SELECT 
     ua.session_id, 
     ua.question_id,
-    (ua.answer_value->>'points')::int as points,
+    -- Mapping points carry the keying of reverse-keyed items
+    COALESCE(sam.points, (ua.answer_value->>'points')::int) as points,
     sam.category_id
   FROM user_answers ua
   JOIN self_assessment_mappings sam ON 
@@ -166,7 +190,21 @@ WITH answers AS (
     uas.id = ua.session_id
   WHERE 
     ua.session_id = $1 AND
-    uas.assessment_type = 'personality'
+    uas.assessment_type = 'personality' AND
+    ua.answer_value->>'format' IS NULL
+),
+typed_answers AS (
+  -- Typed formats are scored when answered and carry their category points
+  SELECT
+    (s->>'category_id')::int as category_id,
+    (s->>'points')::int as points
+  FROM user_answers ua
+  JOIN user_assessment_sessions uas ON uas.id = ua.session_id
+  CROSS JOIN LATERAL jsonb_array_elements(ua.answer_value->'scores') s
+  WHERE
+    ua.session_id = $1 AND
+    uas.assessment_type = 'personality' AND
+    ua.answer_value->>'format' IS NOT NULL
 )
 INSERT INTO user_assessment_scores (
   user_id,
@@ -179,7 +217,11 @@ SELECT
   $1,
   a.category_id,
   SUM(a.points)
-FROM answers a
+FROM (
+  SELECT category_id, points FROM answers
+  UNION ALL
+  SELECT category_id, points FROM typed_answers
+) a
 GROUP BY a.category_id;
 
 -- name: GetSessionScores :many
@@ -231,7 +273,21 @@ WITH answers AS (
     uas.id = ua.session_id
   WHERE 
     ua.session_id = $1 AND
-    uas.assessment_type = 'cognitive'
+    uas.assessment_type = 'cognitive' AND
+    ua.answer_value->>'format' IS NULL
+),
+typed_answers AS (
+  -- Typed formats are scored when answered and carry their category points
+  SELECT
+    (s->>'category_id')::int as category_id,
+    (s->>'points')::int as points
+  FROM user_answers ua
+  JOIN user_assessment_sessions uas ON uas.id = ua.session_id
+  CROSS JOIN LATERAL jsonb_array_elements(ua.answer_value->'scores') s
+  WHERE
+    ua.session_id = $1 AND
+    uas.assessment_type = 'cognitive' AND
+    ua.answer_value->>'format' IS NOT NULL
 )
 INSERT INTO user_assessment_scores (
   user_id,
@@ -244,5 +300,647 @@ SELECT
   $1,
   a.category_id,
   SUM(a.points)
-FROM answers a
-GROUP BY a.category_id;
\ No newline at end of file
+FROM (
+  SELECT category_id, points FROM answers
+  UNION ALL
+  SELECT category_id, points FROM typed_answers
+) a
+GROUP BY a.category_id;
+
+-- name: CreateBlueprint :one
+INSERT INTO assessment_blueprints(
+    name,
+    assessment_type,
+    shuffle_options,
+    max_item_exposures,
+    time_limit_minutes
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+) RETURNING *;
+
+-- name: InsertBlueprintSection :one
+INSERT INTO assessment_blueprint_sections(
+    blueprint_id,
+    category_id,
+    question_count
+)VALUES(
+    $1,
+    $2,
+    $3
+) RETURNING *;
+
+-- name: GetBlueprint :one
+SELECT * FROM assessment_blueprints
+WHERE id = $1 LIMIT 1;
+
+-- name: GetLatestBlueprint :one
+-- The blueprint used when a session is started without naming one
+SELECT * FROM assessment_blueprints
+WHERE assessment_type = $1
+ORDER BY id DESC
+LIMIT 1;
+
+-- name: ListBlueprints :many
+SELECT * FROM assessment_blueprints
+ORDER BY id;
+
+-- name: ListBlueprintSections :many
+SELECT * FROM assessment_blueprint_sections
+WHERE blueprint_id = $1
+ORDER BY id;
+
+-- name: CountCategoryQuestions :one
+-- Number of questions of a type that map to a category
+SELECT COUNT(*) FROM self_assessment_questions q
+WHERE q.type = $1 AND EXISTS (
+  SELECT 1 FROM self_assessment_mappings m
+  WHERE m.question_id = q.id AND m.category_id = $2
+);
+
+-- name: DrawSectionQuestions :many
+-- Randomly pick questions for one blueprint section, skipping questions
+-- already drawn for the session and those that reached their exposure limit
+SELECT q.id FROM self_assessment_questions q
+WHERE
+ q.type = @type AND
+ EXISTS (
+   SELECT 1 FROM self_assessment_mappings m
+   WHERE m.question_id = q.id AND m.category_id = @category_id
+ ) AND
+ NOT (q.id = ANY(@exclude_ids::int[])) AND
+ (sqlc.narg(max_exposures)::int IS NULL OR (
+   SELECT COUNT(*) FROM user_session_questions sq
+   WHERE sq.question_id = q.id
+ ) < sqlc.narg(max_exposures))
+ORDER BY random()
+LIMIT @question_count;
+
+-- name: ListQuestionIDsByType :many
+-- Questions asked when no blueprint applies. Trait model items are only asked
+-- through their model's blueprint, which covers every facet.
+SELECT q.id FROM self_assessment_questions q
+WHERE q.type = $1
+  AND NOT EXISTS (SELECT 1 FROM trait_items ti WHERE ti.question_id = q.id)
+ORDER BY random();
+
+-- name: GetQuestionsByIDs :many
+SELECT * FROM self_assessment_questions
+WHERE id = ANY(@ids::int[]);
+
+-- name: InsertSessionQuestion :exec
+INSERT INTO user_session_questions(
+    session_id,
+    question_id,
+    position,
+    option_order
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4
+);
+
+-- name: ListSessionQuestions :many
+-- Questions drawn for a session in display order
+SELECT
+  q.id,
+  q.question,
+  q.type,
+  q.options,
+  q.format,
+  sq.position,
+  sq.option_order
+FROM user_session_questions sq
+JOIN self_assessment_questions q ON q.id = sq.question_id
+WHERE sq.session_id = $1
+ORDER BY sq.position;
+
+-- name: MarkSessionQuestionAnswered :execrows
+-- Stamp when a candidate answered a question of their unsubmitted session
+UPDATE user_session_questions sq
+SET answered_at = CURRENT_TIMESTAMP
+FROM user_assessment_sessions s
+WHERE s.id = sq.session_id
+  AND sq.session_id = @session_id
+  AND sq.question_id = @question_id
+  AND s.user_id = @user_id
+  AND s.submitted_at IS NULL
+  AND s.completed_at IS NULL;
+
+-- name: ListSessionAnswerTimes :many
+SELECT question_id, answered_at FROM user_session_questions
+WHERE session_id = $1 AND answered_at IS NOT NULL;
+
+-- name: CreateAdaptiveSession :one
+INSERT INTO adaptive_sessions(
+    session_id,
+    target_se,
+    max_items
+)VALUES(
+    $1,
+    $2,
+    $3
+) RETURNING *;
+
+-- name: GetAdaptiveSession :one
+SELECT * FROM adaptive_sessions
+WHERE session_id = $1 LIMIT 1;
+
+-- name: GetOpenAdaptiveSession :one
+-- The latest unfinished adaptive session of a user
+SELECT s.* FROM user_assessment_sessions s
+JOIN adaptive_sessions a ON a.session_id = s.id
+WHERE s.user_id = $1 AND s.completed_at IS NULL
+ORDER BY s.id DESC
+LIMIT 1;
+
+-- name: UpdateAdaptiveEstimate :exec
+UPDATE adaptive_sessions
+SET theta = $2, standard_error = $3
+WHERE session_id = $1;
+
+-- name: ListMappingsByQuestionIDs :many
+SELECT * FROM self_assessment_mappings
+WHERE question_id = ANY(@question_ids::int[])
+ORDER BY question_id, answer_value;
+
+-- name: ListAdaptiveResponses :many
+-- Answered items of a session with their IRT parameters and whether the
+-- selected option was the keyed one
+SELECT
+  ua.question_id,
+  COALESCE(p.discrimination, 1)::float8 AS discrimination,
+  COALESCE(p.difficulty, 0)::float8 AS difficulty,
+  EXISTS (
+    SELECT 1 FROM self_assessment_mappings m
+    WHERE m.question_id = ua.question_id AND
+    m.answer_value = (ua.answer_value->>'selected')::int AND
+    m.points > 0
+  ) AS correct
+FROM user_answers ua
+LEFT JOIN self_assessment_item_parameters p ON p.question_id = ua.question_id
+WHERE ua.session_id = $1
+ORDER BY ua.id;
+
+-- name: ListAdaptiveCandidateItems :many
+-- Cognitive items not yet shown in a session, with their IRT parameters
+SELECT
+  q.id,
+  COALESCE(p.discrimination, 1)::float8 AS discrimination,
+  COALESCE(p.difficulty, 0)::float8 AS difficulty
+FROM self_assessment_questions q
+LEFT JOIN self_assessment_item_parameters p ON p.question_id = q.id
+WHERE q.type = 'cognitive' AND q.format = 'single_choice' AND NOT EXISTS (
+  SELECT 1 FROM user_session_questions sq
+  WHERE sq.session_id = $1 AND sq.question_id = q.id
+)
+ORDER BY q.id;
+
+-- name: SetSessionTheta :exec
+-- Store the final ability estimate alongside every category score of a session
+UPDATE user_assessment_scores
+SET theta = $2, theta_se = $3
+WHERE session_id = $1;
+
+-- name: UpsertItemParameters :one
+INSERT INTO self_assessment_item_parameters(
+    question_id,
+    discrimination,
+    difficulty,
+    updated_at
+)VALUES(
+    $1,
+    $2,
+    $3,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (question_id)
+DO UPDATE SET
+    discrimination = EXCLUDED.discrimination,
+    difficulty = EXCLUDED.difficulty,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListQualityAnswers :many
+-- Answers of a session in the order the questions were shown
+SELECT
+  ua.question_id,
+  q.type,
+  q.options,
+  COALESCE(ua.answer_value->>'selected', '')::text AS selected,
+  ua.answered_at
+FROM user_answers ua
+JOIN self_assessment_questions q ON q.id = ua.question_id
+LEFT JOIN user_session_questions sq ON sq.session_id = ua.session_id AND sq.question_id = ua.question_id
+WHERE ua.session_id = $1 AND q.format = 'single_choice'
+ORDER BY sq.position NULLS LAST, ua.id;
+
+-- name: ListItemPairs :many
+SELECT * FROM self_assessment_item_pairs
+ORDER BY id;
+
+-- name: CreateItemPair :one
+INSERT INTO self_assessment_item_pairs(
+    question_id,
+    paired_question_id,
+    reversed
+)VALUES(
+    $1,
+    $2,
+    $3
+) RETURNING *;
+
+-- name: UpdateSessionQualityFlags :exec
+UPDATE user_assessment_sessions
+SET quality_flags = $2
+WHERE id = $1;
+
+-- name: MarkSessionSubmitted :exec
+UPDATE user_assessment_sessions
+SET submitted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND submitted_at IS NULL;
+
+-- name: LockAssessmentSession :one
+-- Serializes finalization so a session is scored exactly once
+SELECT * FROM user_assessment_sessions
+WHERE id = $1
+FOR UPDATE;
+
+-- name: UpsertRubric :one
+INSERT INTO self_assessment_rubrics(
+    question_id,
+    criteria,
+    double_marked,
+    max_disagreement,
+    updated_at
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (question_id)
+DO UPDATE SET
+    criteria = EXCLUDED.criteria,
+    double_marked = EXCLUDED.double_marked,
+    max_disagreement = EXCLUDED.max_disagreement,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetRubric :one
+SELECT * FROM self_assessment_rubrics
+WHERE question_id = $1;
+
+-- name: CountCategoriesByIDs :one
+SELECT COUNT(*) FROM self_assessment_categories
+WHERE id = ANY(@ids::int[]);
+
+-- name: CreateGradingItems :execrows
+-- Queue every open-ended answer of a session for grading
+INSERT INTO grading_items (answer_id, session_id, question_id, required_grades)
+SELECT
+  ua.id,
+  ua.session_id,
+  ua.question_id,
+  CASE WHEN r.double_marked THEN 2 ELSE 1 END
+FROM user_answers ua
+JOIN self_assessment_questions q ON q.id = ua.question_id
+LEFT JOIN self_assessment_rubrics r ON r.question_id = q.id
+WHERE ua.session_id = $1 AND q.format = 'open_ended'
+ON CONFLICT (answer_id) DO NOTHING;
+
+-- name: CountUngradedItems :one
+SELECT COUNT(*) FROM grading_items
+WHERE session_id = $1 AND status <> 'graded';
+
+-- name: ListGradingQueue :many
+-- Items still waiting for a grade, oldest first. Candidates are not named so
+-- marking stays blind
+SELECT
+  gi.id,
+  gi.session_id,
+  gi.question_id,
+  q.question,
+  s.assessment_type,
+  gi.status,
+  gi.required_grades,
+  gi.created_at,
+  COUNT(a.id) FILTER (WHERE NOT a.resolver) AS markers_assigned,
+  COUNT(a.id) FILTER (WHERE a.submitted_at IS NOT NULL) AS grades_submitted
+FROM grading_items gi
+JOIN self_assessment_questions q ON q.id = gi.question_id
+JOIN user_assessment_sessions s ON s.id = gi.session_id
+LEFT JOIN grading_assignments a ON a.item_id = gi.id
+WHERE gi.status <> 'graded'
+  AND (sqlc.narg(status)::grading_status IS NULL OR gi.status = sqlc.narg(status))
+  AND (sqlc.narg(grader_id)::int IS NULL OR EXISTS (
+    SELECT 1 FROM grading_assignments mine
+    WHERE mine.item_id = gi.id AND mine.grader_id = sqlc.narg(grader_id) AND mine.submitted_at IS NULL
+  ))
+GROUP BY gi.id, q.question, s.assessment_type
+ORDER BY gi.created_at, gi.id;
+
+-- name: GetGradingItem :one
+SELECT
+  gi.id,
+  gi.answer_id,
+  gi.session_id,
+  gi.question_id,
+  gi.required_grades,
+  gi.status,
+  gi.final_scores,
+  gi.created_at,
+  gi.graded_at,
+  q.question,
+  s.assessment_type,
+  COALESCE(ua.answer_value->>'text', '')::text AS answer_text
+FROM grading_items gi
+JOIN self_assessment_questions q ON q.id = gi.question_id
+JOIN user_assessment_sessions s ON s.id = gi.session_id
+JOIN user_answers ua ON ua.id = gi.answer_id
+WHERE gi.id = $1;
+
+-- name: LockGradingItem :one
+SELECT * FROM grading_items
+WHERE id = $1
+FOR UPDATE;
+
+-- name: ListGradingAssignments :many
+SELECT * FROM grading_assignments
+WHERE item_id = $1
+ORDER BY resolver, assigned_at, id;
+
+-- name: CreateGradingAssignment :one
+INSERT INTO grading_assignments(
+    item_id,
+    grader_id,
+    resolver
+)VALUES(
+    $1,
+    $2,
+    $3
+)RETURNING *;
+
+-- name: SubmitGrade :one
+UPDATE grading_assignments
+SET criterion_scores = $3, comment = $4, submitted_at = CURRENT_TIMESTAMP
+WHERE item_id = $1 AND grader_id = $2 AND submitted_at IS NULL
+RETURNING *;
+
+-- name: SetGradingItemStatus :exec
+UPDATE grading_items
+SET status = $2
+WHERE id = $1;
+
+-- name: CompleteGradingItem :exec
+UPDATE grading_items
+SET status = 'graded', final_scores = $2, graded_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: SetAnswerScores :exec
+-- Store the final category points on a graded answer so score calculation picks them up
+UPDATE user_answers
+SET answer_value = jsonb_set(answer_value, '{scores}', @scores::jsonb)
+WHERE id = @id;
+
+-- name: ListRescorableAnswers :many
+-- Typed answers in a session, which carry points worked out from the
+-- question's mappings. Open-ended answers keep the points they were graded.
+SELECT id, question_id, answer_value FROM user_answers
+WHERE session_id = $1
+  AND answer_value->>'format' IS NOT NULL
+  AND answer_value->>'format' <> 'open_ended'
+ORDER BY id;
+
+-- name: DeleteSessionScores :exec
+DELETE FROM user_assessment_scores
+WHERE session_id = $1;
+
+-- name: GetStaffUser :one
+SELECT u.id, u.name FROM users u
+JOIN roles r ON r.id = u.role_id
+WHERE u.id = $1 AND r.name = 'admin';
+
+-- name: ListTraitModels :many
+SELECT * FROM trait_models
+ORDER BY id;
+
+-- name: ListTraitFacets :many
+-- Facets of every trait model, in model order, with their trait and items
+SELECT
+  f.id,
+  f.model_id,
+  f.code,
+  f.position,
+  f.trait_category_id,
+  tc.name AS trait_name,
+  f.facet_category_id,
+  fc.name AS facet_name,
+  (SELECT COUNT(*) FROM trait_items ti WHERE ti.facet_id = f.id) AS item_count
+FROM trait_facets f
+JOIN self_assessment_categories tc ON tc.id = f.trait_category_id
+JOIN self_assessment_categories fc ON fc.id = f.facet_category_id
+ORDER BY f.model_id, f.position;
+
+-- name: GetTraitFacet :one
+SELECT * FROM trait_facets
+WHERE id = $1;
+
+-- name: UpsertTraitItem :one
+INSERT INTO trait_items(
+    question_id,
+    facet_id,
+    reverse_keyed
+)VALUES(
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (question_id) DO UPDATE
+SET facet_id = EXCLUDED.facet_id,
+    reverse_keyed = EXCLUDED.reverse_keyed
+RETURNING *;
+
+-- name: CreateValidityScale :one
+INSERT INTO validity_scales(
+    name,
+    kind,
+    assessment_type,
+    threshold,
+    description
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+) RETURNING *;
+
+-- name: GetValidityScale :one
+SELECT * FROM validity_scales
+WHERE id = $1;
+
+-- name: ListValidityScales :many
+-- Every validity scale with the number of items on it
+SELECT
+  s.id,
+  s.name,
+  s.kind,
+  s.assessment_type,
+  s.threshold,
+  s.description,
+  (SELECT COUNT(*) FROM validity_items vi WHERE vi.scale_id = s.id) AS item_count
+FROM validity_scales s
+ORDER BY s.assessment_type, s.name;
+
+-- name: UpsertValidityItem :one
+INSERT INTO validity_items(
+    question_id,
+    scale_id,
+    keyed_answers
+)VALUES(
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (question_id) DO UPDATE
+SET scale_id = EXCLUDED.scale_id,
+    keyed_answers = EXCLUDED.keyed_answers
+RETURNING *;
+
+-- name: ListValidityQuestionIDs :many
+-- Validity items are asked in every session of their type
+SELECT vi.question_id FROM validity_items vi
+JOIN self_assessment_questions q ON q.id = vi.question_id
+JOIN validity_scales s ON s.id = vi.scale_id
+WHERE q.type = $1 AND s.assessment_type = q.type::text
+ORDER BY vi.question_id;
+
+-- name: ScoreValidityScales :exec
+-- Count the items of every validity scale answered in a session with a keyed
+-- answer, and flag the scales that reach their threshold
+INSERT INTO session_validity_scores (session_id, scale_id, score, item_count, flagged)
+SELECT
+  @session_id::int,
+  s.id,
+  COUNT(*) FILTER (WHERE ua.answer_value->>'selected' = ANY(vi.keyed_answers)),
+  COUNT(*),
+  COUNT(*) FILTER (WHERE ua.answer_value->>'selected' = ANY(vi.keyed_answers)) >= s.threshold
+FROM validity_scales s
+JOIN validity_items vi ON vi.scale_id = s.id
+JOIN user_answers ua ON ua.question_id = vi.question_id
+WHERE ua.session_id = @session_id::int
+GROUP BY s.id, s.threshold
+ON CONFLICT (session_id, scale_id) DO UPDATE
+SET score = EXCLUDED.score,
+    item_count = EXCLUDED.item_count,
+    flagged = EXCLUDED.flagged;
+
+-- name: ListSessionValidityScores :many
+SELECT
+  svs.scale_id,
+  s.name,
+  s.kind,
+  svs.score,
+  svs.item_count,
+  s.threshold,
+  svs.flagged
+FROM session_validity_scores svs
+JOIN validity_scales s ON s.id = svs.scale_id
+WHERE svs.session_id = $1
+ORDER BY s.name;
+
+-- name: CountQuestionMappings :one
+SELECT COUNT(*) FROM self_assessment_mappings
+WHERE question_id = $1;
+
+-- name: ListTranslationLocales :many
+-- Locales with at least one translation
+SELECT locale FROM question_translations
+UNION
+SELECT locale FROM category_translations
+ORDER BY locale;
+
+-- name: ListQuestionTranslations :many
+SELECT question_id, locale, question, options, updated_at FROM question_translations
+WHERE locale = @locale AND question_id = ANY(@question_ids::int[]);
+
+-- name: ListTranslationsForQuestion :many
+SELECT question_id, locale, question, options, updated_at FROM question_translations
+WHERE question_id = $1
+ORDER BY locale;
+
+-- name: UpsertQuestionTranslation :one
+INSERT INTO question_translations (question_id, locale, question, options)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (question_id, locale) DO UPDATE
+SET question = EXCLUDED.question,
+    options = EXCLUDED.options,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: ListCategoryTranslations :many
+SELECT category_id, locale, description, updated_at FROM category_translations
+WHERE locale = $1;
+
+-- name: UpsertCategoryTranslation :one
+INSERT INTO category_translations (category_id, locale, description)
+VALUES ($1, $2, $3)
+ON CONFLICT (category_id, locale) DO UPDATE
+SET description = EXCLUDED.description,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetUserLocale :one
+SELECT locale FROM user_preferences
+WHERE user_id = $1;
+
+-- name: SetUserLocale :exec
+INSERT INTO user_preferences (user_id, locale)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET locale = EXCLUDED.locale;
+
+-- name: LinkCandidateAccommodation :exec
+-- Run a new session under the candidate's latest candidate-wide accommodation
+INSERT INTO session_accommodations (session_id, accommodation_id)
+SELECT @session_id::int, a.id FROM candidate_accommodations a
+WHERE a.user_id = @user_id AND a.session_id IS NULL AND a.revoked_at IS NULL
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT 1
+ON CONFLICT (session_id) DO NOTHING;
+
+-- name: GetSessionAccommodation :one
+-- The accommodation a session runs under, unless it has been revoked
+SELECT a.id, a.user_id, a.session_id, a.time_multiplier, a.break_minutes, a.alternative_formats, a.reason, a.approved_by, a.created_at, a.revoked_at, a.revoked_by
+FROM session_accommodations sa
+JOIN candidate_accommodations a ON a.id = sa.accommodation_id
+WHERE sa.session_id = $1 AND a.revoked_at IS NULL;
+
+-- name: SessionHasAccommodation :one
+SELECT EXISTS (
+  SELECT 1 FROM session_accommodations WHERE session_id = $1
+);
+
+-- name: EnqueueOutboxEvent :exec
+-- Record an event in the outbox; run it in the transaction making the change
+INSERT INTO outbox_events (event_type, dedup_key, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (dedup_key) DO NOTHING;
+
+-- name: EnqueueRescoreJobs :execrows
+-- Queue a score recalculation for every completed session that answered a
+-- question; run it in the transaction that changes how the question scores
+INSERT INTO background_jobs (kind, payload)
+SELECT @kind::text, jsonb_build_object('session_id', s.id)
+FROM user_assessment_sessions s
+WHERE s.completed_at IS NOT NULL
+  AND EXISTS (
+    SELECT 1 FROM user_answers ua
+    WHERE ua.session_id = s.id AND ua.question_id = @question_id::int
+  );
@@ -26,7 +26,21 @@ WITH answers AS (
     uas.id = ua.session_id
   WHERE 
     ua.session_id = $1 AND
-    uas.assessment_type = 'behavioral'
+    uas.assessment_type = 'behavioral' AND
+    ua.answer_value->>'format' IS NULL
+),
+typed_answers AS (
+  -- Typed formats are scored when answered and carry their category points
+  SELECT
+    (s->>'category_id')::int as category_id,
+    (s->>'points')::int as points
+  FROM user_answers ua
+  JOIN user_assessment_sessions uas ON uas.id = ua.session_id
+  CROSS JOIN LATERAL jsonb_array_elements(ua.answer_value->'scores') s
+  WHERE
+    ua.session_id = $1 AND
+    uas.assessment_type = 'behavioral' AND
+    ua.answer_value->>'format' IS NOT NULL
 )
 INSERT INTO user_assessment_scores (
   user_id,
@@ -39,7 +53,11 @@ SELECT
   $1,
   a.category_id,
   SUM(a.points)
-FROM answers a
+FROM (
+  SELECT category_id, points FROM answers
+  UNION ALL
+  SELECT category_id, points FROM typed_answers
+) a
 GROUP BY a.category_id
 `
 
@@ -68,7 +86,21 @@ WITH answers AS (
     uas.id = ua.session_id
   WHERE 
     ua.session_id = $1 AND
-    uas.assessment_type = 'cognitive'
+    uas.assessment_type = 'cognitive' AND
+    ua.answer_value->>'format' IS NULL
+),
+typed_answers AS (
+  -- Typed formats are scored when answered and carry their category points
+  SELECT
+    (s->>'category_id')::int as category_id,
+    (s->>'points')::int as points
+  FROM user_answers ua
+  JOIN user_assessment_sessions uas ON uas.id = ua.session_id
+  CROSS JOIN LATERAL jsonb_array_elements(ua.answer_value->'scores') s
+  WHERE
+    ua.session_id = $1 AND
+    uas.assessment_type = 'cognitive' AND
+    ua.answer_value->>'format' IS NOT NULL
 )
 INSERT INTO user_assessment_scores (
   user_id,
@@ -81,7 +113,11 @@ SELECT
   $1,
   a.category_id,
   SUM(a.points)
-FROM answers a
+FROM (
+  SELECT category_id, points FROM answers
+  UNION ALL
+  SELECT category_id, points FROM typed_answers
+) a
 GROUP BY a.category_id
 `
 
@@ -96,7 +132,8 @@ WITH answers AS (
   SELECT 
     ua.session_id, 
     ua.question_id,
-    (ua.answer_value->>'points')::int as points,
+    -- Mapping points carry the keying of reverse-keyed items
+    COALESCE(sam.points, (ua.answer_value->>'points')::int) as points,
     sam.category_id
   FROM user_answers ua
   JOIN self_assessment_mappings sam ON 
@@ -106,7 +143,21 @@ WITH answers AS (
     uas.id = ua.session_id
   WHERE 
     ua.session_id = $1 AND
-    uas.assessment_type = 'personality'
+    uas.assessment_type = 'personality' AND
+    ua.answer_value->>'format' IS NULL
+),
+typed_answers AS (
+  -- Typed formats are scored when answered and carry their category points
+  SELECT
+    (s->>'category_id')::int as category_id,
+    (s->>'points')::int as points
+  FROM user_answers ua
+  JOIN user_assessment_sessions uas ON uas.id = ua.session_id
+  CROSS JOIN LATERAL jsonb_array_elements(ua.answer_value->'scores') s
+  WHERE
+    ua.session_id = $1 AND
+    uas.assessment_type = 'personality' AND
+    ua.answer_value->>'format' IS NOT NULL
 )
 INSERT INTO user_assessment_scores (
   user_id,
@@ -119,7 +170,11 @@ SELECT
   $1,
   a.category_id,
   SUM(a.points)
-FROM answers a
+FROM (
+  SELECT category_id, points FROM answers
+  UNION ALL
+  SELECT category_id, points FROM typed_answers
+) a
 GROUP BY a.category_id
 `
 
@@ -141,22 +196,128 @@ func (q *Queries) CompleteAssessmentSession(ctx context.Context, id int32) error
 	return err
 }
 
+const completeGradingItem = `-- name: CompleteGradingItem :exec
+UPDATE grading_items
+SET status = 'graded', final_scores = $2, graded_at = CURRENT_TIMESTAMP
+WHERE id = $1
+`
+
+type CompleteGradingItemParams struct {
+	ID          int32
+	FinalScores []byte
+}
+
+func (q *Queries) CompleteGradingItem(ctx context.Context, arg CompleteGradingItemParams) error {
+	_, err := q.db.Exec(ctx, completeGradingItem, arg.ID, arg.FinalScores)
+	return err
+}
+
+const countCategoriesByIDs = `-- name: CountCategoriesByIDs :one
+SELECT COUNT(*) FROM self_assessment_categories
+WHERE id = ANY($1::int[])
+`
+
+func (q *Queries) CountCategoriesByIDs(ctx context.Context, ids []int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countCategoriesByIDs, ids)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countCategoryQuestions = `-- name: CountCategoryQuestions :one
+SELECT COUNT(*) FROM self_assessment_questions q
+WHERE q.type = $1 AND EXISTS (
+  SELECT 1 FROM self_assessment_mappings m
+  WHERE m.question_id = q.id AND m.category_id = $2
+)
+`
+
+type CountCategoryQuestionsParams struct {
+	Type       QuestionType
+	CategoryID int32
+}
+
+// Number of questions of a type that map to a category
+func (q *Queries) CountCategoryQuestions(ctx context.Context, arg CountCategoryQuestionsParams) (int64, error) {
+	row := q.db.QueryRow(ctx, countCategoryQuestions, arg.Type, arg.CategoryID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countQuestionMappings = `-- name: CountQuestionMappings :one
+SELECT COUNT(*) FROM self_assessment_mappings
+WHERE question_id = $1
+`
+
+func (q *Queries) CountQuestionMappings(ctx context.Context, questionID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countQuestionMappings, questionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const countUngradedItems = `-- name: CountUngradedItems :one
+SELECT COUNT(*) FROM grading_items
+WHERE session_id = $1 AND status <> 'graded'
+`
+
+func (q *Queries) CountUngradedItems(ctx context.Context, sessionID int32) (int64, error) {
+	row := q.db.QueryRow(ctx, countUngradedItems, sessionID)
+	var count int64
+	err := row.Scan(&count)
+	return count, err
+}
+
+const createAdaptiveSession = `-- name: CreateAdaptiveSession :one
+INSERT INTO adaptive_sessions(
+    session_id,
+    target_se,
+    max_items
+)VALUES(
+    $1,
+    $2,
+    $3
+) RETURNING session_id, target_se, max_items, theta, standard_error
+`
+
+type CreateAdaptiveSessionParams struct {
+	SessionID int32
+	TargetSe  float64
+	MaxItems  int32
+}
+
+func (q *Queries) CreateAdaptiveSession(ctx context.Context, arg CreateAdaptiveSessionParams) (AdaptiveSession, error) {
+	row := q.db.QueryRow(ctx, createAdaptiveSession, arg.SessionID, arg.TargetSe, arg.MaxItems)
+	var i AdaptiveSession
+	err := row.Scan(
+		&i.SessionID,
+		&i.TargetSe,
+		&i.MaxItems,
+		&i.Theta,
+		&i.StandardError,
+	)
+	return i, err
+}
+
 const createAssessmentSession = `-- name: CreateAssessmentSession :one
 INSERT INTO user_assessment_sessions(
     user_id,
-    assessment_type
+    assessment_type,
+    blueprint_id
 )VALUES(
-    $1, $2
-)RETURNING id, user_id, assessment_type, started_at, completed_at
+    $1, $2, $3
+)RETURNING id, user_id, assessment_type, started_at, completed_at, blueprint_id, quality_flags, submitted_at
 `
 
 type CreateAssessmentSessionParams struct {
 	UserID         int32
 	AssessmentType string
+	BlueprintID    pgtype.Int4
 }
 
 func (q *Queries) CreateAssessmentSession(ctx context.Context, arg CreateAssessmentSessionParams) (UserAssessmentSession, error) {
-	row := q.db.QueryRow(ctx, createAssessmentSession, arg.UserID, arg.AssessmentType)
+	row := q.db.QueryRow(ctx, createAssessmentSession, arg.UserID, arg.AssessmentType, arg.BlueprintID)
 	var i UserAssessmentSession
 	err := row.Scan(
 		&i.ID,
@@ -164,67 +325,256 @@ func (q *Queries) CreateAssessmentSession(ctx context.Context, arg CreateAssessm
 		&i.AssessmentType,
 		&i.StartedAt,
 		&i.CompletedAt,
+		&i.BlueprintID,
+		&i.QualityFlags,
+		&i.SubmittedAt,
+	)
+	return i, err
+}
+
+const createBlueprint = `-- name: CreateBlueprint :one
+INSERT INTO assessment_blueprints(
+    name,
+    assessment_type,
+    shuffle_options,
+    max_item_exposures,
+    time_limit_minutes
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+) RETURNING id, name, assessment_type, shuffle_options, max_item_exposures, created_at, time_limit_minutes
+`
+
+type CreateBlueprintParams struct {
+	Name             string
+	AssessmentType   string
+	ShuffleOptions   bool
+	MaxItemExposures pgtype.Int4
+	TimeLimitMinutes pgtype.Int4
+}
+
+func (q *Queries) CreateBlueprint(ctx context.Context, arg CreateBlueprintParams) (AssessmentBlueprint, error) {
+	row := q.db.QueryRow(ctx, createBlueprint,
+		arg.Name,
+		arg.AssessmentType,
+		arg.ShuffleOptions,
+		arg.MaxItemExposures,
+		arg.TimeLimitMinutes,
+	)
+	var i AssessmentBlueprint
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AssessmentType,
+		&i.ShuffleOptions,
+		&i.MaxItemExposures,
+		&i.CreatedAt,
+		&i.TimeLimitMinutes,
+	)
+	return i, err
+}
+
+const createGradingAssignment = `-- name: CreateGradingAssignment :one
+INSERT INTO grading_assignments(
+    item_id,
+    grader_id,
+    resolver
+)VALUES(
+    $1,
+    $2,
+    $3
+)RETURNING id, item_id, grader_id, resolver, criterion_scores, comment, assigned_at, submitted_at
+`
+
+type CreateGradingAssignmentParams struct {
+	ItemID   int32
+	GraderID int32
+	Resolver bool
+}
+
+func (q *Queries) CreateGradingAssignment(ctx context.Context, arg CreateGradingAssignmentParams) (GradingAssignment, error) {
+	row := q.db.QueryRow(ctx, createGradingAssignment, arg.ItemID, arg.GraderID, arg.Resolver)
+	var i GradingAssignment
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.GraderID,
+		&i.Resolver,
+		&i.CriterionScores,
+		&i.Comment,
+		&i.AssignedAt,
+		&i.SubmittedAt,
 	)
 	return i, err
 }
 
-const getAssessmentQuestionBehavioral = `-- name: GetAssessmentQuestionBehavioral :many
-SELECT id, question, type, options, correct_answer, created_at from self_assessment_questions
-where type = 'behavioral'
+const createGradingItems = `-- name: CreateGradingItems :execrows
+INSERT INTO grading_items (answer_id, session_id, question_id, required_grades)
+SELECT
+  ua.id,
+  ua.session_id,
+  ua.question_id,
+  CASE WHEN r.double_marked THEN 2 ELSE 1 END
+FROM user_answers ua
+JOIN self_assessment_questions q ON q.id = ua.question_id
+LEFT JOIN self_assessment_rubrics r ON r.question_id = q.id
+WHERE ua.session_id = $1 AND q.format = 'open_ended'
+ON CONFLICT (answer_id) DO NOTHING
 `
 
-func (q *Queries) GetAssessmentQuestionBehavioral(ctx context.Context) ([]SelfAssessmentQuestion, error) {
-	rows, err := q.db.Query(ctx, getAssessmentQuestionBehavioral)
+// Queue every open-ended answer of a session for grading
+func (q *Queries) CreateGradingItems(ctx context.Context, sessionID pgtype.Int4) (int64, error) {
+	result, err := q.db.Exec(ctx, createGradingItems, sessionID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []SelfAssessmentQuestion
-	for rows.Next() {
-		var i SelfAssessmentQuestion
-		if err := rows.Scan(
-			&i.ID,
-			&i.Question,
-			&i.Type,
-			&i.Options,
-			&i.CorrectAnswer,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
+}
+
+const createItemPair = `-- name: CreateItemPair :one
+INSERT INTO self_assessment_item_pairs(
+    question_id,
+    paired_question_id,
+    reversed
+)VALUES(
+    $1,
+    $2,
+    $3
+) RETURNING id, question_id, paired_question_id, reversed
+`
+
+type CreateItemPairParams struct {
+	QuestionID       int32
+	PairedQuestionID int32
+	Reversed         bool
+}
+
+func (q *Queries) CreateItemPair(ctx context.Context, arg CreateItemPairParams) (SelfAssessmentItemPair, error) {
+	row := q.db.QueryRow(ctx, createItemPair, arg.QuestionID, arg.PairedQuestionID, arg.Reversed)
+	var i SelfAssessmentItemPair
+	err := row.Scan(
+		&i.ID,
+		&i.QuestionID,
+		&i.PairedQuestionID,
+		&i.Reversed,
+	)
+	return i, err
+}
+
+const createValidityScale = `-- name: CreateValidityScale :one
+INSERT INTO validity_scales(
+    name,
+    kind,
+    assessment_type,
+    threshold,
+    description
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    $5
+) RETURNING id, name, kind, assessment_type, threshold, description, created_at
+`
+
+type CreateValidityScaleParams struct {
+	Name           string
+	Kind           ValidityScaleKind
+	AssessmentType string
+	Threshold      int32
+	Description    pgtype.Text
+}
+
+func (q *Queries) CreateValidityScale(ctx context.Context, arg CreateValidityScaleParams) (ValidityScale, error) {
+	row := q.db.QueryRow(ctx, createValidityScale,
+		arg.Name,
+		arg.Kind,
+		arg.AssessmentType,
+		arg.Threshold,
+		arg.Description,
+	)
+	var i ValidityScale
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.AssessmentType,
+		&i.Threshold,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const deleteQuestionMappings = `-- name: DeleteQuestionMappings :exec
+DELETE FROM self_assessment_mappings
+WHERE question_id = $1
+`
+
+func (q *Queries) DeleteQuestionMappings(ctx context.Context, questionID int32) error {
+	_, err := q.db.Exec(ctx, deleteQuestionMappings, questionID)
+	return err
+}
+
+const deleteSessionScores = `-- name: DeleteSessionScores :exec
+DELETE FROM user_assessment_scores
+WHERE session_id = $1
+`
+
+func (q *Queries) DeleteSessionScores(ctx context.Context, sessionID pgtype.Int4) error {
+	_, err := q.db.Exec(ctx, deleteSessionScores, sessionID)
+	return err
 }
 
-const getAssessmentQuestionCognitive = `-- name: GetAssessmentQuestionCognitive :many
-SELECT id, question, type, options, correct_answer, created_at from self_assessment_questions
-where type = 'cognitive'
+const drawSectionQuestions = `-- name: DrawSectionQuestions :many
+SELECT q.id FROM self_assessment_questions q
+WHERE
+ q.type = $1 AND
+ EXISTS (
+   SELECT 1 FROM self_assessment_mappings m
+   WHERE m.question_id = q.id AND m.category_id = $2
+ ) AND
+ NOT (q.id = ANY($3::int[])) AND
+ ($4::int IS NULL OR (
+   SELECT COUNT(*) FROM user_session_questions sq
+   WHERE sq.question_id = q.id
+ ) < $4)
+ORDER BY random()
+LIMIT $5
 `
 
-func (q *Queries) GetAssessmentQuestionCognitive(ctx context.Context) ([]SelfAssessmentQuestion, error) {
-	rows, err := q.db.Query(ctx, getAssessmentQuestionCognitive)
+type DrawSectionQuestionsParams struct {
+	Type          QuestionType
+	CategoryID    int32
+	ExcludeIds    []int32
+	MaxExposures  pgtype.Int4
+	QuestionCount int32
+}
+
+// Randomly pick questions for one blueprint section, skipping questions
+// already drawn for the session and those that reached their exposure limit
+func (q *Queries) DrawSectionQuestions(ctx context.Context, arg DrawSectionQuestionsParams) ([]int32, error) {
+	rows, err := q.db.Query(ctx, drawSectionQuestions,
+		arg.Type,
+		arg.CategoryID,
+		arg.ExcludeIds,
+		arg.MaxExposures,
+		arg.QuestionCount,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []SelfAssessmentQuestion
+	var items []int32
 	for rows.Next() {
-		var i SelfAssessmentQuestion
-		if err := rows.Scan(
-			&i.ID,
-			&i.Question,
-			&i.Type,
-			&i.Options,
-			&i.CorrectAnswer,
-			&i.CreatedAt,
-		); err != nil {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
 			return nil, err
 		}
-		items = append(items, i)
+		items = append(items, id)
 	}
 	if err := rows.Err(); err != nil {
 		return nil, err
@@ -232,36 +582,107 @@ func (q *Queries) GetAssessmentQuestionCognitive(ctx context.Context) ([]SelfAss
 	return items, nil
 }
 
-const getAssessmentQuestionPersonality = `-- name: GetAssessmentQuestionPersonality :many
-SELECT id, question, type, options, correct_answer, created_at from self_assessment_questions
-where type = 'personality'
+const enqueueOutboxEvent = `-- name: EnqueueOutboxEvent :exec
+INSERT INTO outbox_events (event_type, dedup_key, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (dedup_key) DO NOTHING
+`
+
+type EnqueueOutboxEventParams struct {
+	EventType string
+	DedupKey  string
+	Payload   []byte
+}
+
+// Record an event in the outbox; run it in the transaction making the change
+func (q *Queries) EnqueueOutboxEvent(ctx context.Context, arg EnqueueOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, enqueueOutboxEvent, arg.EventType, arg.DedupKey, arg.Payload)
+	return err
+}
+
+const enqueueRescoreJobs = `-- name: EnqueueRescoreJobs :execrows
+INSERT INTO background_jobs (kind, payload)
+SELECT $1::text, jsonb_build_object('session_id', s.id)
+FROM user_assessment_sessions s
+WHERE s.completed_at IS NOT NULL
+  AND EXISTS (
+    SELECT 1 FROM user_answers ua
+    WHERE ua.session_id = s.id AND ua.question_id = $2::int
+  )
 `
 
-func (q *Queries) GetAssessmentQuestionPersonality(ctx context.Context) ([]SelfAssessmentQuestion, error) {
-	rows, err := q.db.Query(ctx, getAssessmentQuestionPersonality)
+type EnqueueRescoreJobsParams struct {
+	Kind       string
+	QuestionID int32
+}
+
+// Queue a score recalculation for every completed session that answered a
+// question; run it in the transaction that changes how the question scores
+func (q *Queries) EnqueueRescoreJobs(ctx context.Context, arg EnqueueRescoreJobsParams) (int64, error) {
+	result, err := q.db.Exec(ctx, enqueueRescoreJobs, arg.Kind, arg.QuestionID)
 	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []SelfAssessmentQuestion
-	for rows.Next() {
-		var i SelfAssessmentQuestion
-		if err := rows.Scan(
-			&i.ID,
-			&i.Question,
-			&i.Type,
-			&i.Options,
-			&i.CorrectAnswer,
-			&i.CreatedAt,
-		); err != nil {
-			return nil, err
-		}
-		items = append(items, i)
-	}
-	if err := rows.Err(); err != nil {
-		return nil, err
+		return 0, err
 	}
-	return items, nil
+	return result.RowsAffected(), nil
+}
+
+const getAdaptiveSession = `-- name: GetAdaptiveSession :one
+SELECT session_id, target_se, max_items, theta, standard_error FROM adaptive_sessions
+WHERE session_id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAdaptiveSession(ctx context.Context, sessionID int32) (AdaptiveSession, error) {
+	row := q.db.QueryRow(ctx, getAdaptiveSession, sessionID)
+	var i AdaptiveSession
+	err := row.Scan(
+		&i.SessionID,
+		&i.TargetSe,
+		&i.MaxItems,
+		&i.Theta,
+		&i.StandardError,
+	)
+	return i, err
+}
+
+const getAssessmentSession = `-- name: GetAssessmentSession :one
+SELECT id, user_id, assessment_type, started_at, completed_at, blueprint_id, quality_flags, submitted_at FROM user_assessment_sessions
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetAssessmentSession(ctx context.Context, id int32) (UserAssessmentSession, error) {
+	row := q.db.QueryRow(ctx, getAssessmentSession, id)
+	var i UserAssessmentSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AssessmentType,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.BlueprintID,
+		&i.QualityFlags,
+		&i.SubmittedAt,
+	)
+	return i, err
+}
+
+const getBlueprint = `-- name: GetBlueprint :one
+SELECT id, name, assessment_type, shuffle_options, max_item_exposures, created_at, time_limit_minutes FROM assessment_blueprints
+WHERE id = $1 LIMIT 1
+`
+
+func (q *Queries) GetBlueprint(ctx context.Context, id int32) (AssessmentBlueprint, error) {
+	row := q.db.QueryRow(ctx, getBlueprint, id)
+	var i AssessmentBlueprint
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AssessmentType,
+		&i.ShuffleOptions,
+		&i.MaxItemExposures,
+		&i.CreatedAt,
+		&i.TimeLimitMinutes,
+	)
+	return i, err
 }
 
 const getCandidateAssessmentResults = `-- name: GetCandidateAssessmentResults :many
@@ -331,42 +752,258 @@ func (q *Queries) GetCandidateAssessmentResults(ctx context.Context, arg GetCand
 	return items, nil
 }
 
-const getSessionScores = `-- name: GetSessionScores :many
-SELECT 
-  uas.id, uas.user_id, uas.session_id, uas.category_id, uas.score,
-  sac.name as category_name,
-  sac.description as category_description
-FROM user_assessment_scores uas
-JOIN self_assessment_categories sac ON uas.category_id = sac.id
-WHERE uas.session_id = $1
+const getGradingItem = `-- name: GetGradingItem :one
+SELECT
+  gi.id,
+  gi.answer_id,
+  gi.session_id,
+  gi.question_id,
+  gi.required_grades,
+  gi.status,
+  gi.final_scores,
+  gi.created_at,
+  gi.graded_at,
+  q.question,
+  s.assessment_type,
+  COALESCE(ua.answer_value->>'text', '')::text AS answer_text
+FROM grading_items gi
+JOIN self_assessment_questions q ON q.id = gi.question_id
+JOIN user_assessment_sessions s ON s.id = gi.session_id
+JOIN user_answers ua ON ua.id = gi.answer_id
+WHERE gi.id = $1
 `
 
-type GetSessionScoresRow struct {
-	ID                  int32
-	UserID              pgtype.Int4
-	SessionID           pgtype.Int4
-	CategoryID          pgtype.Int4
-	Score               pgtype.Int4
-	CategoryName        pgtype.Text
-	CategoryDescription pgtype.Text
+type GetGradingItemRow struct {
+	ID             int32
+	AnswerID       int32
+	SessionID      int32
+	QuestionID     int32
+	RequiredGrades int32
+	Status         GradingStatus
+	FinalScores    []byte
+	CreatedAt      pgtype.Timestamp
+	GradedAt       pgtype.Timestamp
+	Question       string
+	AssessmentType string
+	AnswerText     string
 }
 
-// Get all scores for a specific session with category details
-func (q *Queries) GetSessionScores(ctx context.Context, sessionID pgtype.Int4) ([]GetSessionScoresRow, error) {
-	rows, err := q.db.Query(ctx, getSessionScores, sessionID)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	var items []GetSessionScoresRow
-	for rows.Next() {
-		var i GetSessionScoresRow
-		if err := rows.Scan(
-			&i.ID,
-			&i.UserID,
-			&i.SessionID,
-			&i.CategoryID,
+func (q *Queries) GetGradingItem(ctx context.Context, id int32) (GetGradingItemRow, error) {
+	row := q.db.QueryRow(ctx, getGradingItem, id)
+	var i GetGradingItemRow
+	err := row.Scan(
+		&i.ID,
+		&i.AnswerID,
+		&i.SessionID,
+		&i.QuestionID,
+		&i.RequiredGrades,
+		&i.Status,
+		&i.FinalScores,
+		&i.CreatedAt,
+		&i.GradedAt,
+		&i.Question,
+		&i.AssessmentType,
+		&i.AnswerText,
+	)
+	return i, err
+}
+
+const getLatestBlueprint = `-- name: GetLatestBlueprint :one
+SELECT id, name, assessment_type, shuffle_options, max_item_exposures, created_at, time_limit_minutes FROM assessment_blueprints
+WHERE assessment_type = $1
+ORDER BY id DESC
+LIMIT 1
+`
+
+// The blueprint used when a session is started without naming one
+func (q *Queries) GetLatestBlueprint(ctx context.Context, assessmentType string) (AssessmentBlueprint, error) {
+	row := q.db.QueryRow(ctx, getLatestBlueprint, assessmentType)
+	var i AssessmentBlueprint
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.AssessmentType,
+		&i.ShuffleOptions,
+		&i.MaxItemExposures,
+		&i.CreatedAt,
+		&i.TimeLimitMinutes,
+	)
+	return i, err
+}
+
+const getOpenAdaptiveSession = `-- name: GetOpenAdaptiveSession :one
+SELECT s.id, s.user_id, s.assessment_type, s.started_at, s.completed_at, s.blueprint_id, s.quality_flags, s.submitted_at FROM user_assessment_sessions s
+JOIN adaptive_sessions a ON a.session_id = s.id
+WHERE s.user_id = $1 AND s.completed_at IS NULL
+ORDER BY s.id DESC
+LIMIT 1
+`
+
+// The latest unfinished adaptive session of a user
+func (q *Queries) GetOpenAdaptiveSession(ctx context.Context, userID int32) (UserAssessmentSession, error) {
+	row := q.db.QueryRow(ctx, getOpenAdaptiveSession, userID)
+	var i UserAssessmentSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AssessmentType,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.BlueprintID,
+		&i.QualityFlags,
+		&i.SubmittedAt,
+	)
+	return i, err
+}
+
+const getOpenAssessmentSession = `-- name: GetOpenAssessmentSession :one
+SELECT id, user_id, assessment_type, started_at, completed_at, blueprint_id, quality_flags, submitted_at FROM user_assessment_sessions s
+WHERE s.user_id = $1 AND s.assessment_type = $2 AND s.completed_at IS NULL AND s.submitted_at IS NULL AND
+ NOT EXISTS (SELECT 1 FROM adaptive_sessions a WHERE a.session_id = s.id)
+ORDER BY s.id DESC
+LIMIT 1
+`
+
+type GetOpenAssessmentSessionParams struct {
+	UserID         int32
+	AssessmentType string
+}
+
+// The latest unfinished fixed-form session a user started for an assessment type
+func (q *Queries) GetOpenAssessmentSession(ctx context.Context, arg GetOpenAssessmentSessionParams) (UserAssessmentSession, error) {
+	row := q.db.QueryRow(ctx, getOpenAssessmentSession, arg.UserID, arg.AssessmentType)
+	var i UserAssessmentSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AssessmentType,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.BlueprintID,
+		&i.QualityFlags,
+		&i.SubmittedAt,
+	)
+	return i, err
+}
+
+const getQuestionsByIDs = `-- name: GetQuestionsByIDs :many
+SELECT id, question, type, options, correct_answer, created_at, format FROM self_assessment_questions
+WHERE id = ANY($1::int[])
+`
+
+func (q *Queries) GetQuestionsByIDs(ctx context.Context, ids []int32) ([]SelfAssessmentQuestion, error) {
+	rows, err := q.db.Query(ctx, getQuestionsByIDs, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelfAssessmentQuestion
+	for rows.Next() {
+		var i SelfAssessmentQuestion
+		if err := rows.Scan(
+			&i.ID,
+			&i.Question,
+			&i.Type,
+			&i.Options,
+			&i.CorrectAnswer,
+			&i.CreatedAt,
+			&i.Format,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getRubric = `-- name: GetRubric :one
+SELECT question_id, criteria, double_marked, max_disagreement, updated_at FROM self_assessment_rubrics
+WHERE question_id = $1
+`
+
+func (q *Queries) GetRubric(ctx context.Context, questionID int32) (SelfAssessmentRubric, error) {
+	row := q.db.QueryRow(ctx, getRubric, questionID)
+	var i SelfAssessmentRubric
+	err := row.Scan(
+		&i.QuestionID,
+		&i.Criteria,
+		&i.DoubleMarked,
+		&i.MaxDisagreement,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const getSessionAccommodation = `-- name: GetSessionAccommodation :one
+SELECT a.id, a.user_id, a.session_id, a.time_multiplier, a.break_minutes, a.alternative_formats, a.reason, a.approved_by, a.created_at, a.revoked_at, a.revoked_by
+FROM session_accommodations sa
+JOIN candidate_accommodations a ON a.id = sa.accommodation_id
+WHERE sa.session_id = $1 AND a.revoked_at IS NULL
+`
+
+// The accommodation a session runs under, unless it has been revoked
+func (q *Queries) GetSessionAccommodation(ctx context.Context, sessionID int32) (CandidateAccommodation, error) {
+	row := q.db.QueryRow(ctx, getSessionAccommodation, sessionID)
+	var i CandidateAccommodation
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.SessionID,
+		&i.TimeMultiplier,
+		&i.BreakMinutes,
+		&i.AlternativeFormats,
+		&i.Reason,
+		&i.ApprovedBy,
+		&i.CreatedAt,
+		&i.RevokedAt,
+		&i.RevokedBy,
+	)
+	return i, err
+}
+
+const getSessionScores = `-- name: GetSessionScores :many
+SELECT 
+  uas.id, uas.user_id, uas.session_id, uas.category_id, uas.score, uas.theta, uas.theta_se,
+  sac.name as category_name,
+  sac.description as category_description
+FROM user_assessment_scores uas
+JOIN self_assessment_categories sac ON uas.category_id = sac.id
+WHERE uas.session_id = $1
+`
+
+type GetSessionScoresRow struct {
+	ID                  int32
+	UserID              pgtype.Int4
+	SessionID           pgtype.Int4
+	CategoryID          pgtype.Int4
+	Score               pgtype.Int4
+	Theta               pgtype.Float8
+	ThetaSe             pgtype.Float8
+	CategoryName        pgtype.Text
+	CategoryDescription pgtype.Text
+}
+
+// Get all scores for a specific session with category details
+func (q *Queries) GetSessionScores(ctx context.Context, sessionID pgtype.Int4) ([]GetSessionScoresRow, error) {
+	rows, err := q.db.Query(ctx, getSessionScores, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetSessionScoresRow
+	for rows.Next() {
+		var i GetSessionScoresRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.SessionID,
+			&i.CategoryID,
 			&i.Score,
+			&i.Theta,
+			&i.ThetaSe,
 			&i.CategoryName,
 			&i.CategoryDescription,
 		); err != nil {
@@ -380,6 +1017,43 @@ func (q *Queries) GetSessionScores(ctx context.Context, sessionID pgtype.Int4) (
 	return items, nil
 }
 
+const getStaffUser = `-- name: GetStaffUser :one
+SELECT u.id, u.name FROM users u
+JOIN roles r ON r.id = u.role_id
+WHERE u.id = $1 AND r.name = 'admin'
+`
+
+type GetStaffUserRow struct {
+	ID   int32
+	Name string
+}
+
+func (q *Queries) GetStaffUser(ctx context.Context, id int32) (GetStaffUserRow, error) {
+	row := q.db.QueryRow(ctx, getStaffUser, id)
+	var i GetStaffUserRow
+	err := row.Scan(&i.ID, &i.Name)
+	return i, err
+}
+
+const getTraitFacet = `-- name: GetTraitFacet :one
+SELECT id, model_id, code, trait_category_id, facet_category_id, position FROM trait_facets
+WHERE id = $1
+`
+
+func (q *Queries) GetTraitFacet(ctx context.Context, id int32) (TraitFacet, error) {
+	row := q.db.QueryRow(ctx, getTraitFacet, id)
+	var i TraitFacet
+	err := row.Scan(
+		&i.ID,
+		&i.ModelID,
+		&i.Code,
+		&i.TraitCategoryID,
+		&i.FacetCategoryID,
+		&i.Position,
+	)
+	return i, err
+}
+
 const getUserCompletedAssessments = `-- name: GetUserCompletedAssessments :many
 SELECT assessment_type, completed_at 
 FROM user_assessment_sessions
@@ -411,6 +1085,68 @@ func (q *Queries) GetUserCompletedAssessments(ctx context.Context, userID int32)
 	return items, nil
 }
 
+const getUserLocale = `-- name: GetUserLocale :one
+SELECT locale FROM user_preferences
+WHERE user_id = $1
+`
+
+func (q *Queries) GetUserLocale(ctx context.Context, userID int32) (pgtype.Text, error) {
+	row := q.db.QueryRow(ctx, getUserLocale, userID)
+	var locale pgtype.Text
+	err := row.Scan(&locale)
+	return locale, err
+}
+
+const getValidityScale = `-- name: GetValidityScale :one
+SELECT id, name, kind, assessment_type, threshold, description, created_at FROM validity_scales
+WHERE id = $1
+`
+
+func (q *Queries) GetValidityScale(ctx context.Context, id int32) (ValidityScale, error) {
+	row := q.db.QueryRow(ctx, getValidityScale, id)
+	var i ValidityScale
+	err := row.Scan(
+		&i.ID,
+		&i.Name,
+		&i.Kind,
+		&i.AssessmentType,
+		&i.Threshold,
+		&i.Description,
+		&i.CreatedAt,
+	)
+	return i, err
+}
+
+const insertBlueprintSection = `-- name: InsertBlueprintSection :one
+INSERT INTO assessment_blueprint_sections(
+    blueprint_id,
+    category_id,
+    question_count
+)VALUES(
+    $1,
+    $2,
+    $3
+) RETURNING id, blueprint_id, category_id, question_count
+`
+
+type InsertBlueprintSectionParams struct {
+	BlueprintID   int32
+	CategoryID    int32
+	QuestionCount int32
+}
+
+func (q *Queries) InsertBlueprintSection(ctx context.Context, arg InsertBlueprintSectionParams) (AssessmentBlueprintSection, error) {
+	row := q.db.QueryRow(ctx, insertBlueprintSection, arg.BlueprintID, arg.CategoryID, arg.QuestionCount)
+	var i AssessmentBlueprintSection
+	err := row.Scan(
+		&i.ID,
+		&i.BlueprintID,
+		&i.CategoryID,
+		&i.QuestionCount,
+	)
+	return i, err
+}
+
 const insertCategory = `-- name: InsertCategory :one
 INSERT INTO self_assessment_categories(
     name,
@@ -478,14 +1214,16 @@ INSERT INTO self_assessment_questions(
     type,
     options,
     correct_answer,
+    format,
     created_at
 )VALUES(
     $1,
     $2,
     $3,
     $4,
+    $5,
     CURRENT_TIMESTAMP
-) RETURNING id, question, type, options, correct_answer, created_at
+) RETURNING id, question, type, options, correct_answer, created_at, format
 `
 
 type InsertQuestionParams struct {
@@ -493,6 +1231,7 @@ type InsertQuestionParams struct {
 	Type          QuestionType
 	Options       []byte
 	CorrectAnswer pgtype.Text
+	Format        QuestionFormat
 }
 
 func (q *Queries) InsertQuestion(ctx context.Context, arg InsertQuestionParams) (SelfAssessmentQuestion, error) {
@@ -501,6 +1240,7 @@ func (q *Queries) InsertQuestion(ctx context.Context, arg InsertQuestionParams)
 		arg.Type,
 		arg.Options,
 		arg.CorrectAnswer,
+		arg.Format,
 	)
 	var i SelfAssessmentQuestion
 	err := row.Scan(
@@ -510,16 +1250,48 @@ func (q *Queries) InsertQuestion(ctx context.Context, arg InsertQuestionParams)
 		&i.Options,
 		&i.CorrectAnswer,
 		&i.CreatedAt,
+		&i.Format,
 	)
 	return i, err
 }
 
+const insertSessionQuestion = `-- name: InsertSessionQuestion :exec
+INSERT INTO user_session_questions(
+    session_id,
+    question_id,
+    position,
+    option_order
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4
+)
+`
+
+type InsertSessionQuestionParams struct {
+	SessionID   int32
+	QuestionID  int32
+	Position    int32
+	OptionOrder []byte
+}
+
+func (q *Queries) InsertSessionQuestion(ctx context.Context, arg InsertSessionQuestionParams) error {
+	_, err := q.db.Exec(ctx, insertSessionQuestion,
+		arg.SessionID,
+		arg.QuestionID,
+		arg.Position,
+		arg.OptionOrder,
+	)
+	return err
+}
+
 const insertUserAnswer = `-- name: InsertUserAnswer :exec
 INSERT INTO user_answers (
-    user_id, session_id, question_id, answer_value
+    user_id, session_id, question_id, answer_value, answered_at
 ) VALUES (
-    $1, $2, $3, $4
-) RETURNING id, user_id, session_id, question_id, answer_value
+    $1, $2, $3, $4, $5
+) RETURNING id, user_id, session_id, question_id, answer_value, answered_at
 `
 
 type InsertUserAnswerParams struct {
@@ -527,6 +1299,7 @@ type InsertUserAnswerParams struct {
 	SessionID   pgtype.Int4
 	QuestionID  pgtype.Int4
 	AnswerValue []byte
+	AnsweredAt  pgtype.Timestamp
 }
 
 func (q *Queries) InsertUserAnswer(ctx context.Context, arg InsertUserAnswerParams) error {
@@ -535,76 +1308,112 @@ func (q *Queries) InsertUserAnswer(ctx context.Context, arg InsertUserAnswerPara
 		arg.SessionID,
 		arg.QuestionID,
 		arg.AnswerValue,
+		arg.AnsweredAt,
 	)
 	return err
 }
 
-const listCandidateScores = `-- name: ListCandidateScores :many
-WITH user_category_scores AS (
-  SELECT 
-    user_id,
-    session_id,
-    category_id,
-    score,
-    sac.name AS category_name
-  FROM 
-    user_assessment_scores uas
-  JOIN 
-    self_assessment_categories sac ON uas.category_id = sac.id
-),
-candidate_behavioral_scores AS (
-  SELECT 
-    user_id,
-    session_id,
-    category_id,
-    category_name,
-    score,
-    RANK() OVER (PARTITION BY user_id ORDER BY score DESC) as score_rank
-  FROM 
-    user_category_scores
+const linkCandidateAccommodation = `-- name: LinkCandidateAccommodation :exec
+INSERT INTO session_accommodations (session_id, accommodation_id)
+SELECT $1::int, a.id FROM candidate_accommodations a
+WHERE a.user_id = $2 AND a.session_id IS NULL AND a.revoked_at IS NULL
+ORDER BY a.created_at DESC, a.id DESC
+LIMIT 1
+ON CONFLICT (session_id) DO NOTHING
+`
+
+type LinkCandidateAccommodationParams struct {
+	SessionID int32
+	UserID    int32
+}
+
+// Run a new session under the candidate's latest candidate-wide accommodation
+func (q *Queries) LinkCandidateAccommodation(ctx context.Context, arg LinkCandidateAccommodationParams) error {
+	_, err := q.db.Exec(ctx, linkCandidateAccommodation, arg.SessionID, arg.UserID)
+	return err
+}
+
+const listAdaptiveCandidateItems = `-- name: ListAdaptiveCandidateItems :many
+SELECT
+  q.id,
+  COALESCE(p.discrimination, 1)::float8 AS discrimination,
+  COALESCE(p.difficulty, 0)::float8 AS difficulty
+FROM self_assessment_questions q
+LEFT JOIN self_assessment_item_parameters p ON p.question_id = q.id
+WHERE q.type = 'cognitive' AND q.format = 'single_choice' AND NOT EXISTS (
+  SELECT 1 FROM user_session_questions sq
+  WHERE sq.session_id = $1 AND sq.question_id = q.id
 )
+ORDER BY q.id
+`
 
-SELECT 
-  user_id,
-  session_id,
-  category_name AS top_behavioral_trait,
-  score AS top_behavioral_score,
-  CASE 
-    WHEN COUNT(category_name) OVER (PARTITION BY user_id) > 0 
-    THEN 'In Progress'
-    ELSE 'Not Started'
-  END AS assessment_status
-FROM 
-  candidate_behavioral_scores
-WHERE 
-  score_rank = 1
-ORDER BY 
-  user_id
+type ListAdaptiveCandidateItemsRow struct {
+	ID             int32
+	Discrimination float64
+	Difficulty     float64
+}
+
+// Cognitive items not yet shown in a session, with their IRT parameters
+func (q *Queries) ListAdaptiveCandidateItems(ctx context.Context, sessionID int32) ([]ListAdaptiveCandidateItemsRow, error) {
+	rows, err := q.db.Query(ctx, listAdaptiveCandidateItems, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListAdaptiveCandidateItemsRow
+	for rows.Next() {
+		var i ListAdaptiveCandidateItemsRow
+		if err := rows.Scan(&i.ID, &i.Discrimination, &i.Difficulty); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAdaptiveResponses = `-- name: ListAdaptiveResponses :many
+SELECT
+  ua.question_id,
+  COALESCE(p.discrimination, 1)::float8 AS discrimination,
+  COALESCE(p.difficulty, 0)::float8 AS difficulty,
+  EXISTS (
+    SELECT 1 FROM self_assessment_mappings m
+    WHERE m.question_id = ua.question_id AND
+    m.answer_value = (ua.answer_value->>'selected')::int AND
+    m.points > 0
+  ) AS correct
+FROM user_answers ua
+LEFT JOIN self_assessment_item_parameters p ON p.question_id = ua.question_id
+WHERE ua.session_id = $1
+ORDER BY ua.id
 `
 
-type ListCandidateScoresRow struct {
-	UserID             pgtype.Int4
-	SessionID          pgtype.Int4
-	TopBehavioralTrait pgtype.Text
-	TopBehavioralScore pgtype.Int4
-	AssessmentStatus   string
+type ListAdaptiveResponsesRow struct {
+	QuestionID     pgtype.Int4
+	Discrimination float64
+	Difficulty     float64
+	Correct        bool
 }
 
-func (q *Queries) ListCandidateScores(ctx context.Context) ([]ListCandidateScoresRow, error) {
-	rows, err := q.db.Query(ctx, listCandidateScores)
+// Answered items of a session with their IRT parameters and whether the
+// selected option was the keyed one
+func (q *Queries) ListAdaptiveResponses(ctx context.Context, sessionID pgtype.Int4) ([]ListAdaptiveResponsesRow, error) {
+	rows, err := q.db.Query(ctx, listAdaptiveResponses, sessionID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []ListCandidateScoresRow
+	var items []ListAdaptiveResponsesRow
 	for rows.Next() {
-		var i ListCandidateScoresRow
+		var i ListAdaptiveResponsesRow
 		if err := rows.Scan(
-			&i.UserID,
-			&i.SessionID,
-			&i.TopBehavioralTrait,
-			&i.TopBehavioralScore,
-			&i.AssessmentStatus,
+			&i.QuestionID,
+			&i.Discrimination,
+			&i.Difficulty,
+			&i.Correct,
 		); err != nil {
 			return nil, err
 		}
@@ -615,3 +1424,1354 @@ func (q *Queries) ListCandidateScores(ctx context.Context) ([]ListCandidateScore
 	}
 	return items, nil
 }
+
+const listBlueprintSections = `-- name: ListBlueprintSections :many
+SELECT id, blueprint_id, category_id, question_count FROM assessment_blueprint_sections
+WHERE blueprint_id = $1
+ORDER BY id
+`
+
+func (q *Queries) ListBlueprintSections(ctx context.Context, blueprintID int32) ([]AssessmentBlueprintSection, error) {
+	rows, err := q.db.Query(ctx, listBlueprintSections, blueprintID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AssessmentBlueprintSection
+	for rows.Next() {
+		var i AssessmentBlueprintSection
+		if err := rows.Scan(
+			&i.ID,
+			&i.BlueprintID,
+			&i.CategoryID,
+			&i.QuestionCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listBlueprints = `-- name: ListBlueprints :many
+SELECT id, name, assessment_type, shuffle_options, max_item_exposures, created_at, time_limit_minutes FROM assessment_blueprints
+ORDER BY id
+`
+
+func (q *Queries) ListBlueprints(ctx context.Context) ([]AssessmentBlueprint, error) {
+	rows, err := q.db.Query(ctx, listBlueprints)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []AssessmentBlueprint
+	for rows.Next() {
+		var i AssessmentBlueprint
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.AssessmentType,
+			&i.ShuffleOptions,
+			&i.MaxItemExposures,
+			&i.CreatedAt,
+			&i.TimeLimitMinutes,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCandidateScores = `-- name: ListCandidateScores :many
+SELECT
+ user_id,
+ session_id,
+ top_behavioral_trait,
+ top_behavioral_score,
+CASE
+WHEN COUNT(top_behavioral_trait) OVER (PARTITION BY user_id) > 0
+THEN 'In Progress'
+ ELSE 'Not Started'
+END AS assessment_status,
+ ARRAY(
+   SELECT DISTINCT f->>'flag'
+   FROM user_assessment_sessions s, jsonb_array_elements(s.quality_flags) f
+   WHERE s.user_id = cl.user_id
+   ORDER BY 1
+ )::text[] AS quality_flags
+FROM
+ candidate_listing cl
+WHERE
+ ($1::text IS NULL OR EXISTS (
+   SELECT 1 FROM users u
+   WHERE u.id = cl.user_id AND
+   (u.name ILIKE '%' || $1 || '%' OR u.email ILIKE '%' || $1 || '%')
+ )) AND
+ ($2::text IS NULL OR EXISTS (
+   SELECT 1 FROM user_assessment_sessions s
+   WHERE s.user_id = cl.user_id AND
+   s.assessment_type = $2 AND
+   s.completed_at IS NOT NULL
+ )) AND
+ ($3::timestamp IS NULL OR EXISTS (
+   SELECT 1 FROM user_assessment_sessions s
+   WHERE s.user_id = cl.user_id AND s.completed_at >= $3
+ )) AND
+ ($4::timestamp IS NULL OR EXISTS (
+   SELECT 1 FROM user_assessment_sessions s
+   WHERE s.user_id = cl.user_id AND s.completed_at < $4
+ ))
+ORDER BY
+ user_id
+`
+
+type ListCandidateScoresParams struct {
+	Search         pgtype.Text
+	AssessmentType pgtype.Text
+	CompletedFrom  pgtype.Timestamp
+	CompletedTo    pgtype.Timestamp
+}
+
+type ListCandidateScoresRow struct {
+	UserID             pgtype.Int4
+	SessionID          pgtype.Int4
+	TopBehavioralTrait pgtype.Text
+	TopBehavioralScore pgtype.Int4
+	AssessmentStatus   string
+	QualityFlags       []string
+}
+
+// Top behavioral trait per candidate, narrowed by the optional listing filters
+func (q *Queries) ListCandidateScores(ctx context.Context, arg ListCandidateScoresParams) ([]ListCandidateScoresRow, error) {
+	rows, err := q.db.Query(ctx, listCandidateScores,
+		arg.Search,
+		arg.AssessmentType,
+		arg.CompletedFrom,
+		arg.CompletedTo,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListCandidateScoresRow
+	for rows.Next() {
+		var i ListCandidateScoresRow
+		if err := rows.Scan(
+			&i.UserID,
+			&i.SessionID,
+			&i.TopBehavioralTrait,
+			&i.TopBehavioralScore,
+			&i.AssessmentStatus,
+			&i.QualityFlags,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listCategoryTranslations = `-- name: ListCategoryTranslations :many
+SELECT category_id, locale, description, updated_at FROM category_translations
+WHERE locale = $1
+`
+
+func (q *Queries) ListCategoryTranslations(ctx context.Context, locale string) ([]CategoryTranslation, error) {
+	rows, err := q.db.Query(ctx, listCategoryTranslations, locale)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []CategoryTranslation
+	for rows.Next() {
+		var i CategoryTranslation
+		if err := rows.Scan(
+			&i.CategoryID,
+			&i.Locale,
+			&i.Description,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGradingAssignments = `-- name: ListGradingAssignments :many
+SELECT id, item_id, grader_id, resolver, criterion_scores, comment, assigned_at, submitted_at FROM grading_assignments
+WHERE item_id = $1
+ORDER BY resolver, assigned_at, id
+`
+
+func (q *Queries) ListGradingAssignments(ctx context.Context, itemID int32) ([]GradingAssignment, error) {
+	rows, err := q.db.Query(ctx, listGradingAssignments, itemID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GradingAssignment
+	for rows.Next() {
+		var i GradingAssignment
+		if err := rows.Scan(
+			&i.ID,
+			&i.ItemID,
+			&i.GraderID,
+			&i.Resolver,
+			&i.CriterionScores,
+			&i.Comment,
+			&i.AssignedAt,
+			&i.SubmittedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listGradingQueue = `-- name: ListGradingQueue :many
+SELECT
+  gi.id,
+  gi.session_id,
+  gi.question_id,
+  q.question,
+  s.assessment_type,
+  gi.status,
+  gi.required_grades,
+  gi.created_at,
+  COUNT(a.id) FILTER (WHERE NOT a.resolver) AS markers_assigned,
+  COUNT(a.id) FILTER (WHERE a.submitted_at IS NOT NULL) AS grades_submitted
+FROM grading_items gi
+JOIN self_assessment_questions q ON q.id = gi.question_id
+JOIN user_assessment_sessions s ON s.id = gi.session_id
+LEFT JOIN grading_assignments a ON a.item_id = gi.id
+WHERE gi.status <> 'graded'
+  AND ($1::grading_status IS NULL OR gi.status = $1)
+  AND ($2::int IS NULL OR EXISTS (
+    SELECT 1 FROM grading_assignments mine
+    WHERE mine.item_id = gi.id AND mine.grader_id = $2 AND mine.submitted_at IS NULL
+  ))
+GROUP BY gi.id, q.question, s.assessment_type
+ORDER BY gi.created_at, gi.id
+`
+
+type ListGradingQueueParams struct {
+	Status   NullGradingStatus
+	GraderID pgtype.Int4
+}
+
+type ListGradingQueueRow struct {
+	ID              int32
+	SessionID       int32
+	QuestionID      int32
+	Question        string
+	AssessmentType  string
+	Status          GradingStatus
+	RequiredGrades  int32
+	CreatedAt       pgtype.Timestamp
+	MarkersAssigned int64
+	GradesSubmitted int64
+}
+
+// Items still waiting for a grade, oldest first. Candidates are not named so
+// marking stays blind
+func (q *Queries) ListGradingQueue(ctx context.Context, arg ListGradingQueueParams) ([]ListGradingQueueRow, error) {
+	rows, err := q.db.Query(ctx, listGradingQueue, arg.Status, arg.GraderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListGradingQueueRow
+	for rows.Next() {
+		var i ListGradingQueueRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.SessionID,
+			&i.QuestionID,
+			&i.Question,
+			&i.AssessmentType,
+			&i.Status,
+			&i.RequiredGrades,
+			&i.CreatedAt,
+			&i.MarkersAssigned,
+			&i.GradesSubmitted,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listItemPairs = `-- name: ListItemPairs :many
+SELECT id, question_id, paired_question_id, reversed FROM self_assessment_item_pairs
+ORDER BY id
+`
+
+func (q *Queries) ListItemPairs(ctx context.Context) ([]SelfAssessmentItemPair, error) {
+	rows, err := q.db.Query(ctx, listItemPairs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelfAssessmentItemPair
+	for rows.Next() {
+		var i SelfAssessmentItemPair
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionID,
+			&i.PairedQuestionID,
+			&i.Reversed,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listMappingsByQuestionIDs = `-- name: ListMappingsByQuestionIDs :many
+SELECT id, question_id, answer_value, category_id, points FROM self_assessment_mappings
+WHERE question_id = ANY($1::int[])
+ORDER BY question_id, answer_value
+`
+
+func (q *Queries) ListMappingsByQuestionIDs(ctx context.Context, questionIds []int32) ([]SelfAssessmentMapping, error) {
+	rows, err := q.db.Query(ctx, listMappingsByQuestionIDs, questionIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SelfAssessmentMapping
+	for rows.Next() {
+		var i SelfAssessmentMapping
+		if err := rows.Scan(
+			&i.ID,
+			&i.QuestionID,
+			&i.AnswerValue,
+			&i.CategoryID,
+			&i.Points,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQualityAnswers = `-- name: ListQualityAnswers :many
+SELECT
+  ua.question_id,
+  q.type,
+  q.options,
+  COALESCE(ua.answer_value->>'selected', '')::text AS selected,
+  ua.answered_at
+FROM user_answers ua
+JOIN self_assessment_questions q ON q.id = ua.question_id
+LEFT JOIN user_session_questions sq ON sq.session_id = ua.session_id AND sq.question_id = ua.question_id
+WHERE ua.session_id = $1 AND q.format = 'single_choice'
+ORDER BY sq.position NULLS LAST, ua.id
+`
+
+type ListQualityAnswersRow struct {
+	QuestionID pgtype.Int4
+	Type       QuestionType
+	Options    []byte
+	Selected   string
+	AnsweredAt pgtype.Timestamp
+}
+
+// Answers of a session in the order the questions were shown
+func (q *Queries) ListQualityAnswers(ctx context.Context, sessionID pgtype.Int4) ([]ListQualityAnswersRow, error) {
+	rows, err := q.db.Query(ctx, listQualityAnswers, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListQualityAnswersRow
+	for rows.Next() {
+		var i ListQualityAnswersRow
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.Type,
+			&i.Options,
+			&i.Selected,
+			&i.AnsweredAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestionIDsByType = `-- name: ListQuestionIDsByType :many
+SELECT q.id FROM self_assessment_questions q
+WHERE q.type = $1
+  AND NOT EXISTS (SELECT 1 FROM trait_items ti WHERE ti.question_id = q.id)
+ORDER BY random()
+`
+
+// Questions asked when no blueprint applies. Trait model items are only asked
+// through their model's blueprint, which covers every facet.
+func (q *Queries) ListQuestionIDsByType(ctx context.Context, type_ QuestionType) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listQuestionIDsByType, type_)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var id int32
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listQuestionTranslations = `-- name: ListQuestionTranslations :many
+SELECT question_id, locale, question, options, updated_at FROM question_translations
+WHERE locale = $1 AND question_id = ANY($2::int[])
+`
+
+type ListQuestionTranslationsParams struct {
+	Locale      string
+	QuestionIds []int32
+}
+
+func (q *Queries) ListQuestionTranslations(ctx context.Context, arg ListQuestionTranslationsParams) ([]QuestionTranslation, error) {
+	rows, err := q.db.Query(ctx, listQuestionTranslations, arg.Locale, arg.QuestionIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QuestionTranslation
+	for rows.Next() {
+		var i QuestionTranslation
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.Locale,
+			&i.Question,
+			&i.Options,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listRescorableAnswers = `-- name: ListRescorableAnswers :many
+SELECT id, question_id, answer_value FROM user_answers
+WHERE session_id = $1
+  AND answer_value->>'format' IS NOT NULL
+  AND answer_value->>'format' <> 'open_ended'
+ORDER BY id
+`
+
+type ListRescorableAnswersRow struct {
+	ID          int32
+	QuestionID  pgtype.Int4
+	AnswerValue []byte
+}
+
+// Typed answers in a session, which carry points worked out from the
+// question's mappings. Open-ended answers keep the points they were graded.
+func (q *Queries) ListRescorableAnswers(ctx context.Context, sessionID pgtype.Int4) ([]ListRescorableAnswersRow, error) {
+	rows, err := q.db.Query(ctx, listRescorableAnswers, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListRescorableAnswersRow
+	for rows.Next() {
+		var i ListRescorableAnswersRow
+		if err := rows.Scan(&i.ID, &i.QuestionID, &i.AnswerValue); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionAnswerTimes = `-- name: ListSessionAnswerTimes :many
+SELECT question_id, answered_at FROM user_session_questions
+WHERE session_id = $1 AND answered_at IS NOT NULL
+`
+
+type ListSessionAnswerTimesRow struct {
+	QuestionID int32
+	AnsweredAt pgtype.Timestamp
+}
+
+func (q *Queries) ListSessionAnswerTimes(ctx context.Context, sessionID int32) ([]ListSessionAnswerTimesRow, error) {
+	rows, err := q.db.Query(ctx, listSessionAnswerTimes, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSessionAnswerTimesRow
+	for rows.Next() {
+		var i ListSessionAnswerTimesRow
+		if err := rows.Scan(&i.QuestionID, &i.AnsweredAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionQuestions = `-- name: ListSessionQuestions :many
+SELECT
+  q.id,
+  q.question,
+  q.type,
+  q.options,
+  q.format,
+  sq.position,
+  sq.option_order
+FROM user_session_questions sq
+JOIN self_assessment_questions q ON q.id = sq.question_id
+WHERE sq.session_id = $1
+ORDER BY sq.position
+`
+
+type ListSessionQuestionsRow struct {
+	ID          int32
+	Question    string
+	Type        QuestionType
+	Options     []byte
+	Format      QuestionFormat
+	Position    int32
+	OptionOrder []byte
+}
+
+// Questions drawn for a session in display order
+func (q *Queries) ListSessionQuestions(ctx context.Context, sessionID int32) ([]ListSessionQuestionsRow, error) {
+	rows, err := q.db.Query(ctx, listSessionQuestions, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSessionQuestionsRow
+	for rows.Next() {
+		var i ListSessionQuestionsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Question,
+			&i.Type,
+			&i.Options,
+			&i.Format,
+			&i.Position,
+			&i.OptionOrder,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listSessionValidityScores = `-- name: ListSessionValidityScores :many
+SELECT
+  svs.scale_id,
+  s.name,
+  s.kind,
+  svs.score,
+  svs.item_count,
+  s.threshold,
+  svs.flagged
+FROM session_validity_scores svs
+JOIN validity_scales s ON s.id = svs.scale_id
+WHERE svs.session_id = $1
+ORDER BY s.name
+`
+
+type ListSessionValidityScoresRow struct {
+	ScaleID   int32
+	Name      string
+	Kind      ValidityScaleKind
+	Score     int32
+	ItemCount int32
+	Threshold int32
+	Flagged   bool
+}
+
+func (q *Queries) ListSessionValidityScores(ctx context.Context, sessionID int32) ([]ListSessionValidityScoresRow, error) {
+	rows, err := q.db.Query(ctx, listSessionValidityScores, sessionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListSessionValidityScoresRow
+	for rows.Next() {
+		var i ListSessionValidityScoresRow
+		if err := rows.Scan(
+			&i.ScaleID,
+			&i.Name,
+			&i.Kind,
+			&i.Score,
+			&i.ItemCount,
+			&i.Threshold,
+			&i.Flagged,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTraitFacets = `-- name: ListTraitFacets :many
+SELECT
+  f.id,
+  f.model_id,
+  f.code,
+  f.position,
+  f.trait_category_id,
+  tc.name AS trait_name,
+  f.facet_category_id,
+  fc.name AS facet_name,
+  (SELECT COUNT(*) FROM trait_items ti WHERE ti.facet_id = f.id) AS item_count
+FROM trait_facets f
+JOIN self_assessment_categories tc ON tc.id = f.trait_category_id
+JOIN self_assessment_categories fc ON fc.id = f.facet_category_id
+ORDER BY f.model_id, f.position
+`
+
+type ListTraitFacetsRow struct {
+	ID              int32
+	ModelID         int32
+	Code            string
+	Position        int32
+	TraitCategoryID int32
+	TraitName       pgtype.Text
+	FacetCategoryID int32
+	FacetName       pgtype.Text
+	ItemCount       int64
+}
+
+// Facets of every trait model, in model order, with their trait and items
+func (q *Queries) ListTraitFacets(ctx context.Context) ([]ListTraitFacetsRow, error) {
+	rows, err := q.db.Query(ctx, listTraitFacets)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListTraitFacetsRow
+	for rows.Next() {
+		var i ListTraitFacetsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.ModelID,
+			&i.Code,
+			&i.Position,
+			&i.TraitCategoryID,
+			&i.TraitName,
+			&i.FacetCategoryID,
+			&i.FacetName,
+			&i.ItemCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTraitModels = `-- name: ListTraitModels :many
+SELECT id, name, source, created_at FROM trait_models
+ORDER BY id
+`
+
+func (q *Queries) ListTraitModels(ctx context.Context) ([]TraitModel, error) {
+	rows, err := q.db.Query(ctx, listTraitModels)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []TraitModel
+	for rows.Next() {
+		var i TraitModel
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Source,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationLocales = `-- name: ListTranslationLocales :many
+SELECT locale FROM question_translations
+UNION
+SELECT locale FROM category_translations
+ORDER BY locale
+`
+
+// Locales with at least one translation
+func (q *Queries) ListTranslationLocales(ctx context.Context) ([]string, error) {
+	rows, err := q.db.Query(ctx, listTranslationLocales)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var locale string
+		if err := rows.Scan(&locale); err != nil {
+			return nil, err
+		}
+		items = append(items, locale)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listTranslationsForQuestion = `-- name: ListTranslationsForQuestion :many
+SELECT question_id, locale, question, options, updated_at FROM question_translations
+WHERE question_id = $1
+ORDER BY locale
+`
+
+func (q *Queries) ListTranslationsForQuestion(ctx context.Context, questionID int32) ([]QuestionTranslation, error) {
+	rows, err := q.db.Query(ctx, listTranslationsForQuestion, questionID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []QuestionTranslation
+	for rows.Next() {
+		var i QuestionTranslation
+		if err := rows.Scan(
+			&i.QuestionID,
+			&i.Locale,
+			&i.Question,
+			&i.Options,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listValidityQuestionIDs = `-- name: ListValidityQuestionIDs :many
+SELECT vi.question_id FROM validity_items vi
+JOIN self_assessment_questions q ON q.id = vi.question_id
+JOIN validity_scales s ON s.id = vi.scale_id
+WHERE q.type = $1 AND s.assessment_type = q.type::text
+ORDER BY vi.question_id
+`
+
+// Validity items are asked in every session of their type
+func (q *Queries) ListValidityQuestionIDs(ctx context.Context, type_ QuestionType) ([]int32, error) {
+	rows, err := q.db.Query(ctx, listValidityQuestionIDs, type_)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []int32
+	for rows.Next() {
+		var question_id int32
+		if err := rows.Scan(&question_id); err != nil {
+			return nil, err
+		}
+		items = append(items, question_id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listValidityScales = `-- name: ListValidityScales :many
+SELECT
+  s.id,
+  s.name,
+  s.kind,
+  s.assessment_type,
+  s.threshold,
+  s.description,
+  (SELECT COUNT(*) FROM validity_items vi WHERE vi.scale_id = s.id) AS item_count
+FROM validity_scales s
+ORDER BY s.assessment_type, s.name
+`
+
+type ListValidityScalesRow struct {
+	ID             int32
+	Name           string
+	Kind           ValidityScaleKind
+	AssessmentType string
+	Threshold      int32
+	Description    pgtype.Text
+	ItemCount      int64
+}
+
+// Every validity scale with the number of items on it
+func (q *Queries) ListValidityScales(ctx context.Context) ([]ListValidityScalesRow, error) {
+	rows, err := q.db.Query(ctx, listValidityScales)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []ListValidityScalesRow
+	for rows.Next() {
+		var i ListValidityScalesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Kind,
+			&i.AssessmentType,
+			&i.Threshold,
+			&i.Description,
+			&i.ItemCount,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const lockAssessmentSession = `-- name: LockAssessmentSession :one
+SELECT id, user_id, assessment_type, started_at, completed_at, blueprint_id, quality_flags, submitted_at FROM user_assessment_sessions
+WHERE id = $1
+FOR UPDATE
+`
+
+// Serializes finalization so a session is scored exactly once
+func (q *Queries) LockAssessmentSession(ctx context.Context, id int32) (UserAssessmentSession, error) {
+	row := q.db.QueryRow(ctx, lockAssessmentSession, id)
+	var i UserAssessmentSession
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AssessmentType,
+		&i.StartedAt,
+		&i.CompletedAt,
+		&i.BlueprintID,
+		&i.QualityFlags,
+		&i.SubmittedAt,
+	)
+	return i, err
+}
+
+const lockGradingItem = `-- name: LockGradingItem :one
+SELECT id, answer_id, session_id, question_id, required_grades, status, final_scores, created_at, graded_at FROM grading_items
+WHERE id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockGradingItem(ctx context.Context, id int32) (GradingItem, error) {
+	row := q.db.QueryRow(ctx, lockGradingItem, id)
+	var i GradingItem
+	err := row.Scan(
+		&i.ID,
+		&i.AnswerID,
+		&i.SessionID,
+		&i.QuestionID,
+		&i.RequiredGrades,
+		&i.Status,
+		&i.FinalScores,
+		&i.CreatedAt,
+		&i.GradedAt,
+	)
+	return i, err
+}
+
+const markSessionQuestionAnswered = `-- name: MarkSessionQuestionAnswered :execrows
+UPDATE user_session_questions sq
+SET answered_at = CURRENT_TIMESTAMP
+FROM user_assessment_sessions s
+WHERE s.id = sq.session_id
+  AND sq.session_id = $1
+  AND sq.question_id = $2
+  AND s.user_id = $3
+  AND s.submitted_at IS NULL
+  AND s.completed_at IS NULL
+`
+
+type MarkSessionQuestionAnsweredParams struct {
+	SessionID  int32
+	QuestionID int32
+	UserID     int32
+}
+
+// Stamp when a candidate answered a question of their unsubmitted session
+func (q *Queries) MarkSessionQuestionAnswered(ctx context.Context, arg MarkSessionQuestionAnsweredParams) (int64, error) {
+	result, err := q.db.Exec(ctx, markSessionQuestionAnswered, arg.SessionID, arg.QuestionID, arg.UserID)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const markSessionSubmitted = `-- name: MarkSessionSubmitted :exec
+UPDATE user_assessment_sessions
+SET submitted_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND submitted_at IS NULL
+`
+
+func (q *Queries) MarkSessionSubmitted(ctx context.Context, id int32) error {
+	_, err := q.db.Exec(ctx, markSessionSubmitted, id)
+	return err
+}
+
+const scoreValidityScales = `-- name: ScoreValidityScales :exec
+INSERT INTO session_validity_scores (session_id, scale_id, score, item_count, flagged)
+SELECT
+  $1::int,
+  s.id,
+  COUNT(*) FILTER (WHERE ua.answer_value->>'selected' = ANY(vi.keyed_answers)),
+  COUNT(*),
+  COUNT(*) FILTER (WHERE ua.answer_value->>'selected' = ANY(vi.keyed_answers)) >= s.threshold
+FROM validity_scales s
+JOIN validity_items vi ON vi.scale_id = s.id
+JOIN user_answers ua ON ua.question_id = vi.question_id
+WHERE ua.session_id = $1::int
+GROUP BY s.id, s.threshold
+ON CONFLICT (session_id, scale_id) DO UPDATE
+SET score = EXCLUDED.score,
+    item_count = EXCLUDED.item_count,
+    flagged = EXCLUDED.flagged
+`
+
+// Count the items of every validity scale answered in a session with a keyed
+// answer, and flag the scales that reach their threshold
+func (q *Queries) ScoreValidityScales(ctx context.Context, sessionID int32) error {
+	_, err := q.db.Exec(ctx, scoreValidityScales, sessionID)
+	return err
+}
+
+const sessionHasAccommodation = `-- name: SessionHasAccommodation :one
+SELECT EXISTS (
+  SELECT 1 FROM session_accommodations WHERE session_id = $1
+)
+`
+
+func (q *Queries) SessionHasAccommodation(ctx context.Context, sessionID int32) (bool, error) {
+	row := q.db.QueryRow(ctx, sessionHasAccommodation, sessionID)
+	var exists bool
+	err := row.Scan(&exists)
+	return exists, err
+}
+
+const setAnswerScores = `-- name: SetAnswerScores :exec
+UPDATE user_answers
+SET answer_value = jsonb_set(answer_value, '{scores}', $1::jsonb)
+WHERE id = $2
+`
+
+type SetAnswerScoresParams struct {
+	Scores []byte
+	ID     int32
+}
+
+// Store the final category points on a graded answer so score calculation picks them up
+func (q *Queries) SetAnswerScores(ctx context.Context, arg SetAnswerScoresParams) error {
+	_, err := q.db.Exec(ctx, setAnswerScores, arg.Scores, arg.ID)
+	return err
+}
+
+const setGradingItemStatus = `-- name: SetGradingItemStatus :exec
+UPDATE grading_items
+SET status = $2
+WHERE id = $1
+`
+
+type SetGradingItemStatusParams struct {
+	ID     int32
+	Status GradingStatus
+}
+
+func (q *Queries) SetGradingItemStatus(ctx context.Context, arg SetGradingItemStatusParams) error {
+	_, err := q.db.Exec(ctx, setGradingItemStatus, arg.ID, arg.Status)
+	return err
+}
+
+const setSessionTheta = `-- name: SetSessionTheta :exec
+UPDATE user_assessment_scores
+SET theta = $2, theta_se = $3
+WHERE session_id = $1
+`
+
+type SetSessionThetaParams struct {
+	SessionID pgtype.Int4
+	Theta     pgtype.Float8
+	ThetaSe   pgtype.Float8
+}
+
+// Store the final ability estimate alongside every category score of a session
+func (q *Queries) SetSessionTheta(ctx context.Context, arg SetSessionThetaParams) error {
+	_, err := q.db.Exec(ctx, setSessionTheta, arg.SessionID, arg.Theta, arg.ThetaSe)
+	return err
+}
+
+const setUserLocale = `-- name: SetUserLocale :exec
+INSERT INTO user_preferences (user_id, locale)
+VALUES ($1, $2)
+ON CONFLICT (user_id) DO UPDATE
+SET locale = EXCLUDED.locale
+`
+
+type SetUserLocaleParams struct {
+	UserID int32
+	Locale pgtype.Text
+}
+
+func (q *Queries) SetUserLocale(ctx context.Context, arg SetUserLocaleParams) error {
+	_, err := q.db.Exec(ctx, setUserLocale, arg.UserID, arg.Locale)
+	return err
+}
+
+const submitGrade = `-- name: SubmitGrade :one
+UPDATE grading_assignments
+SET criterion_scores = $3, comment = $4, submitted_at = CURRENT_TIMESTAMP
+WHERE item_id = $1 AND grader_id = $2 AND submitted_at IS NULL
+RETURNING id, item_id, grader_id, resolver, criterion_scores, comment, assigned_at, submitted_at
+`
+
+type SubmitGradeParams struct {
+	ItemID          int32
+	GraderID        int32
+	CriterionScores []byte
+	Comment         pgtype.Text
+}
+
+func (q *Queries) SubmitGrade(ctx context.Context, arg SubmitGradeParams) (GradingAssignment, error) {
+	row := q.db.QueryRow(ctx, submitGrade,
+		arg.ItemID,
+		arg.GraderID,
+		arg.CriterionScores,
+		arg.Comment,
+	)
+	var i GradingAssignment
+	err := row.Scan(
+		&i.ID,
+		&i.ItemID,
+		&i.GraderID,
+		&i.Resolver,
+		&i.CriterionScores,
+		&i.Comment,
+		&i.AssignedAt,
+		&i.SubmittedAt,
+	)
+	return i, err
+}
+
+const updateAdaptiveEstimate = `-- name: UpdateAdaptiveEstimate :exec
+UPDATE adaptive_sessions
+SET theta = $2, standard_error = $3
+WHERE session_id = $1
+`
+
+type UpdateAdaptiveEstimateParams struct {
+	SessionID     int32
+	Theta         float64
+	StandardError float64
+}
+
+func (q *Queries) UpdateAdaptiveEstimate(ctx context.Context, arg UpdateAdaptiveEstimateParams) error {
+	_, err := q.db.Exec(ctx, updateAdaptiveEstimate, arg.SessionID, arg.Theta, arg.StandardError)
+	return err
+}
+
+const updateSessionQualityFlags = `-- name: UpdateSessionQualityFlags :exec
+UPDATE user_assessment_sessions
+SET quality_flags = $2
+WHERE id = $1
+`
+
+type UpdateSessionQualityFlagsParams struct {
+	ID           int32
+	QualityFlags []byte
+}
+
+func (q *Queries) UpdateSessionQualityFlags(ctx context.Context, arg UpdateSessionQualityFlagsParams) error {
+	_, err := q.db.Exec(ctx, updateSessionQualityFlags, arg.ID, arg.QualityFlags)
+	return err
+}
+
+const upsertCategoryTranslation = `-- name: UpsertCategoryTranslation :one
+INSERT INTO category_translations (category_id, locale, description)
+VALUES ($1, $2, $3)
+ON CONFLICT (category_id, locale) DO UPDATE
+SET description = EXCLUDED.description,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING category_id, locale, description, updated_at
+`
+
+type UpsertCategoryTranslationParams struct {
+	CategoryID  int32
+	Locale      string
+	Description string
+}
+
+func (q *Queries) UpsertCategoryTranslation(ctx context.Context, arg UpsertCategoryTranslationParams) (CategoryTranslation, error) {
+	row := q.db.QueryRow(ctx, upsertCategoryTranslation, arg.CategoryID, arg.Locale, arg.Description)
+	var i CategoryTranslation
+	err := row.Scan(
+		&i.CategoryID,
+		&i.Locale,
+		&i.Description,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertItemParameters = `-- name: UpsertItemParameters :one
+INSERT INTO self_assessment_item_parameters(
+    question_id,
+    discrimination,
+    difficulty,
+    updated_at
+)VALUES(
+    $1,
+    $2,
+    $3,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (question_id)
+DO UPDATE SET
+    discrimination = EXCLUDED.discrimination,
+    difficulty = EXCLUDED.difficulty,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING question_id, discrimination, difficulty, updated_at
+`
+
+type UpsertItemParametersParams struct {
+	QuestionID     int32
+	Discrimination float64
+	Difficulty     float64
+}
+
+func (q *Queries) UpsertItemParameters(ctx context.Context, arg UpsertItemParametersParams) (SelfAssessmentItemParameter, error) {
+	row := q.db.QueryRow(ctx, upsertItemParameters, arg.QuestionID, arg.Discrimination, arg.Difficulty)
+	var i SelfAssessmentItemParameter
+	err := row.Scan(
+		&i.QuestionID,
+		&i.Discrimination,
+		&i.Difficulty,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertQuestionTranslation = `-- name: UpsertQuestionTranslation :one
+INSERT INTO question_translations (question_id, locale, question, options)
+VALUES ($1, $2, $3, $4)
+ON CONFLICT (question_id, locale) DO UPDATE
+SET question = EXCLUDED.question,
+    options = EXCLUDED.options,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING question_id, locale, question, options, updated_at
+`
+
+type UpsertQuestionTranslationParams struct {
+	QuestionID int32
+	Locale     string
+	Question   string
+	Options    []byte
+}
+
+func (q *Queries) UpsertQuestionTranslation(ctx context.Context, arg UpsertQuestionTranslationParams) (QuestionTranslation, error) {
+	row := q.db.QueryRow(ctx, upsertQuestionTranslation,
+		arg.QuestionID,
+		arg.Locale,
+		arg.Question,
+		arg.Options,
+	)
+	var i QuestionTranslation
+	err := row.Scan(
+		&i.QuestionID,
+		&i.Locale,
+		&i.Question,
+		&i.Options,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertRubric = `-- name: UpsertRubric :one
+INSERT INTO self_assessment_rubrics(
+    question_id,
+    criteria,
+    double_marked,
+    max_disagreement,
+    updated_at
+)VALUES(
+    $1,
+    $2,
+    $3,
+    $4,
+    CURRENT_TIMESTAMP
+)
+ON CONFLICT (question_id)
+DO UPDATE SET
+    criteria = EXCLUDED.criteria,
+    double_marked = EXCLUDED.double_marked,
+    max_disagreement = EXCLUDED.max_disagreement,
+    updated_at = CURRENT_TIMESTAMP
+RETURNING question_id, criteria, double_marked, max_disagreement, updated_at
+`
+
+type UpsertRubricParams struct {
+	QuestionID      int32
+	Criteria        []byte
+	DoubleMarked    bool
+	MaxDisagreement int32
+}
+
+func (q *Queries) UpsertRubric(ctx context.Context, arg UpsertRubricParams) (SelfAssessmentRubric, error) {
+	row := q.db.QueryRow(ctx, upsertRubric,
+		arg.QuestionID,
+		arg.Criteria,
+		arg.DoubleMarked,
+		arg.MaxDisagreement,
+	)
+	var i SelfAssessmentRubric
+	err := row.Scan(
+		&i.QuestionID,
+		&i.Criteria,
+		&i.DoubleMarked,
+		&i.MaxDisagreement,
+		&i.UpdatedAt,
+	)
+	return i, err
+}
+
+const upsertTraitItem = `-- name: UpsertTraitItem :one
+INSERT INTO trait_items(
+    question_id,
+    facet_id,
+    reverse_keyed
+)VALUES(
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (question_id) DO UPDATE
+SET facet_id = EXCLUDED.facet_id,
+    reverse_keyed = EXCLUDED.reverse_keyed
+RETURNING question_id, facet_id, reverse_keyed
+`
+
+type UpsertTraitItemParams struct {
+	QuestionID   int32
+	FacetID      int32
+	ReverseKeyed bool
+}
+
+func (q *Queries) UpsertTraitItem(ctx context.Context, arg UpsertTraitItemParams) (TraitItem, error) {
+	row := q.db.QueryRow(ctx, upsertTraitItem, arg.QuestionID, arg.FacetID, arg.ReverseKeyed)
+	var i TraitItem
+	err := row.Scan(
+		&i.QuestionID,
+		&i.FacetID,
+		&i.ReverseKeyed,
+	)
+	return i, err
+}
+
+const upsertValidityItem = `-- name: UpsertValidityItem :one
+INSERT INTO validity_items(
+    question_id,
+    scale_id,
+    keyed_answers
+)VALUES(
+    $1,
+    $2,
+    $3
+)
+ON CONFLICT (question_id) DO UPDATE
+SET scale_id = EXCLUDED.scale_id,
+    keyed_answers = EXCLUDED.keyed_answers
+RETURNING question_id, scale_id, keyed_answers
+`
+
+type UpsertValidityItemParams struct {
+	QuestionID   int32
+	ScaleID      int32
+	KeyedAnswers []string
+}
+
+func (q *Queries) UpsertValidityItem(ctx context.Context, arg UpsertValidityItemParams) (ValidityItem, error) {
+	row := q.db.QueryRow(ctx, upsertValidityItem, arg.QuestionID, arg.ScaleID, arg.KeyedAnswers)
+	var i ValidityItem
+	err := row.Scan(
+		&i.QuestionID,
+		&i.ScaleID,
+		&i.KeyedAnswers,
+	)
+	return i, err
+}
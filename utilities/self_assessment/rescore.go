@@ -0,0 +1,119 @@
+package self_assessment
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"log"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+// RescoreJobKind is the background job that recalculates a completed
+// session's scores after the scoring of one of its questions changed.
+const RescoreJobKind = "scores.recalculate"
+
+// RescoreJob is the payload of a RescoreJobKind job.
+type RescoreJob struct {
+	SessionID int32 `json:"session_id"`
+}
+
+// RecalculateScores runs a RescoreJobKind job. Typed answers are scored
+// again against the current mappings, then the session's scores are
+// replaced. Adaptive sessions are scored from item parameters as they are
+// answered, so they are left alone.
+func (h *SelfAssessmentHandler) RecalculateScores(ctx context.Context, job RescoreJob) error {
+	tx, err := h.db.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback(ctx)
+	qtx := h.queries.WithTx(tx)
+
+	session, err := qtx.LockAssessmentSession(ctx, job.SessionID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !session.CompletedAt.Valid {
+		return nil
+	}
+	if _, err := qtx.GetAdaptiveSession(ctx, session.ID); err == nil {
+		return nil
+	} else if !errors.Is(err, pgx.ErrNoRows) {
+		return err
+	}
+
+	if err := rescoreTypedAnswers(ctx, qtx, session.ID); err != nil {
+		return err
+	}
+	if err := qtx.DeleteSessionScores(ctx, pgtype.Int4{Int32: session.ID, Valid: true}); err != nil {
+		return err
+	}
+	if err := calculateScores(ctx, qtx, session.ID, session.AssessmentType); err != nil {
+		return err
+	}
+	return tx.Commit(ctx)
+}
+
+// rescoreTypedAnswers works out the points of a session's typed answers
+// again from the stored responses. An answer that no longer fits its
+// question, because the options changed, keeps the points it had.
+func rescoreTypedAnswers(ctx context.Context, q *Queries, sessionID int32) error {
+	answers, err := q.ListRescorableAnswers(ctx, pgtype.Int4{Int32: sessionID, Valid: true})
+	if err != nil || len(answers) == 0 {
+		return err
+	}
+	questionIDs := make([]int32, 0, len(answers))
+	for _, answer := range answers {
+		questionIDs = append(questionIDs, answer.QuestionID.Int32)
+	}
+	questions, err := q.GetQuestionsByIDs(ctx, questionIDs)
+	if err != nil {
+		return err
+	}
+	mappings, err := q.ListMappingsByQuestionIDs(ctx, questionIDs)
+	if err != nil {
+		return err
+	}
+	questionByID := make(map[int32]SelfAssessmentQuestion, len(questions))
+	for _, question := range questions {
+		questionByID[question.ID] = question
+	}
+	mappingsByQuestion := make(map[int32][]SelfAssessmentMapping)
+	for _, mapping := range mappings {
+		mappingsByQuestion[mapping.QuestionID] = append(mappingsByQuestion[mapping.QuestionID], mapping)
+	}
+
+	for _, answer := range answers {
+		question, ok := questionByID[answer.QuestionID.Int32]
+		if !ok {
+			continue
+		}
+		var stored typedAnswer
+		if err := json.Unmarshal(answer.AnswerValue, &stored); err != nil {
+			return err
+		}
+		rescored, err := scoreTypedAnswer(question, mappingsByQuestion[question.ID], TypedResponse{
+			Choices: stored.Choices,
+			Order:   stored.Order,
+			Value:   stored.Value,
+			Text:    stored.Text,
+		})
+		if err != nil {
+			log.Printf("self_assessment: rescoring answer %d: %v", answer.ID, err)
+			continue
+		}
+		scores, err := json.Marshal(rescored.Scores)
+		if err != nil {
+			return err
+		}
+		if err := q.SetAnswerScores(ctx, SetAnswerScoresParams{Scores: scores, ID: answer.ID}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
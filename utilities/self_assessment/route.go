@@ -11,15 +11,55 @@ func SetupRoutesSelfAssessment(r *gin.Engine, selfAssessmentHandler *SelfAssessm
 	auth.Use(middleware.AuthMiddleware())
 	auth.POST("/category", selfAssessmentHandler.InsertCategory)
 	auth.POST("/question", selfAssessmentHandler.InsertQuestion)
-	auth.GET("/question/behavioral", selfAssessmentHandler.GetSelfAssessmentBehavioral)
-	auth.GET("/question/personality", selfAssessmentHandler.GetSelfAssessmentPersonality)
-	auth.GET("/question/cognitive", selfAssessmentHandler.GetSelfAssessmentCognitive)
 	auth.GET("/status", selfAssessmentHandler.GetUserAssessmentStatus)
 	auth.GET("/candidate/scores", selfAssessmentHandler.GetCandidateScores)
+	auth.GET("/locales", selfAssessmentHandler.ListLocales)
+	auth.PUT("/locale", selfAssessmentHandler.SetPreferredLocale)
+
+	// Start Assessment
+	auth.POST("/start/:type", selfAssessmentHandler.StartAssessment)
+
+	// Adaptive Assessment
+	auth.POST("/adaptive/start", selfAssessmentHandler.StartAdaptiveAssessment)
+	auth.POST("/adaptive/:session_id/answer", selfAssessmentHandler.AnswerAdaptiveItem)
 
 	// Submit Assessment
+	auth.PUT("/sessions/:session_id/questions/:question_id/answered", selfAssessmentHandler.RecordAnswerTime)
 	auth.POST("/submit/:type", selfAssessmentHandler.SubmitAssessment)
 
 	// Candidate Details
 	auth.POST("/candidate/details", selfAssessmentHandler.GetCandidateAssessmentDetails)
+
+	admin := r.Group("self-assessment")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.POST("/blueprints", selfAssessmentHandler.CreateBlueprint)
+	admin.GET("/blueprints", selfAssessmentHandler.ListBlueprints)
+	admin.GET("/blueprints/:id", selfAssessmentHandler.GetBlueprint)
+	admin.PUT("/items/:id/parameters", selfAssessmentHandler.SetItemParameters)
+	admin.POST("/item-pairs", selfAssessmentHandler.CreateItemPair)
+	admin.GET("/item-pairs", selfAssessmentHandler.ListItemPairs)
+
+	// Scoring
+	admin.PUT("/questions/:id/mappings", selfAssessmentHandler.SetMappings)
+
+	// Trait Models
+	admin.GET("/trait-models", selfAssessmentHandler.ListTraitModels)
+	admin.PUT("/questions/:id/trait-item", selfAssessmentHandler.SetTraitItem)
+
+	// Validity Scales
+	admin.POST("/validity-scales", selfAssessmentHandler.CreateValidityScale)
+	admin.GET("/validity-scales", selfAssessmentHandler.ListValidityScales)
+	admin.PUT("/questions/:id/validity-item", selfAssessmentHandler.SetValidityItem)
+
+	// Translations
+	admin.GET("/questions/:id/translations", selfAssessmentHandler.GetQuestionTranslations)
+	admin.PUT("/questions/:id/translations/:locale", selfAssessmentHandler.SetQuestionTranslation)
+	admin.PUT("/categories/:id/translations/:locale", selfAssessmentHandler.SetCategoryTranslation)
+
+	// Manual Grading
+	admin.PUT("/questions/:id/rubric", selfAssessmentHandler.SetRubric)
+	admin.GET("/grading/queue", selfAssessmentHandler.GetGradingQueue)
+	admin.GET("/grading/items/:id", selfAssessmentHandler.GetGradingItem)
+	admin.POST("/grading/items/:id/assignments", selfAssessmentHandler.AssignGrader)
+	admin.POST("/grading/items/:id/grades", selfAssessmentHandler.SubmitGrade)
 }
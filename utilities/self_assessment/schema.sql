@@ -6,13 +6,16 @@ CREATE TABLE IF NOT EXISTS self_assessment_categories(
 
 CREATE TYPE question_type as ENUM ('personality','cognitive','behavioral');
 
+CREATE TYPE question_format as ENUM ('single_choice','multi_select','ranking','numeric','free_text','open_ended','situational');
+
 CREATE TABLE IF NOT EXISTS self_assessment_questions (
     id SERIAL PRIMARY KEY,
     question text not null,
     type question_type not null,
     options JSONB NOT NULL DEFAULT '{}'::jsonb,
     correct_answer VARCHAR(255) null,
-    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    format question_format NOT NULL DEFAULT 'single_choice'
 );
 
 CREATE TABLE IF NOT EXISTS self_assessment_mappings (
@@ -22,7 +25,8 @@ CREATE TABLE IF NOT EXISTS self_assessment_mappings (
     category_id int not null,
     points int,
     constraint fk_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete SET NULL,
-    constraint fk_category foreign key (category_id) REFERENCES self_assessment_categories(id) on delete SET NULL
+    constraint fk_category foreign key (category_id) REFERENCES self_assessment_categories(id) on delete SET NULL,
+    constraint uq_mapping_option_category unique (question_id, answer_value, category_id)
 );
 
 CREATE TABLE IF NOT EXISTS user_assessment_sessions(
@@ -30,7 +34,10 @@ CREATE TABLE IF NOT EXISTS user_assessment_sessions(
     user_id int not null,
     assessment_type varchar(50) not null,
     started_at timestamp DEFAULT CURRENT_TIMESTAMP,
-    completed_at timestamp null
+    completed_at timestamp null,
+    blueprint_id int null,
+    quality_flags JSONB NOT NULL DEFAULT '[]'::jsonb,
+    submitted_at timestamp null
 );
 
 CREATE TABLE IF NOT EXISTS user_answers(
@@ -39,6 +46,7 @@ CREATE TABLE IF NOT EXISTS user_answers(
      session_id int, 
      question_id int,
      answer_value JSONB not null,
+     answered_at timestamp null,
      constraint fk_user_answer foreign key (user_id) REFERENCES users(id) on delete SET NULL,
      constraint fk_session_answer foreign key (session_id) REFERENCES user_assessment_session(id) on delete SET NULL,
      constraint fk_question_answer foreign key (question_id) REFERENCES self_assessment_questions(id) on delete SET NULL
@@ -50,6 +58,8 @@ CREATE TABLE IF NOT EXISTS user_assessment_scores(
     session_id int, 
     category_id int,
     score int,
+    theta double precision null,
+    theta_se double precision null,
     constraint fk_user_score foreign key (user_id) REFERENCES users(id) on delete SET NULL,
     constraint fk_session_score foreign key (session_id) REFERENCES user_assessment_session(id) on delete SET NULL,
     constraint fk_category_score foreign key (category_id) REFERENCES self_assessment_categories(id) on delete SET NULL
@@ -63,4 +73,264 @@ CREATE TABLE IF NOT EXISTS users(
     password varchar(100) NOT NULL,
     created_at timestamp default now(),
     constraint fk_role foreign key (role_id) REFERENCES roles(id) on delete SET NULL
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS assessment_blueprints(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) not null,
+    assessment_type varchar(50) not null,
+    shuffle_options boolean not null DEFAULT true,
+    max_item_exposures int null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    time_limit_minutes int null
+);
+
+CREATE TABLE IF NOT EXISTS assessment_blueprint_sections(
+    id SERIAL PRIMARY KEY,
+    blueprint_id int not null,
+    category_id int not null,
+    question_count int not null,
+    constraint fk_blueprint_section foreign key (blueprint_id) REFERENCES assessment_blueprints(id) on delete CASCADE,
+    constraint fk_category_section foreign key (category_id) REFERENCES self_assessment_categories(id) on delete CASCADE,
+    constraint uq_blueprint_section unique (blueprint_id, category_id)
+);
+
+CREATE TABLE IF NOT EXISTS user_session_questions(
+    session_id int not null,
+    question_id int not null,
+    position int not null,
+    option_order JSONB NOT NULL DEFAULT '[]'::jsonb,
+    answered_at timestamp null,
+    PRIMARY KEY (session_id, question_id)
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_item_parameters(
+    question_id int PRIMARY KEY,
+    discrimination double precision not null DEFAULT 1,
+    difficulty double precision not null DEFAULT 0,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS adaptive_sessions(
+    session_id int PRIMARY KEY,
+    target_se double precision not null,
+    max_items int not null,
+    theta double precision not null DEFAULT 0,
+    standard_error double precision not null DEFAULT 1
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_item_pairs(
+    id SERIAL PRIMARY KEY,
+    question_id int not null,
+    paired_question_id int not null,
+    reversed boolean not null DEFAULT false
+);
+
+CREATE TABLE IF NOT EXISTS roles(
+    id SERIAL PRIMARY KEY,
+    name varchar(100) NOT NULL,
+    created_at timestamp default now()
+);
+
+CREATE TABLE IF NOT EXISTS self_assessment_rubrics(
+    question_id int PRIMARY KEY,
+    criteria JSONB NOT NULL DEFAULT '[]'::jsonb,
+    double_marked boolean not null DEFAULT false,
+    max_disagreement int not null DEFAULT 0,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TYPE grading_status as ENUM ('pending','disputed','graded');
+
+CREATE TABLE IF NOT EXISTS grading_items(
+    id SERIAL PRIMARY KEY,
+    answer_id int not null UNIQUE,
+    session_id int not null,
+    question_id int not null,
+    required_grades int not null DEFAULT 1,
+    status grading_status not null DEFAULT 'pending',
+    final_scores JSONB null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    graded_at timestamp null
+);
+
+CREATE TABLE IF NOT EXISTS grading_assignments(
+    id SERIAL PRIMARY KEY,
+    item_id int not null,
+    grader_id int not null,
+    resolver boolean not null DEFAULT false,
+    criterion_scores JSONB null,
+    comment text null,
+    assigned_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    submitted_at timestamp null,
+    constraint uq_grading_assignment unique (item_id, grader_id)
+);
+
+CREATE TABLE IF NOT EXISTS trait_models(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) not null UNIQUE,
+    source text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS trait_facets(
+    id SERIAL PRIMARY KEY,
+    model_id int not null,
+    code varchar(10) not null,
+    trait_category_id int not null,
+    facet_category_id int not null,
+    position int not null DEFAULT 0,
+    constraint fk_trait_facet_model foreign key (model_id) REFERENCES trait_models(id) on delete CASCADE,
+    constraint fk_trait_facet_trait foreign key (trait_category_id) REFERENCES self_assessment_categories(id) on delete CASCADE,
+    constraint fk_trait_facet_facet foreign key (facet_category_id) REFERENCES self_assessment_categories(id) on delete CASCADE,
+    constraint uq_trait_facet_code unique (model_id, code),
+    constraint uq_trait_facet_category unique (model_id, facet_category_id)
+);
+
+CREATE TABLE IF NOT EXISTS trait_items(
+    question_id int PRIMARY KEY,
+    facet_id int not null,
+    reverse_keyed boolean not null DEFAULT false,
+    constraint fk_trait_item_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE,
+    constraint fk_trait_item_facet foreign key (facet_id) REFERENCES trait_facets(id) on delete CASCADE
+);
+
+CREATE TYPE validity_scale_kind as ENUM ('lie','infrequency');
+
+CREATE TABLE IF NOT EXISTS validity_scales(
+    id SERIAL PRIMARY KEY,
+    name varchar(255) not null UNIQUE,
+    kind validity_scale_kind not null,
+    assessment_type varchar(50) not null,
+    threshold int not null,
+    description text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    constraint ck_validity_scale_threshold check (threshold > 0)
+);
+
+CREATE TABLE IF NOT EXISTS validity_items(
+    question_id int PRIMARY KEY,
+    scale_id int not null,
+    keyed_answers text[] not null,
+    constraint fk_validity_item_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE,
+    constraint fk_validity_item_scale foreign key (scale_id) REFERENCES validity_scales(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS session_validity_scores(
+    session_id int not null,
+    scale_id int not null,
+    score int not null,
+    item_count int not null,
+    flagged boolean not null,
+    PRIMARY KEY (session_id, scale_id),
+    constraint fk_session_validity_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_session_validity_scale foreign key (scale_id) REFERENCES validity_scales(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS question_translations(
+    question_id int not null,
+    locale varchar(35) not null,
+    question text not null,
+    options JSONB NOT NULL DEFAULT '{}'::jsonb,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (question_id, locale),
+    constraint fk_question_translation_question foreign key (question_id) REFERENCES self_assessment_questions(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS category_translations(
+    category_id int not null,
+    locale varchar(35) not null,
+    description text not null,
+    updated_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (category_id, locale),
+    constraint fk_category_translation_category foreign key (category_id) REFERENCES self_assessment_categories(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS user_preferences(
+    user_id int PRIMARY KEY,
+    locale varchar(35) null,
+    email_opt_out boolean not null DEFAULT false,
+    constraint fk_user_preferences_user foreign key (user_id) REFERENCES users(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS candidate_accommodations(
+    id SERIAL PRIMARY KEY,
+    user_id int not null,
+    session_id int null,
+    time_multiplier double precision not null DEFAULT 1,
+    break_minutes int not null DEFAULT 0,
+    alternative_formats text[] not null DEFAULT '{}',
+    reason text not null,
+    approved_by int not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    revoked_at timestamp null,
+    revoked_by int null,
+    constraint fk_accommodation_user foreign key (user_id) REFERENCES users(id) on delete CASCADE,
+    constraint fk_accommodation_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_accommodation_approver foreign key (approved_by) REFERENCES users(id),
+    constraint fk_accommodation_revoker foreign key (revoked_by) REFERENCES users(id)
+);
+
+CREATE TABLE IF NOT EXISTS session_accommodations(
+    session_id int PRIMARY KEY,
+    accommodation_id int not null,
+    constraint fk_session_accommodation_session foreign key (session_id) REFERENCES user_assessment_sessions(id) on delete CASCADE,
+    constraint fk_session_accommodation_accommodation foreign key (accommodation_id) REFERENCES candidate_accommodations(id) on delete CASCADE
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events(
+    id BIGSERIAL PRIMARY KEY,
+    event_type varchar(50) not null,
+    dedup_key varchar(255) not null UNIQUE,
+    payload JSONB not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    available_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    attempts int not null DEFAULT 0,
+    last_error text null,
+    dispatched_at timestamp null
+);
+
+CREATE TYPE background_job_status as ENUM ('queued','running','succeeded','failed');
+
+CREATE TABLE IF NOT EXISTS background_jobs(
+    id BIGSERIAL PRIMARY KEY,
+    kind varchar(100) not null,
+    payload JSONB not null,
+    status background_job_status not null DEFAULT 'queued',
+    attempts int not null DEFAULT 0,
+    max_attempts int not null DEFAULT 5,
+    run_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    locked_until timestamp null,
+    last_error text null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    started_at timestamp null,
+    finished_at timestamp null,
+    constraint ck_background_job_max_attempts check (max_attempts > 0)
+);
+
+CREATE VIEW candidate_listing AS
+WITH candidate_behavioral_scores AS (
+SELECT
+ uas.user_id,
+ uas.session_id,
+ sac.name AS category_name,
+ uas.score,
+ RANK() OVER (PARTITION BY uas.user_id ORDER BY uas.score DESC) as score_rank
+FROM
+ user_assessment_scores uas
+JOIN
+ self_assessment_categories sac ON uas.category_id = sac.id
+JOIN
+ user_assessment_sessions sessions ON uas.session_id = sessions.id
+WHERE
+ sessions.assessment_type = 'behavioral'
+)
+SELECT
+ user_id,
+ session_id,
+ category_name AS top_behavioral_trait,
+ score AS top_behavioral_score
+FROM
+ candidate_behavioral_scores
+WHERE
+ score_rank = 1;
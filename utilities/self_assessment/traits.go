@@ -0,0 +1,95 @@
+package self_assessment
+
+import (
+	"encoding/json"
+	"errors"
+	"sort"
+	"strconv"
+)
+
+// FacetScore is a session's subscore on one facet of a trait.
+type FacetScore struct {
+	CategoryID int32  `json:"category_id"`
+	Code       string `json:"code"`
+	Name       string `json:"name"`
+	Score      int32  `json:"score"`
+}
+
+// TraitScore is a session's score on a trait of a trait model, with the
+// facet subscores it is made of.
+type TraitScore struct {
+	CategoryID int32        `json:"category_id"`
+	Name       string       `json:"name"`
+	Score      int32        `json:"score"`
+	Facets     []FacetScore `json:"facets"`
+}
+
+// keyedPoints works out the points each option of a Likert item earns. The
+// options must be numbered; a reverse-keyed item turns the scale around so
+// its lowest option earns the most.
+func keyedPoints(options []byte, reverse bool) (map[int32]int32, error) {
+	var parsed map[string]json.RawMessage
+	if err := json.Unmarshal(options, &parsed); err != nil {
+		return nil, errors.New("question has invalid options")
+	}
+	if len(parsed) < 2 {
+		return nil, errors.New("trait items need a scale of at least two options")
+	}
+	keys := make([]int32, 0, len(parsed))
+	for key := range parsed {
+		n, err := strconv.Atoi(key)
+		if err != nil {
+			return nil, errors.New("trait items need numbered options")
+		}
+		keys = append(keys, int32(n))
+	}
+	sort.Slice(keys, func(i, j int) bool { return keys[i] < keys[j] })
+
+	low, high := keys[0], keys[len(keys)-1]
+	points := make(map[int32]int32, len(keys))
+	for _, key := range keys {
+		if reverse {
+			points[key] = low + high - key
+		} else {
+			points[key] = key
+		}
+	}
+	return points, nil
+}
+
+// traitProfile groups a session's scores by trait, with facets in model
+// order. Only traits the session was scored on are included.
+func traitProfile(facets []ListTraitFacetsRow, scores []GetSessionScoresRow) []TraitScore {
+	byCategory := make(map[int32]int32, len(scores))
+	for _, score := range scores {
+		if score.CategoryID.Valid {
+			byCategory[score.CategoryID.Int32] = score.Score.Int32
+		}
+	}
+
+	profile := []TraitScore{}
+	index := make(map[int32]int)
+	for _, facet := range facets {
+		facetScore, ok := byCategory[facet.FacetCategoryID]
+		if !ok {
+			continue
+		}
+		i, seen := index[facet.TraitCategoryID]
+		if !seen {
+			i = len(profile)
+			index[facet.TraitCategoryID] = i
+			profile = append(profile, TraitScore{
+				CategoryID: facet.TraitCategoryID,
+				Name:       facet.TraitName.String,
+				Score:      byCategory[facet.TraitCategoryID],
+			})
+		}
+		profile[i].Facets = append(profile[i].Facets, FacetScore{
+			CategoryID: facet.FacetCategoryID,
+			Code:       facet.Code,
+			Name:       facet.FacetName.String,
+			Score:      facetScore,
+		})
+	}
+	return profile
+}
@@ -0,0 +1,49 @@
+package self_assessment
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestKeyedPoints(t *testing.T) {
+	likert := `{"1":"Very inaccurate","2":"","3":"","4":"","5":"Very accurate"}`
+	tests := []struct {
+		name    string
+		options string
+		reverse bool
+		want    map[int32]int32
+		wantErr bool
+	}{
+		{
+			name:    "keyed",
+			options: likert,
+			want:    map[int32]int32{1: 1, 2: 2, 3: 3, 4: 4, 5: 5},
+		},
+		{
+			name:    "reverse keyed",
+			options: likert,
+			reverse: true,
+			want:    map[int32]int32{1: 5, 2: 4, 3: 3, 4: 2, 5: 1},
+		},
+		{
+			name:    "reverse keyed on a scale not starting at one",
+			options: `{"0":"","1":"","2":"","3":""}`,
+			reverse: true,
+			want:    map[int32]int32{0: 3, 1: 2, 2: 1, 3: 0},
+		},
+		{name: "single option", options: `{"1":""}`, wantErr: true},
+		{name: "unnumbered options", options: `{"a":"","b":""}`, wantErr: true},
+		{name: "invalid options", options: `not json`, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := keyedPoints([]byte(tt.options), tt.reverse)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("keyedPoints error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("keyedPoints = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
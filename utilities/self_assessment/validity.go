@@ -0,0 +1,47 @@
+package self_assessment
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// interpretWithCaution reports whether any validity scale of a session
+// reached its threshold, so its scores may not reflect the candidate.
+func interpretWithCaution(scores []ListSessionValidityScoresRow) bool {
+	for _, score := range scores {
+		if score.Flagged {
+			return true
+		}
+	}
+	return false
+}
+
+// validateKeyedAnswers checks that the keyed answers of a validity item are
+// distinct options of the question.
+func validateKeyedAnswers(question SelfAssessmentQuestion, keyed []string) error {
+	if question.Format != QuestionFormatSingleChoice {
+		return errors.New("validity items must be single-choice questions")
+	}
+	if len(keyed) == 0 {
+		return errors.New("at least one keyed answer is required")
+	}
+	var options map[string]json.RawMessage
+	if err := json.Unmarshal(question.Options, &options); err != nil {
+		return errors.New("question has invalid options")
+	}
+	if len(keyed) >= len(options) {
+		return errors.New("not every option can be a keyed answer")
+	}
+	seen := make(map[string]bool)
+	for _, key := range keyed {
+		if _, ok := options[key]; !ok {
+			return fmt.Errorf("keyed answer %q is not one of the options", key)
+		}
+		if seen[key] {
+			return fmt.Errorf("keyed answer %q is listed twice", key)
+		}
+		seen[key] = true
+	}
+	return nil
+}
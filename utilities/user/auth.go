@@ -9,7 +9,6 @@ type SignupRequest struct {
 	Email    string `json:"email" binding:"required,email"`
 	Password string `json:"password" binding:"required,min=8"`
 	Name     string `json:"name" binding:"required"`
-	RoleID   int    `json:"role_id"`
 }
 
 type AuthResponse struct {
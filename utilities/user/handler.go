@@ -1,7 +1,9 @@
 package users
 
 import (
+	"backend/pkg/events"
 	"context"
+	"encoding/json"
 	"net/http"
 	"strconv"
 	"time"
@@ -9,16 +11,23 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/golang-jwt/jwt/v4"
 	"github.com/jackc/pgx/v5/pgtype"
+	"github.com/jackc/pgx/v5/pgxpool"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// candidateRoleID is the role every sign-up gets. Admins are never created
+// through the public sign-up.
+const candidateRoleID = 2
+
 type AuthHandler struct {
+	db        *pgxpool.Pool
 	queries   *Queries
 	secretKey string
 }
 
-func NewAuthHandler(queries *Queries, secretKey string) *AuthHandler {
+func NewAuthHandler(db *pgxpool.Pool, queries *Queries, secretKey string) *AuthHandler {
 	return &AuthHandler{
+		db:        db,
 		queries:   queries,
 		secretKey: secretKey,
 	}
@@ -79,17 +88,45 @@ func (h *AuthHandler) Signup(c *gin.Context) {
 		return
 	}
 
-	if req.RoleID == 0 {
-		req.RoleID = 2
+	tx, err := h.db.Begin(context.Background())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to start transaction"})
+		return
 	}
+	defer tx.Rollback(context.Background())
+	qtx := h.queries.WithTx(tx)
 
-	user, err := h.queries.CreateUser(context.Background(), CreateUserParams{
+	user, err := qtx.CreateUser(context.Background(), CreateUserParams{
 		Email:    req.Email,
 		Password: string(hashedPassword),
 		Name:     req.Name,
-		RoleID:   pgtype.Int4{Int32: int32(req.RoleID), Valid: true},
+		RoleID:   pgtype.Int4{Int32: candidateRoleID, Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create user"})
+		return
+	}
+
+	payload, err := json.Marshal(events.CandidateCreatedData{
+		UserID: user.ID,
+		Name:   user.Name,
+		Email:  user.Email,
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record new candidate"})
+		return
+	}
+	err = qtx.EnqueueOutboxEvent(context.Background(), EnqueueOutboxEventParams{
+		EventType: events.CandidateCreated,
+		DedupKey:  events.DedupKey(events.CandidateCreated, user.ID),
+		Payload:   payload,
 	})
 	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to record new candidate"})
+		return
+	}
+
+	if err := tx.Commit(context.Background()); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": "failed to create user"})
 		return
 	}
@@ -8,6 +8,18 @@ import (
 	"github.com/jackc/pgx/v5/pgtype"
 )
 
+type OutboxEvent struct {
+	ID           int64
+	EventType    string
+	DedupKey     string
+	Payload      []byte
+	CreatedAt    pgtype.Timestamp
+	AvailableAt  pgtype.Timestamp
+	Attempts     int32
+	LastError    pgtype.Text
+	DispatchedAt pgtype.Timestamp
+}
+
 type User struct {
 	ID        int32
 	RoleID    pgtype.Int4
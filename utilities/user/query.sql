@@ -24,4 +24,10 @@ INSERT INTO users(
     $3,
     $4,
     CURRENT_TIMESTAMP
-) RETURNING *;
\ No newline at end of file
+) RETURNING *;
+
+-- name: EnqueueOutboxEvent :exec
+-- Record an event in the outbox; run it in the transaction making the change
+INSERT INTO outbox_events (event_type, dedup_key, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (dedup_key) DO NOTHING;
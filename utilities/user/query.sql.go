@@ -69,6 +69,24 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (User, e
 	return i, err
 }
 
+const enqueueOutboxEvent = `-- name: EnqueueOutboxEvent :exec
+INSERT INTO outbox_events (event_type, dedup_key, payload)
+VALUES ($1, $2, $3)
+ON CONFLICT (dedup_key) DO NOTHING
+`
+
+type EnqueueOutboxEventParams struct {
+	EventType string
+	DedupKey  string
+	Payload   []byte
+}
+
+// Record an event in the outbox; run it in the transaction making the change
+func (q *Queries) EnqueueOutboxEvent(ctx context.Context, arg EnqueueOutboxEventParams) error {
+	_, err := q.db.Exec(ctx, enqueueOutboxEvent, arg.EventType, arg.DedupKey, arg.Payload)
+	return err
+}
+
 const getUser = `-- name: GetUser :one
 SELECT id, role_id, name, email, password, created_at
 FROM users
@@ -6,4 +6,16 @@ CREATE TABLE IF NOT EXISTS users(
     password varchar(100) NOT NULL,
     created_at timestamp default now(),
     constraint fk_role foreign key (role_id) REFERENCES roles(id) on delete SET NULL
-);
\ No newline at end of file
+);
+
+CREATE TABLE IF NOT EXISTS outbox_events(
+    id BIGSERIAL PRIMARY KEY,
+    event_type varchar(50) not null,
+    dedup_key varchar(255) not null UNIQUE,
+    payload JSONB not null,
+    created_at timestamp DEFAULT CURRENT_TIMESTAMP,
+    available_at timestamp not null DEFAULT CURRENT_TIMESTAMP,
+    attempts int not null DEFAULT 0,
+    last_error text null,
+    dispatched_at timestamp null
+);
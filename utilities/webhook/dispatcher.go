@@ -0,0 +1,177 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"time"
+
+	"backend/pkg/backoff"
+	"backend/pkg/events"
+
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// pollInterval is how often due deliveries are looked for.
+	pollInterval = 5 * time.Second
+	// batchSize bounds how many deliveries one poll takes on.
+	batchSize = 20
+	// requestTimeout bounds a single request to a subscriber.
+	requestTimeout = 10 * time.Second
+	// leaseDuration is how long a claimed delivery is left alone before
+	// another worker may retry it. It must outlast a full batch of requests.
+	leaseDuration = batchSize * requestTimeout * 2
+	// maxAttempts is how many times a delivery is tried before it is marked
+	// failed and left for a replay.
+	maxAttempts = 10
+	// baseRetryDelay and maxRetryDelay bound the exponential backoff between
+	// attempts.
+	baseRetryDelay = 30 * time.Second
+	maxRetryDelay  = 6 * time.Hour
+)
+
+// Dispatcher queues events from the outbox for subscribers and delivers them
+// in the background, retrying failures with exponential backoff.
+type Dispatcher struct {
+	queries *Queries
+	client  *http.Client
+}
+
+func NewDispatcher(queries *Queries) *Dispatcher {
+	return &Dispatcher{
+		queries: queries,
+		client:  newClient(),
+	}
+}
+
+// envelope is the body of every delivery.
+type envelope struct {
+	ID        string          `json:"id"`
+	Type      string          `json:"type"`
+	CreatedAt time.Time       `json:"created_at"`
+	Data      json.RawMessage `json:"data"`
+}
+
+// Consume queues a committed event for every active subscription to its
+// type. The event ID comes from the dedup key, so an event handed over twice
+// is still delivered once per subscription.
+func (d *Dispatcher) Consume(ctx context.Context, event events.Event) error {
+	eventID := eventIDFor(event.DedupKey)
+	payload, err := json.Marshal(envelope{
+		ID:        eventID,
+		Type:      event.Type,
+		CreatedAt: event.CreatedAt.UTC(),
+		Data:      event.Payload,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = d.queries.CreateDeliveries(ctx, CreateDeliveriesParams{
+		EventID:   eventID,
+		EventType: event.Type,
+		Payload:   payload,
+	})
+	return err
+}
+
+// Run delivers due webhooks until the context is cancelled. Deliveries are
+// claimed with row locks, so several servers can run it side by side.
+func (d *Dispatcher) Run(ctx context.Context) {
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		// Keep going while there is a backlog rather than waiting a tick
+		// between every batch.
+		for {
+			n, err := d.deliverDue(ctx)
+			if err != nil {
+				log.Printf("webhook: delivering: %v", err)
+			}
+			if err != nil || n < batchSize || ctx.Err() != nil {
+				break
+			}
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// deliverDue sends one batch of due deliveries and reports how many it took.
+func (d *Dispatcher) deliverDue(ctx context.Context) (int, error) {
+	deliveries, err := d.queries.ClaimDueDeliveries(ctx, ClaimDueDeliveriesParams{
+		BatchSize:    batchSize,
+		LeaseSeconds: int32(leaseDuration.Seconds()),
+	})
+	if err != nil {
+		return 0, err
+	}
+	for _, delivery := range deliveries {
+		if err := d.attempt(ctx, delivery); err != nil {
+			log.Printf("webhook: recording delivery %d: %v", delivery.ID, err)
+		}
+	}
+	return len(deliveries), nil
+}
+
+// attempt makes one request for a delivery and records how it went.
+func (d *Dispatcher) attempt(ctx context.Context, delivery ClaimDueDeliveriesRow) error {
+	started := time.Now()
+	statusCode, sendErr := d.send(ctx, delivery)
+
+	attempt := RecordDeliveryAttemptParams{
+		DeliveryID: delivery.ID,
+		DurationMs: int32(time.Since(started).Milliseconds()),
+	}
+	if statusCode != 0 {
+		attempt.StatusCode = pgtype.Int4{Int32: int32(statusCode), Valid: true}
+	}
+	if sendErr != nil {
+		attempt.Error = pgtype.Text{String: describeFailure(statusCode, sendErr), Valid: true}
+	}
+	if err := d.queries.RecordDeliveryAttempt(ctx, attempt); err != nil {
+		return err
+	}
+
+	if sendErr == nil {
+		return d.queries.MarkDeliverySucceeded(ctx, delivery.ID)
+	}
+	attempts := int(delivery.Attempts) + 1
+	return d.queries.MarkDeliveryFailed(ctx, MarkDeliveryFailedParams{
+		GiveUp:            attempts >= maxAttempts,
+		RetryAfterSeconds: int32(backoff.Exponential(attempts, baseRetryDelay, maxRetryDelay).Seconds()),
+		ID:                delivery.ID,
+	})
+}
+
+// send posts a signed delivery. Any 2xx response counts as received.
+func (d *Dispatcher) send(ctx context.Context, delivery ClaimDueDeliveriesRow) (int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, delivery.Url, bytes.NewReader(delivery.Payload))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "assessment-webhooks/1.0")
+	req.Header.Set(headerEvent, delivery.EventType)
+	req.Header.Set(headerEventID, delivery.EventID)
+	req.Header.Set(headerDelivery, fmt.Sprint(delivery.ID))
+	req.Header.Set(headerSignature, sign(delivery.Secret, time.Now().Unix(), delivery.Payload))
+
+	resp, err := d.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("subscriber responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,113 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+)
+
+var errBlockedAddress = errors.New("webhook: destination address is not public")
+
+// nonPublicPrefixes are ranges that are reachable only from inside the
+// network, or not routable at all, beyond what netip already classifies.
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("100.64.0.0/10"),
+	netip.MustParsePrefix("192.0.0.0/24"),
+	netip.MustParsePrefix("198.18.0.0/15"),
+	netip.MustParsePrefix("240.0.0.0/4"),
+	netip.MustParsePrefix("64:ff9b::/96"),
+	netip.MustParsePrefix("2001:db8::/32"),
+}
+
+// isPublicAddr reports whether addr is on the public internet, so that a
+// subscription cannot be used to reach the server's own network or a cloud
+// metadata service.
+func isPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsValid() || addr.IsUnspecified() || addr.IsLoopback() || addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() || addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() || addr.IsMulticast() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
+
+// checkHost rejects subscription URLs that obviously point inside the
+// network. It is only early feedback: a public name can resolve to a
+// private address, so the dialer checks again on every connection.
+func checkHost(u *url.URL) error {
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errBlockedAddress
+	}
+	if addr, err := netip.ParseAddr(host); err == nil && !isPublicAddr(addr) {
+		return errBlockedAddress
+	}
+	return nil
+}
+
+// controlPublicOnly runs after DNS resolution and before each connect, so
+// it sees the address actually dialled and DNS rebinding cannot get round
+// it.
+func controlPublicOnly(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil || !isPublicAddr(addrPort.Addr()) {
+		return errBlockedAddress
+	}
+	return nil
+}
+
+// newClient builds the HTTP client deliveries go out on. It ignores proxy
+// settings, which would otherwise hide the real destination from the dialer.
+func newClient() *http.Client {
+	dialer := &net.Dialer{
+		Timeout: requestTimeout,
+		Control: controlPublicOnly,
+	}
+	return &http.Client{
+		Timeout: requestTimeout,
+		Transport: &http.Transport{
+			Proxy:                 nil,
+			DialContext:           dialer.DialContext,
+			TLSHandshakeTimeout:   requestTimeout,
+			ResponseHeaderTimeout: requestTimeout,
+			MaxIdleConnsPerHost:   2,
+			IdleConnTimeout:       90 * time.Second,
+		},
+		// A redirect would send the signed body somewhere the admin did
+		// not register.
+		CheckRedirect: func(*http.Request, []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// describeFailure says why an attempt failed without repeating anything
+// from the subscriber's response or the network, so delivery logs cannot be
+// used to read from hosts the server can reach.
+func describeFailure(statusCode int, err error) string {
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	switch {
+	case errors.Is(err, errBlockedAddress):
+		return "destination address is not allowed"
+	case statusCode != 0:
+		return "subscriber responded with a non-2xx status"
+	case errors.As(err, &dnsErr):
+		return "host could not be resolved"
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "request timed out"
+	}
+	return "request failed"
+}
@@ -0,0 +1,360 @@
+package webhook
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/pkg/events"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	minSecretLength     = 16
+	maxSecretLength     = 100
+	defaultDeliveryList = 100
+	maxDeliveryList     = 1000
+)
+
+var deliveryStatuses = []string{
+	string(WebhookDeliveryStatusPending),
+	string(WebhookDeliveryStatusSucceeded),
+	string(WebhookDeliveryStatusFailed),
+}
+
+type WebhookHandler struct {
+	queries *Queries
+}
+
+func NewWebhookHandler(queries *Queries) *WebhookHandler {
+	return &WebhookHandler{
+		queries: queries,
+	}
+}
+
+// Subscription is a webhook subscription as shown to admins. The secret is
+// only shown when the subscription is created.
+type Subscription struct {
+	ID         int32     `json:"id"`
+	URL        string    `json:"url"`
+	EventTypes []string  `json:"event_types"`
+	Active     bool      `json:"active"`
+	CreatedBy  *int32    `json:"created_by"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+func presentSubscription(s WebhookSubscription) Subscription {
+	var createdBy *int32
+	if s.CreatedBy.Valid {
+		createdBy = &s.CreatedBy.Int32
+	}
+	return Subscription{
+		ID:         s.ID,
+		URL:        s.Url,
+		EventTypes: s.EventTypes,
+		Active:     s.Active,
+		CreatedBy:  createdBy,
+		CreatedAt:  s.CreatedAt.Time,
+		UpdatedAt:  s.UpdatedAt.Time,
+	}
+}
+
+// Delivery is one event on its way to a subscription, as shown to admins.
+type Delivery struct {
+	ID             int32                 `json:"id"`
+	SubscriptionID int32                 `json:"subscription_id"`
+	EventID        string                `json:"event_id"`
+	EventType      string                `json:"event_type"`
+	Payload        json.RawMessage       `json:"payload"`
+	Status         WebhookDeliveryStatus `json:"status"`
+	Attempts       int32                 `json:"attempts"`
+	NextAttemptAt  *time.Time            `json:"next_attempt_at"`
+	CreatedAt      time.Time             `json:"created_at"`
+	DeliveredAt    *time.Time            `json:"delivered_at"`
+}
+
+func presentDelivery(d WebhookDelivery) Delivery {
+	delivery := Delivery{
+		ID:             d.ID,
+		SubscriptionID: d.SubscriptionID,
+		EventID:        d.EventID,
+		EventType:      d.EventType,
+		Payload:        d.Payload,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		CreatedAt:      d.CreatedAt.Time,
+	}
+	if d.Status == WebhookDeliveryStatusPending {
+		delivery.NextAttemptAt = &d.NextAttemptAt.Time
+	}
+	if d.DeliveredAt.Valid {
+		delivery.DeliveredAt = &d.DeliveredAt.Time
+	}
+	return delivery
+}
+
+// validateSubscription checks a subscription's target and the events it
+// asks for.
+func validateSubscription(rawURL string, eventTypes []string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return errors.New("URL must be an absolute http or https URL")
+	}
+	if u.User != nil {
+		return errors.New("URL must not contain credentials; use the signing secret instead")
+	}
+	if checkHost(u) != nil {
+		return errors.New("URL must point to a public host")
+	}
+	if len(eventTypes) == 0 {
+		return errors.New("at least one event type is required")
+	}
+	for i, eventType := range eventTypes {
+		if !slices.Contains(events.Types, eventType) {
+			return fmt.Errorf("unknown event type %q; expected one of %s", eventType, strings.Join(events.Types, ", "))
+		}
+		if slices.Contains(eventTypes[:i], eventType) {
+			return fmt.Errorf("event type %q appears more than once", eventType)
+		}
+	}
+	return nil
+}
+
+func (h *WebhookHandler) CreateSubscription(c *gin.Context) {
+	userIDStr, exists := c.Get("userID")
+	if !exists {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "User not authenticated"})
+		return
+	}
+	userID, err := strconv.Atoi(userIDStr.(string))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid user ID"})
+		return
+	}
+
+	var req struct {
+		URL        string   `json:"url" binding:"required"`
+		Secret     string   `json:"secret"`
+		EventTypes []string `json:"event_types" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.URL = strings.TrimSpace(req.URL)
+	if err := validateSubscription(req.URL, req.EventTypes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if req.Secret == "" {
+		req.Secret, err = newSecret()
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to generate secret"})
+			return
+		}
+	} else if len(req.Secret) < minSecretLength || len(req.Secret) > maxSecretLength {
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Secret must be between %d and %d characters", minSecretLength, maxSecretLength)})
+		return
+	}
+
+	subscription, err := h.queries.CreateSubscription(c, CreateSubscriptionParams{
+		Url:        req.URL,
+		Secret:     req.Secret,
+		EventTypes: req.EventTypes,
+		CreatedBy:  pgtype.Int4{Int32: int32(userID), Valid: true},
+	})
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to create subscription"})
+		return
+	}
+
+	c.JSON(http.StatusCreated, gin.H{
+		"subscription": presentSubscription(subscription),
+		"secret":       subscription.Secret,
+	})
+}
+
+func (h *WebhookHandler) ListSubscriptions(c *gin.Context) {
+	subscriptions, err := h.queries.ListSubscriptions(c)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve subscriptions"})
+		return
+	}
+
+	presented := make([]Subscription, 0, len(subscriptions))
+	for _, subscription := range subscriptions {
+		presented = append(presented, presentSubscription(subscription))
+	}
+	c.JSON(http.StatusOK, presented)
+}
+
+func (h *WebhookHandler) UpdateSubscription(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subscription ID"})
+		return
+	}
+
+	var req struct {
+		URL        string   `json:"url" binding:"required"`
+		EventTypes []string `json:"event_types" binding:"required"`
+		Active     *bool    `json:"active" binding:"required"`
+	}
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	req.URL = strings.TrimSpace(req.URL)
+	if err := validateSubscription(req.URL, req.EventTypes); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	subscription, err := h.queries.UpdateSubscription(c, UpdateSubscriptionParams{
+		Url:        req.URL,
+		EventTypes: req.EventTypes,
+		Active:     *req.Active,
+		ID:         int32(id),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Subscription not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to update subscription"})
+		return
+	}
+	c.JSON(http.StatusOK, presentSubscription(subscription))
+}
+
+func (h *WebhookHandler) DeleteSubscription(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subscription ID"})
+		return
+	}
+
+	deleted, err := h.queries.DeleteSubscription(c, int32(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to delete subscription"})
+		return
+	}
+	if deleted == 0 {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Subscription not found"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "Subscription deleted"})
+}
+
+func (h *WebhookHandler) ListDeliveries(c *gin.Context) {
+	params := ListDeliveriesParams{RowLimit: defaultDeliveryList}
+	if s := c.Query("subscription_id"); s != "" {
+		subscriptionID, err := strconv.ParseInt(s, 10, 32)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subscription ID"})
+			return
+		}
+		params.SubscriptionID = pgtype.Int4{Int32: int32(subscriptionID), Valid: true}
+	}
+	if s := c.Query("status"); s != "" {
+		if !slices.Contains(deliveryStatuses, s) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Status must be one of %s", strings.Join(deliveryStatuses, ", "))})
+			return
+		}
+		params.Status = NullWebhookDeliveryStatus{WebhookDeliveryStatus: WebhookDeliveryStatus(s), Valid: true}
+	}
+	if s := c.Query("limit"); s != "" {
+		limit, err := strconv.Atoi(s)
+		if err != nil || limit < 1 || limit > maxDeliveryList {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("Limit must be between 1 and %d", maxDeliveryList)})
+			return
+		}
+		params.RowLimit = int32(limit)
+	}
+
+	deliveries, err := h.queries.ListDeliveries(c, params)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to retrieve deliveries"})
+		return
+	}
+	presented := make([]Delivery, 0, len(deliveries))
+	for _, delivery := range deliveries {
+		presented = append(presented, presentDelivery(delivery))
+	}
+	c.JSON(http.StatusOK, presented)
+}
+
+func (h *WebhookHandler) GetDelivery(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+
+	delivery, err := h.queries.GetDelivery(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load delivery"})
+		return
+	}
+	attempts, err := h.queries.ListDeliveryAttempts(c, delivery.ID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to load delivery attempts"})
+		return
+	}
+	if attempts == nil {
+		attempts = []WebhookDeliveryAttempt{}
+	}
+
+	c.JSON(http.StatusOK, gin.H{
+		"delivery": presentDelivery(delivery),
+		"attempts": attempts,
+	})
+}
+
+func (h *WebhookHandler) ReplayDelivery(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid delivery ID"})
+		return
+	}
+
+	delivery, err := h.queries.ReplayDelivery(c, int32(id))
+	if errors.Is(err, pgx.ErrNoRows) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "Delivery not found or not failed"})
+		return
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replay delivery"})
+		return
+	}
+	c.JSON(http.StatusOK, presentDelivery(delivery))
+}
+
+func (h *WebhookHandler) ReplayFailedDeliveries(c *gin.Context) {
+	id, err := strconv.ParseInt(c.Param("id"), 10, 32)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid subscription ID"})
+		return
+	}
+
+	replayed, err := h.queries.ReplayFailedDeliveries(c, int32(id))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "Failed to replay deliveries"})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"replayed": replayed})
+}
@@ -0,0 +1,97 @@
+-- name: CreateSubscription :one
+INSERT INTO webhook_subscriptions (url, secret, event_types, created_by)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: ListSubscriptions :many
+SELECT * FROM webhook_subscriptions
+ORDER BY id;
+
+-- name: UpdateSubscription :one
+UPDATE webhook_subscriptions
+SET url = @url,
+    event_types = @event_types,
+    active = @active,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = @id
+RETURNING *;
+
+-- name: DeleteSubscription :execrows
+DELETE FROM webhook_subscriptions WHERE id = $1;
+
+-- name: CreateDeliveries :execrows
+-- Queue an event for every active subscription that wants it
+INSERT INTO webhook_deliveries (subscription_id, event_id, event_type, payload)
+SELECT s.id, @event_id, @event_type, @payload
+FROM webhook_subscriptions s
+WHERE s.active AND @event_type::text = ANY(s.event_types)
+ON CONFLICT (subscription_id, event_id) DO NOTHING;
+
+-- name: ClaimDueDeliveries :many
+-- Take a batch of due deliveries, pushing their next attempt out by the
+-- lease so that a worker dying mid-request leaves them to be retried
+WITH due AS (
+  SELECT d.id
+  FROM webhook_deliveries d
+  JOIN webhook_subscriptions s ON s.id = d.subscription_id AND s.active
+  WHERE d.status = 'pending' AND d.next_attempt_at <= CURRENT_TIMESTAMP
+  ORDER BY d.next_attempt_at
+  LIMIT @batch_size
+  FOR UPDATE OF d SKIP LOCKED
+)
+UPDATE webhook_deliveries d
+SET next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => @lease_seconds::int)
+FROM due, webhook_subscriptions s
+WHERE d.id = due.id AND s.id = d.subscription_id
+RETURNING d.id, d.event_id, d.event_type, d.payload, d.attempts, s.url, s.secret;
+
+-- name: RecordDeliveryAttempt :exec
+INSERT INTO webhook_delivery_attempts (delivery_id, status_code, error, duration_ms)
+VALUES ($1, $2, $3, $4);
+
+-- name: MarkDeliverySucceeded :exec
+UPDATE webhook_deliveries
+SET status = 'succeeded',
+    attempts = attempts + 1,
+    delivered_at = CURRENT_TIMESTAMP
+WHERE id = $1;
+
+-- name: MarkDeliveryFailed :exec
+-- Schedule another attempt, or give up once retries have run out
+UPDATE webhook_deliveries
+SET attempts = attempts + 1,
+    status = CASE WHEN @give_up::boolean THEN 'failed'::webhook_delivery_status ELSE 'pending'::webhook_delivery_status END,
+    next_attempt_at = CURRENT_TIMESTAMP + make_interval(secs => @retry_after_seconds::int)
+WHERE id = @id;
+
+-- name: GetDelivery :one
+SELECT * FROM webhook_deliveries WHERE id = $1;
+
+-- name: ListDeliveries :many
+-- Deliveries newest first, optionally narrowed to a subscription or status
+SELECT * FROM webhook_deliveries
+WHERE (sqlc.narg(subscription_id)::int IS NULL OR subscription_id = sqlc.narg(subscription_id)::int)
+  AND (sqlc.narg(status)::webhook_delivery_status IS NULL OR status = sqlc.narg(status)::webhook_delivery_status)
+ORDER BY created_at DESC, id DESC
+LIMIT @row_limit;
+
+-- name: ListDeliveryAttempts :many
+SELECT * FROM webhook_delivery_attempts
+WHERE delivery_id = $1
+ORDER BY attempted_at, id;
+
+-- name: ReplayDelivery :one
+-- Give a failed delivery a fresh set of retries
+UPDATE webhook_deliveries
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND status = 'failed'
+RETURNING *;
+
+-- name: ReplayFailedDeliveries :execrows
+UPDATE webhook_deliveries
+SET status = 'pending',
+    attempts = 0,
+    next_attempt_at = CURRENT_TIMESTAMP
+WHERE subscription_id = $1 AND status = 'failed';
@@ -0,0 +1,22 @@
+package webhook
+
+import (
+	"backend/app/middleware"
+
+	"github.com/gin-gonic/gin"
+)
+
+func SetupRoutesWebhook(r *gin.Engine, webhookHandler *WebhookHandler) {
+	admin := r.Group("webhooks")
+	admin.Use(middleware.AuthMiddleware(), middleware.AdminMiddleware())
+	admin.POST("", webhookHandler.CreateSubscription)
+	admin.GET("", webhookHandler.ListSubscriptions)
+	admin.PUT("/:id", webhookHandler.UpdateSubscription)
+	admin.DELETE("/:id", webhookHandler.DeleteSubscription)
+	admin.POST("/:id/replay", webhookHandler.ReplayFailedDeliveries)
+
+	// Deliveries
+	admin.GET("/deliveries", webhookHandler.ListDeliveries)
+	admin.GET("/deliveries/:id", webhookHandler.GetDelivery)
+	admin.POST("/deliveries/:id/replay", webhookHandler.ReplayDelivery)
+}
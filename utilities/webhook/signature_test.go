@@ -0,0 +1,49 @@
+package webhook
+
+import "testing"
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name      string
+		secret    string
+		timestamp int64
+		body      string
+		want      string
+	}{
+		{
+			name:      "event body",
+			secret:    "whsec_test",
+			timestamp: 1700000000,
+			body:      `{"event":"candidate.completed"}`,
+			want:      "t=1700000000,v1=6427daba7b0505a289e3f920039d16b06b4a2bea1a22d0a385c974ab1ce32c0c",
+		},
+		{
+			name:      "empty body",
+			secret:    "whsec_test",
+			timestamp: 1700000000,
+			body:      "",
+			want:      "t=1700000000,v1=5967f3c560522fa40cf2876ebc3c3a08551dd6959aaade3b413460591895bdcc",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sign(tt.secret, tt.timestamp, []byte(tt.body)); got != tt.want {
+				t.Errorf("sign = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSignCoversTimestampAndSecret(t *testing.T) {
+	body := []byte(`{"event":"candidate.completed"}`)
+	base := sign("whsec_test", 1700000000, body)
+	if sign("whsec_test", 1700000001, body) == base {
+		t.Error("changing the timestamp did not change the signature")
+	}
+	if sign("whsec_other", 1700000000, body) == base {
+		t.Error("changing the secret did not change the signature")
+	}
+	if sign("whsec_test", 1700000000, []byte(`{"event":"candidate.completed "}`)) == base {
+		t.Error("changing the body did not change the signature")
+	}
+}